@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// --- CLIPBOARD ---
+
+// EnvOSC52 forces yanking through the terminal (OSC52) when set to 1/true
+const EnvOSC52 = "K9S_DECK_OSC52"
+
+// OSC52MaxBytes is the largest payload sent via OSC52; many terminals drop
+// longer sequences (xterm and tmux cap them around 100 KB)
+const OSC52MaxBytes = 74994 // 100000 bytes once base64 encoded
+
+// ForceOSC52 skips the native clipboard utilities (--osc52, config or $K9S_DECK_OSC52)
+var ForceOSC52 bool
+
+// osc52Out is where OSC52 sequences are written (the terminal Bubble Tea renders to)
+var osc52Out io.Writer = os.Stdout
+
+// copyToClipboard copies content to the system clipboard, trying the
+// platform's clipboard utility first and the terminal (OSC52) after that,
+// which also works over SSH and inside tmux. It reports whether OSC52 was used.
+func copyToClipboard(content string) (bool, error) {
+	// Strip ANSI color codes before copying
+	cleanContent := stripANSI(content)
+
+	if !ForceOSC52 {
+		for _, cmd := range nativeClipboardCmds(false) {
+			cmd.Stdin = strings.NewReader(cleanContent)
+			if err := cmd.Run(); err == nil {
+				return false, nil
+			}
+		}
+	}
+	return true, writeOSC52(osc52Out, cleanContent)
+}
+
+// readClipboard reads the system clipboard with the first of the
+// platform's clipboard utilities that works
+func readClipboard() (string, error) {
+	cmds := nativeClipboardCmds(true)
+	if len(cmds) == 0 {
+		return "", fmt.Errorf("unsupported platform")
+	}
+	var errs []error
+	for _, cmd := range cmds {
+		out, err := cmd.Output()
+		if err == nil {
+			return string(out), nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", cmd.Args[0], err))
+	}
+	return "", errors.Join(errs...)
+}
+
+// nativeClipboardCmds returns the platform's clipboard utilities that copy
+// from stdin (or, with paste, print the clipboard), in the order to try
+// them: wl-copy/wl-paste on Wayland, then xclip and xsel
+func nativeClipboardCmds(paste bool) []*exec.Cmd {
+	switch runtime.GOOS {
+	case "darwin":
+		if paste {
+			return []*exec.Cmd{exec.Command("pbpaste")}
+		}
+		return []*exec.Cmd{exec.Command("pbcopy")}
+	case "linux", "freebsd", "openbsd", "netbsd":
+		var cmds []*exec.Cmd
+		if os.Getenv("WAYLAND_DISPLAY") != "" {
+			if paste {
+				cmds = append(cmds, exec.Command("wl-paste", "--no-newline"))
+			} else {
+				cmds = append(cmds, exec.Command("wl-copy"))
+			}
+		}
+		if paste {
+			return append(cmds, exec.Command("xclip", "-selection", "clipboard", "-o"), exec.Command("xsel", "--clipboard", "--output"))
+		}
+		return append(cmds, exec.Command("xclip", "-selection", "clipboard"), exec.Command("xsel", "--clipboard", "--input"))
+	case "windows":
+		if paste {
+			return []*exec.Cmd{exec.Command("powershell", "-NoProfile", "-Command", "Get-Clipboard")}
+		}
+		return []*exec.Cmd{exec.Command("clip")}
+	}
+	return nil
+}
+
+// writeOSC52 asks the terminal to set its clipboard to content
+func writeOSC52(w io.Writer, content string) error {
+	if len(content) > OSC52MaxBytes {
+		return fmt.Errorf("%d bytes is too large for the terminal clipboard (OSC52 limit %d)", len(content), OSC52MaxBytes)
+	}
+	_, err := io.WriteString(w, osc52Sequence(content, os.Getenv("TMUX") != ""))
+	return err
+}
+
+// osc52Sequence builds the OSC52 escape sequence for content. Inside tmux it
+// is wrapped in a DCS passthrough so it reaches the outer terminal (needs
+// tmux's allow-passthrough option).
+func osc52Sequence(content string, tmux bool) string {
+	seq := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(content)) + "\a"
+	if tmux {
+		return "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	}
+	return seq
+}
+
+// osc52FromEnv reports whether $K9S_DECK_OSC52 forces OSC52
+func osc52FromEnv() bool {
+	switch strings.ToLower(os.Getenv(EnvOSC52)) {
+	case "1", "true", "yes", "on":
+		return true
+	}
+	return false
+}
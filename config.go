@@ -0,0 +1,445 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	yamlv3 "gopkg.in/yaml.v3"
+	"sigs.k8s.io/yaml"
+
+	"github.com/devpopsdotin/k9s-deck/internal/parser"
+)
+
+// --- USER CONFIG ---
+
+// EnvConfigFile overrides the config file location
+const EnvConfigFile = "K9S_DECK_CONFIG"
+
+// ConfigSaveDebounce is how long after the last change the config is written back
+const ConfigSaveDebounce = time.Second
+
+// configFilePath is where monitored targets, the log format and the pane
+// split are written back to, "" when no config location could be resolved
+var configFilePath string
+
+// Config is the optional user configuration file (YAML)
+type Config struct {
+	// Context, Namespace and Targets are monitored when no arguments are given.
+	// They are written back when targets are added or removed, the namespace
+	// or context is switched, and by :save. A target "sts/<name>" is a StatefulSet.
+	Context   string   `json:"context,omitempty"`
+	Namespace string   `json:"namespace,omitempty"`
+	Targets   []string `json:"targets,omitempty"`
+
+	// LeftPaneRatio is the share of the width taken by the resource list
+	// (default 0.35); written back by '<' and '>'
+	LeftPaneRatio float64 `json:"leftPaneRatio,omitempty"`
+
+	// Tabs overrides the detail tabs per resource type, in display order
+	// e.g. {"POD": ["logs", "yaml", "events"]}
+	Tabs map[string][]string `json:"tabs,omitempty"`
+
+	// RawLogs starts with raw (unformatted) logs; written back by the 'f' toggle
+	RawLogs bool `json:"rawLogs,omitempty"`
+
+	// LineNumbers starts with line numbers in the details pane ('#' toggles them)
+	LineNumbers bool `json:"lineNumbers,omitempty"`
+
+	// LogFields are the JSON log keys shown as columns by 'J', comma-separated
+	// and in order (default "level,msg,ts"); nested keys use dots, e.g. http.status
+	LogFields string `json:"logFields,omitempty"`
+
+	// LogPrefixStyle is how aggregated log lines name their pod: full
+	// ([web-55c74d7f8-zn5fd]), short ([55c74d7f8-zn5fd], default) or pod-only ([zn5fd])
+	LogPrefixStyle string `json:"logPrefixStyle,omitempty"`
+
+	// LogLevels adds level keywords to the detected ones with their color: a
+	// built-in level (error, warn, ...), red, yellow, green, gray, a hex color
+	// or an ANSI color number, e.g. {"CRITICAL": "red", "NOTICE": "info"}
+	LogLevels map[string]string `json:"logLevels,omitempty"`
+
+	// HideUnleveledLogs hides log lines without a detectable level while the
+	// 'L' level filter is active (shown by default)
+	HideUnleveledLogs bool `json:"hideUnleveledLogs,omitempty"`
+
+	// Theme is the color theme: dark (default), light, solarized-dark or solarized-light
+	Theme string `json:"theme,omitempty"`
+
+	// SyntaxStyle is the Chroma style YAML/JSON is highlighted with, instead of
+	// the theme's (e.g. monokai; $K9S_DECK_SYNTAX_STYLE wins). SyntaxFormatter
+	// picks terminal, terminal16, terminal256 (default) or terminal16m (truecolor).
+	SyntaxStyle     string `json:"syntaxStyle,omitempty"`
+	SyntaxFormatter string `json:"syntaxFormatter,omitempty"`
+
+	// ASCII forces ASCII markers (true) or emoji icons (false) instead of auto-detecting
+	ASCII *bool `json:"ascii,omitempty"`
+
+	// Keybindings remaps shortcuts by action name, e.g. {"scale": "m"}
+	// (actions: scale, rollback, restart, addTarget, removeTarget,
+	// toggleFormat, filter, yank)
+	Keybindings map[string]string `json:"keybindings,omitempty"`
+
+	// ConfirmActions asks before scale, restart and rollback run, e.g.
+	// "Scale web from 3 to 10 replicas? [y/N]" (they run right away by default)
+	ConfirmActions bool `json:"confirmActions,omitempty"`
+
+	// OSC52 copies through the terminal instead of the native clipboard
+	// utility (for SSH sessions without one); same as --osc52
+	OSC52 bool `json:"osc52,omitempty"`
+
+	// RefreshInterval is how often targets are refreshed, e.g. "5s" (default
+	// 1s, at least 250ms); --refresh wins
+	RefreshInterval string `json:"refreshInterval,omitempty"`
+
+	// MaxLogLines caps the log lines kept in the details pane, following or
+	// not; older ones are dropped (default 5000, at least 100)
+	MaxLogLines int `json:"maxLogLines,omitempty"`
+
+	// RestartWarnThreshold is the pod restart count shown in red in the
+	// sidebar from (default 5)
+	RestartWarnThreshold int `json:"restartWarnThreshold,omitempty"`
+
+	// QPS and Burst tune the client-side API rate limiter (client-go defaults: 5/10)
+	QPS   float32 `json:"qps,omitempty"`
+	Burst int     `json:"burst,omitempty"`
+}
+
+// defaultConfigPath returns <user config dir>/k9s-deck/config.yaml
+func defaultConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "k9s-deck", "config.yaml"), nil
+}
+
+// resolveConfigPath returns path, $K9S_DECK_CONFIG or the default location,
+// and whether the file was asked for explicitly
+func resolveConfigPath(path string) (string, bool, error) {
+	if path == "" {
+		path = os.Getenv(EnvConfigFile)
+	}
+	if path != "" {
+		return path, true, nil
+	}
+	path, err := defaultConfigPath()
+	return path, false, err
+}
+
+// loadConfig reads the config file from path, $K9S_DECK_CONFIG or the default
+// location. A missing file at the default location is not an error.
+func loadConfig(path string) (Config, error) {
+	var cfg Config
+
+	path, explicit, err := resolveConfigPath(path)
+	if err != nil {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !explicit && errors.Is(err, os.ErrNotExist) {
+			return cfg, nil
+		}
+		return cfg, fmt.Errorf("cannot read config %s: %w", path, err)
+	}
+	if err := yaml.UnmarshalStrict(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// applyConfig installs the user config over the built-in defaults
+func applyConfig(cfg Config) error {
+	for resourceType, tabs := range cfg.Tabs {
+		resourceType = strings.ToUpper(resourceType)
+		available, ok := availableTabs[resourceType]
+		if !ok {
+			return fmt.Errorf("tabs: unsupported resource type %q", resourceType)
+		}
+		if len(tabs) == 0 {
+			return fmt.Errorf("tabs: %s needs at least one tab", resourceType)
+		}
+
+		normalized := make([]string, 0, len(tabs))
+		for _, tab := range tabs {
+			tab = strings.ToLower(tab)
+			if !containsString(available, tab) {
+				return fmt.Errorf("tabs: %s does not support tab %q (available: %s)", resourceType, tab, strings.Join(available, ", "))
+			}
+			normalized = append(normalized, tab)
+		}
+		tabSets[resourceType] = normalized
+	}
+
+	for _, target := range cfg.Targets {
+		if !isValidTarget(target) {
+			return fmt.Errorf("targets: invalid deployment name %q", target)
+		}
+	}
+	if cfg.Theme != "" {
+		if err := setTheme(cfg.Theme); err != nil {
+			return fmt.Errorf("theme: %w", err)
+		}
+	}
+	if cfg.LeftPaneRatio != 0 {
+		if cfg.LeftPaneRatio < MinLeftPaneRatio || cfg.LeftPaneRatio > MaxLeftPaneRatio {
+			return fmt.Errorf("leftPaneRatio: %v is not between %v and %v", cfg.LeftPaneRatio, MinLeftPaneRatio, MaxLeftPaneRatio)
+		}
+		LeftPaneWidthRatio = cfg.LeftPaneRatio
+	}
+	if cfg.RefreshInterval != "" {
+		d, err := parseRefreshInterval(cfg.RefreshInterval)
+		if err != nil {
+			return fmt.Errorf("refreshInterval: %w", err)
+		}
+		RefreshInterval = d
+	}
+	if cfg.MaxLogLines != 0 {
+		if err := checkMaxLogLines(cfg.MaxLogLines); err != nil {
+			return fmt.Errorf("maxLogLines: %w", err)
+		}
+		MaxLogLines = cfg.MaxLogLines
+	}
+	if cfg.LogFields != "" {
+		fields, err := parseLogFields(cfg.LogFields)
+		if err != nil {
+			return fmt.Errorf("logFields: %w", err)
+		}
+		parser.LogFields = fields
+	}
+	switch cfg.LogPrefixStyle {
+	case "":
+	case parser.PrefixFull, parser.PrefixShort, parser.PrefixPodOnly:
+		parser.LogPrefixStyle = cfg.LogPrefixStyle
+	default:
+		return fmt.Errorf("logPrefixStyle: %q is not %s, %s or %s", cfg.LogPrefixStyle, parser.PrefixFull, parser.PrefixShort, parser.PrefixPodOnly)
+	}
+	if cfg.RestartWarnThreshold < 0 {
+		return fmt.Errorf("restartWarnThreshold: %d is negative", cfg.RestartWarnThreshold)
+	}
+	if cfg.RestartWarnThreshold > 0 {
+		RestartWarnThreshold = cfg.RestartWarnThreshold
+	}
+	return nil
+}
+
+// launchTarget picks what to monitor from the positional arguments, then
+// the config, then the demo defaults when $KUBECONFIG is set; ok is false
+// when none applies
+func launchTarget(args []string, cfg Config) (kubeContext, ns, deployment string, ok bool) {
+	switch {
+	case len(args) >= 3:
+		return args[0], args[1], args[2], true
+	case cfg.Context != "" && cfg.Namespace != "" && len(cfg.Targets) > 0:
+		return cfg.Context, cfg.Namespace, cfg.Targets[0], true
+	case os.Getenv("KUBECONFIG") != "":
+		return "kind-kind", "default", "hello-app", true
+	}
+	return "", "", "", false
+}
+
+// startTargets returns the deployments to monitor at launch. The config's
+// targets are kept next to the one from the arguments as long as they
+// belong to the same context and namespace.
+func startTargets(cfg Config, deployment string) []string {
+	targets := []string{deployment}
+	if cfg.Context != Context || cfg.Namespace != Namespace {
+		return targets
+	}
+	for _, t := range cfg.Targets {
+		if !containsString(targets, t) {
+			targets = append(targets, t)
+		}
+	}
+	return targets
+}
+
+// --- CONFIG WRITE-BACK ---
+
+// persistedConfig is the part of the config the app writes back
+type persistedConfig struct {
+	context       string
+	namespace     string
+	targets       []string
+	rawLogs       bool
+	leftPaneRatio float64 // 0 keeps the file's
+}
+
+type configSaveMsg struct {
+	seq int
+}
+
+// configSavedMsg reports the outcome of :save
+type configSavedMsg struct {
+	path string
+	err  error
+}
+
+// scheduleConfigSave writes the config back ConfigSaveDebounce after the
+// last of a series of changes (several :add in a row cause one write)
+func (m *model) scheduleConfigSave() tea.Cmd {
+	if configFilePath == "" {
+		return nil
+	}
+	m.configSaveSeq++
+	seq := m.configSaveSeq
+	return tea.Tick(ConfigSaveDebounce, func(t time.Time) tea.Msg {
+		return configSaveMsg{seq: seq}
+	})
+}
+
+// persisted returns what the config write-back stores: the context,
+// namespace, targets and log format, and the pane split if it was changed
+// with '<' and '>'
+func (m model) persisted() persistedConfig {
+	pc := persistedConfig{
+		context:   Context,
+		namespace: Namespace,
+		targets:   append([]string(nil), m.targets...),
+		rawLogs:   !m.logFormatMode,
+	}
+	if m.leftPaneRatio != LeftPaneWidthRatio {
+		pc.leftPaneRatio = m.leftPaneRatio
+	}
+	return pc
+}
+
+// autoSaveConfigCmd writes the config back, unless a newer change is
+// pending; failures are only logged
+func (m model) autoSaveConfigCmd(msg configSaveMsg) tea.Cmd {
+	if msg.seq != m.configSaveSeq {
+		return nil
+	}
+	pc, path := m.persisted(), configFilePath
+	return func() tea.Msg {
+		if err := writeBackConfig(path, pc); err != nil {
+			slog.Warn("failed to write back config", "path", path, "error", err)
+		}
+		return nil
+	}
+}
+
+// saveConfigCmd writes the config back right away for :save and reports
+// how it went
+func (m model) saveConfigCmd() tea.Cmd {
+	pc, path := m.persisted(), configFilePath
+	return func() tea.Msg {
+		if path == "" {
+			return configSavedMsg{err: errors.New("no config location, use --config")}
+		}
+		err := writeBackConfig(path, pc)
+		if err != nil {
+			slog.Warn("failed to write back config", "path", path, "error", err)
+		}
+		return configSavedMsg{path: path, err: err}
+	}
+}
+
+// writeBackConfig updates the persisted keys of the config file at path,
+// creating it if needed. Other keys and comments are left as they are; a
+// symlinked config is written through to its target.
+func writeBackConfig(path string, pc persistedConfig) error {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	perm := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	var doc yamlv3.Node
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := yamlv3.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	if doc.Kind == 0 {
+		doc.Kind = yamlv3.DocumentNode
+	}
+	if len(doc.Content) == 0 {
+		doc.Content = []*yamlv3.Node{{Kind: yamlv3.MappingNode, Tag: "!!map"}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yamlv3.MappingNode {
+		return fmt.Errorf("config %s is not a mapping", path)
+	}
+
+	targets := &yamlv3.Node{Kind: yamlv3.SequenceNode, Tag: "!!seq", Style: yamlv3.FlowStyle}
+	for _, t := range pc.targets {
+		targets.Content = append(targets.Content, &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: t})
+	}
+	setConfigKey(root, "context", &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: pc.context})
+	setConfigKey(root, "namespace", &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: pc.namespace})
+	setConfigKey(root, "targets", targets)
+	if pc.rawLogs || hasConfigKey(root, "rawLogs") {
+		setConfigKey(root, "rawLogs", &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(pc.rawLogs)})
+	}
+	if pc.leftPaneRatio != 0 {
+		setConfigKey(root, "leftPaneRatio", &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!float", Value: strconv.FormatFloat(pc.leftPaneRatio, 'f', -1, 64)})
+	}
+
+	var buf bytes.Buffer
+	enc := yamlv3.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// hasConfigKey reports whether a mapping node has key
+func hasConfigKey(mapping *yamlv3.Node, key string) bool {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return true
+		}
+	}
+	return false
+}
+
+// setConfigKey sets key of a mapping node to value, keeping the comments
+// of an existing entry
+func setConfigKey(mapping *yamlv3.Node, key string, value *yamlv3.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			old := mapping.Content[i+1]
+			value.HeadComment, value.LineComment, value.FootComment = old.HeadComment, old.LineComment, old.FootComment
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content,
+		&yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// containsString reports whether list contains s
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
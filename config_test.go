@@ -0,0 +1,91 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteBackConfigThroughSymlink(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "dotfiles", "config.yaml")
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(target, []byte("# my targets\ntargets: [web]\ntheme: light\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(dir, "config.yaml")
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writeBackConfig(link, persistedConfig{context: "kind-kind", namespace: "default", targets: []string{"web", "api"}}); err != nil {
+		t.Fatalf("writeBackConfig() error = %v", err)
+	}
+
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Fatalf("config should still be a symlink, got %v (%v)", info, err)
+	}
+	data, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	for _, want := range []string{"# my targets", "targets: [web, api]", "theme: light", "namespace: default"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("config should contain %q, got:\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"rawLogs", "leftPaneRatio"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("config should not get %s, got:\n%s", unwanted, got)
+		}
+	}
+	if info, err := os.Stat(target); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("config mode = %v, want 0600 kept", info.Mode().Perm())
+	}
+}
+
+func TestWriteBackConfigRawLogs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("rawLogs: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writeBackConfig(path, persistedConfig{targets: []string{"web"}}); err != nil {
+		t.Fatalf("writeBackConfig() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "rawLogs: false") {
+		t.Errorf("toggling back to formatted logs should be written, got:\n%s", data)
+	}
+}
+
+func TestKubeconfigFlagEnablesDemoTarget(t *testing.T) {
+	t.Setenv("KUBECONFIG", "")
+	if _, _, _, ok := launchTarget(nil, Config{}); ok {
+		t.Fatal("launchTarget() without arguments, config or KUBECONFIG should fail")
+	}
+
+	kubeconfig := filepath.Join(t.TempDir(), "kubeconfig")
+	if err := os.WriteFile(kubeconfig, []byte("apiVersion: v1\nkind: Config\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := setKubeconfig(kubeconfig); err != nil {
+		t.Fatalf("setKubeconfig() error = %v", err)
+	}
+	kubeContext, ns, deployment, ok := launchTarget(nil, Config{})
+	if !ok || kubeContext != "kind-kind" || ns != "default" || deployment != "hello-app" {
+		t.Errorf("launchTarget() after --kubeconfig = %q %q %q %v, want the demo target", kubeContext, ns, deployment, ok)
+	}
+
+	if err := setKubeconfig(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("setKubeconfig() of a missing file should fail")
+	}
+}
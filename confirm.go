@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tidwall/gjson"
+
+	"github.com/devpopsdotin/k9s-deck/internal/k8s"
+)
+
+// --- ACTION CONFIRMATION ---
+
+// ConfirmActions asks before scale, restart and rollback run, showing what
+// will change (config: confirmActions)
+var ConfirmActions bool
+
+// pendingAction is a command waiting for the y/N answer of its prompt
+type pendingAction struct {
+	input       string
+	helmRelease string
+	deployment  string
+}
+
+// actionPreviewMsg carries the prompt describing what a pending action changes
+type actionPreviewMsg struct {
+	action pendingAction
+	prompt string
+}
+
+// needsConfirmation reports whether verb asks before it runs: pod deletion
+// always does, scale, restart and rollback with ConfirmActions
+func needsConfirmation(verb string) bool {
+	switch verb {
+	case "delete-pod":
+		return true
+	case "scale", "undo", "restart", "rollback":
+		return ConfirmActions
+	}
+	return false
+}
+
+// previewActionCmd looks up the current state an action changes (replicas,
+// the deployed Helm revision) to phrase its prompt; failures show in the
+// details pane and nothing runs
+func previewActionCmd(kc k8s.Client, ns string, action pendingAction) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), CommandTimeout)
+		defer cancel()
+
+		parts := strings.Fields(action.input)
+		prompt, err := actionPrompt(ctx, kc, ns, parts[0], parts[1:], action)
+		if err != nil {
+			return detailsMsg{err: err}
+		}
+		return actionPreviewMsg{action: action, prompt: prompt}
+	}
+}
+
+// actionPrompt phrases the question for verb, e.g.
+// "Scale web-app from 3 to 10 replicas? [y/N] "
+func actionPrompt(ctx context.Context, kc k8s.Client, ns string, verb string, args []string, action pendingAction) (string, error) {
+	if kind, _ := parseTarget(action.deployment); action.deployment != "" {
+		if err := workloadActionError(kind, verb); err != nil {
+			return "", err
+		}
+	}
+	switch verb {
+	case "scale", "undo":
+		if len(args) < 1 || action.deployment == "" {
+			return "", fmt.Errorf("Usage: scale <replicas> with a deployment selected")
+		}
+		current, err := currentReplicas(ctx, kc, ns, action.deployment)
+		if err != nil {
+			return "", fmt.Errorf("Cannot read %s: %v", action.deployment, err)
+		}
+		if fmt.Sprint(current) == args[0] {
+			return fmt.Sprintf("%s already has %d replicas. Scale anyway? [y/N] ", action.deployment, current), nil
+		}
+		return fmt.Sprintf("Scale %s from %d to %s replicas? [y/N] ", action.deployment, current, args[0]), nil
+	case "restart":
+		if action.deployment == "" {
+			return "", fmt.Errorf("No deployment selected")
+		}
+		out, err := getWorkload(ctx, kc, ns, action.deployment)
+		if err != nil {
+			return "", fmt.Errorf("Cannot read %s: %v", action.deployment, err)
+		}
+		pods := gjson.GetBytes(out, "spec.replicas").Int()
+		if kind, _ := parseTarget(action.deployment); kind == "DS" {
+			pods = gjson.GetBytes(out, "status.desiredNumberScheduled").Int()
+		}
+		return fmt.Sprintf("Restart %s (rolling restart of %d pods)? [y/N] ", action.deployment, pods), nil
+	case "rollback":
+		if len(args) < 1 || action.helmRelease == "" {
+			return "", fmt.Errorf("No Helm release associated.")
+		}
+		revisions, err := kc.ListHelmRevisions(ctx, ns, action.helmRelease)
+		if err != nil {
+			return "", fmt.Errorf("Cannot read the history of %s: %v", action.helmRelease, err)
+		}
+		return rollbackPrompt(action.helmRelease, revisions, args[0])
+	case "delete-pod":
+		if len(args) < 1 {
+			return "", fmt.Errorf("Usage: delete-pod <pod>")
+		}
+		return fmt.Sprintf("Delete pod %s? [y/N] ", args[0]), nil
+	}
+	return "", fmt.Errorf("Unknown command: %s", verb)
+}
+
+// rollbackPrompt names the chart and app version deployed now and at the
+// target revision
+func rollbackPrompt(release string, revisions []k8s.HelmRevision, target string) (string, error) {
+	var to, from *k8s.HelmRevision
+	current := currentHelmRevision(revisions)
+	for i := range revisions {
+		r := &revisions[i]
+		if fmt.Sprint(r.Revision) == target {
+			to = r
+		}
+		if r.Revision == current {
+			from = r
+		}
+	}
+	if to == nil {
+		return "", fmt.Errorf("Revision %s not found in the history of %s", target, release)
+	}
+	if from == nil {
+		return fmt.Sprintf("Roll back %s to revision %s? [y/N] ", release, revisionSummary(*to)), nil
+	}
+	return fmt.Sprintf("Roll back %s from revision %s to %s? [y/N] ", release, revisionSummary(*from), revisionSummary(*to)), nil
+}
+
+// revisionSummary renders a revision as "3 (web-1.2.0, app 2.0.0)"
+func revisionSummary(r k8s.HelmRevision) string {
+	if r.AppVersion == "" {
+		return fmt.Sprintf("%d (%s)", r.Revision, r.Chart)
+	}
+	return fmt.Sprintf("%d (%s, app %s)", r.Revision, r.Chart, r.AppVersion)
+}
+
+// handleActionPreview asks the previewed action's question in the command bar
+func (m *model) handleActionPreview(msg actionPreviewMsg) tea.Cmd {
+	m.pendingAction = &msg.action
+	m.inputMode = true
+	m.filterMode = false
+	m.shortcutMode = "confirm"
+	m.textInput.Prompt = msg.prompt
+	m.textInput.Placeholder = ""
+	m.textInput.Reset()
+	m.textInput.Focus()
+	return nil
+}
+
+// confirmAction answers the action prompt: 'y' runs it, any other key cancels
+func (m *model) confirmAction(key string) tea.Cmd {
+	action := m.pendingAction
+	m.pendingAction = nil
+	m.inputMode = false
+	m.shortcutMode = ""
+	m.textInput.Blur()
+	m.textInput.Reset()
+
+	if action == nil {
+		return nil
+	}
+	if key != "y" && key != "Y" {
+		m.statusMsg = "Cancelled: " + action.input
+		return clearStatusLater()
+	}
+	return m.runMutation(action.input, action.helmRelease, action.deployment)
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/devpopsdotin/k9s-deck/internal/k8s"
+)
+
+// --- CONTAINER PICKER ---
+
+// allContainersLabel is the picker's first entry, going back to every container
+const allContainersLabel = "All containers"
+
+// containerPicker is the overlay listing a multi-container pod's containers,
+// for its logs ('c') or to open a shell in one ('x')
+type containerPicker struct {
+	pod        string
+	containers []string
+	index      int  // into entries()
+	shell      bool // pick for a shell: no allContainersLabel entry
+}
+
+// entries are the picker's lines: allContainersLabel first for logs
+func (p *containerPicker) entries() []string {
+	if p.shell {
+		return p.containers
+	}
+	return append([]string{allContainersLabel}, p.containers...)
+}
+
+// containersMsg carries the container names of a pod for the picker
+type containersMsg struct {
+	pod        string
+	containers []string
+	shell      bool // fetched to open a shell, not to pick logs
+	err        error
+}
+
+// fetchContainersCmd lists the containers of pod, for a shell if shell
+func fetchContainersCmd(kc k8s.Client, ns string, pod string, shell bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), CommandTimeout)
+		defer cancel()
+
+		containers, err := kc.GetPodContainers(ctx, ns, pod)
+		return containersMsg{pod: pod, containers: containers, shell: shell, err: err}
+	}
+}
+
+// openContainerPicker fetches the selected pod's containers for the picker
+func (m *model) openContainerPicker() tea.Cmd {
+	if len(m.items) == 0 || m.items[m.cursor].Type != "POD" {
+		m.statusMsg = "Select a pod to pick one of its containers"
+		return clearStatusLater()
+	}
+	return fetchContainersCmd(client, Namespace, m.items[m.cursor].Name, false)
+}
+
+// showContainerPicker opens the picker once the containers are known
+func (m *model) showContainerPicker(msg containersMsg) tea.Cmd {
+	if len(m.items) == 0 || m.items[m.cursor].Name != msg.pod {
+		// The cursor moved on while the containers were fetched
+		return nil
+	}
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("Cannot list containers of %s: %v", msg.pod, msg.err)
+		return clearStatusLater()
+	}
+	if msg.shell && len(msg.containers) < 2 {
+		return execShellCmd(msg.pod, "", shells[0])
+	}
+	if len(msg.containers) < 2 {
+		m.statusMsg = msg.pod + " has a single container"
+		return clearStatusLater()
+	}
+
+	p := &containerPicker{pod: msg.pod, containers: msg.containers, shell: msg.shell}
+	if msg.pod == m.containerPod && !msg.shell {
+		for i, c := range msg.containers {
+			if c == m.container {
+				p.index = i + 1
+			}
+		}
+	}
+	m.picker = p
+	return nil
+}
+
+// updatePicker handles keys while the container picker is open
+func (m *model) updatePicker(msg tea.KeyMsg) tea.Cmd {
+	p := m.picker
+	last := len(p.entries()) - 1
+	switch msg.String() {
+	case "up", "k":
+		if p.index > 0 {
+			p.index--
+		} else {
+			p.index = last
+		}
+	case "down", "j", "tab":
+		if p.index < last {
+			p.index++
+		} else {
+			p.index = 0
+		}
+	case "esc", "c", "x", "q":
+		m.picker = nil
+	case "enter":
+		m.picker = nil
+		if p.shell {
+			return execShellCmd(p.pod, p.containers[p.index], shells[0])
+		}
+		m.container, m.containerPod = "", ""
+		if p.index > 0 {
+			m.container, m.containerPod = p.containers[p.index-1], p.pod
+		}
+		m.stopFollow()
+		// Show the picked container's logs right away
+		if i := tabIndex("POD", TabLogs); i >= 0 {
+			m.activeTab = i
+		}
+		if len(m.items) > 0 {
+			return fetchDetailsCmd(client, Namespace, m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(m.items[m.cursor]))
+		}
+	}
+	return nil
+}
+
+// pickerView renders the container picker as a small bordered list
+func (m model) pickerView() string {
+	p := m.picker
+	lines := []string{styleTitle.Render("Containers of " + p.pod)}
+	for i, entry := range p.entries() {
+		if i == p.index {
+			lines = append(lines, lipgloss.NewStyle().Foreground(activeTheme.Active).Bold(true).Render("▶ "+entry))
+		} else {
+			lines = append(lines, lipgloss.NewStyle().Foreground(activeTheme.Inactive).Render("  "+entry))
+		}
+	}
+	action := "Show logs"
+	if p.shell {
+		action = "Open shell"
+	}
+	lines = append(lines, styleDim.Render("[↑↓] Navigate  [Enter] "+action+"  [Esc] Cancel"))
+	return lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(cPrimary).Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- DASHBOARD ---
+
+const (
+	DashboardCardWidth  = 34
+	DashboardCardHeight = 9 // including border
+	MaxDashboardAlerts  = 2
+)
+
+// Card styles, built from the theme by buildStyles
+var styleCard, styleCardSelected lipgloss.Style
+
+// dashboardCard summarizes one monitored deployment
+type dashboardCard struct {
+	name     string // the target, "sts/<name>" for a statefulset
+	replicas string // "ready/desired"
+	image    string
+	ok       int
+	pending  int
+	failing  int
+	alerts   []string
+	digests  map[string]int // running image digest -> pod count
+	err      bool
+}
+
+// buildDashboardCards groups m.items into one card per target, with targetErrs
+// explaining the targets that failed to refresh
+func buildDashboardCards(items []item, targets []string, targetErrs map[string]error) []dashboardCard {
+	var cards []dashboardCard
+	seen := make(map[string]bool)
+	var curr *dashboardCard
+	reasons := make(map[string]int)
+
+	flush := func() {
+		if curr == nil {
+			return
+		}
+		keys := make([]string, 0, len(reasons))
+		for r := range reasons {
+			keys = append(keys, r)
+		}
+		sort.Strings(keys)
+		for _, r := range keys {
+			curr.alerts = append(curr.alerts, fmt.Sprintf("%s x%d", r, reasons[r]))
+		}
+		var ready, desired int
+		if _, err := fmt.Sscanf(curr.replicas, "%d/%d", &ready, &desired); err == nil && ready < desired {
+			curr.alerts = append([]string{fmt.Sprintf("%d/%d replicas ready", ready, desired)}, curr.alerts...)
+		}
+		if len(curr.digests) > 1 {
+			curr.alerts = append(curr.alerts, fmt.Sprintf("%d image digests running", len(curr.digests)))
+		}
+		cards = append(cards, *curr)
+		curr = nil
+		reasons = make(map[string]int)
+	}
+
+	for _, it := range items {
+		switch it.Type {
+		case "DEP", "STS", "DS", "RS":
+			flush()
+			seen[targetOf(it)] = true
+			replicas := it.Status
+			if desired, ready, _, ok := daemonSetCounts(it.Status); it.Type == "DS" && ok {
+				replicas = fmt.Sprintf("%d/%d", ready, desired)
+			}
+			curr = &dashboardCard{name: targetOf(it), replicas: replicas, image: it.Image, digests: make(map[string]int)}
+			if it.Anomaly != "" {
+				curr.alerts = append(curr.alerts, "unexpected shape: "+it.Anomaly)
+			}
+		case "POD":
+			if curr == nil {
+				continue
+			}
+			switch podHealth(it.Status) {
+			case healthOK:
+				curr.ok++
+			case healthPending:
+				curr.pending++
+			default:
+				curr.failing++
+			}
+			if reason := strings.Fields(it.Status); len(reason) > 0 && reason[0] != "Running" {
+				reasons[reason[0]]++
+			}
+			if it.Digest != "" {
+				curr.digests[it.Digest]++
+			}
+		}
+	}
+	flush()
+
+	// Targets whose deployment could not be fetched
+	sorted := append([]string(nil), targets...)
+	sort.Strings(sorted)
+	for _, t := range sorted {
+		if !seen[t] {
+			alert := "failed to fetch deployment"
+			if err, ok := targetErrs[t]; ok {
+				alert = "failed to fetch: " + targetErrReason(err)
+			}
+			cards = append(cards, dashboardCard{name: t, err: true, alerts: []string{alert}})
+		}
+	}
+	return cards
+}
+
+// shortImage trims the registry path so the tag fits on a card
+func shortImage(image string) string {
+	parts := strings.Split(image, ",")
+	for i, p := range parts {
+		if idx := strings.LastIndex(p, "/"); idx != -1 {
+			parts[i] = p[idx+1:]
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// dashboardColumns returns how many cards fit side by side
+func (m model) dashboardColumns() int {
+	return maxInt(m.width/(DashboardCardWidth+4), 1)
+}
+
+// updateDashboard handles keys while the dashboard is shown.
+// handled is false for keys that should fall through to normal mode.
+func (m model) updateDashboard(msg tea.KeyMsg) (model, tea.Cmd, bool) {
+	cards := buildDashboardCards(m.items, m.targets, m.targetErrs)
+	cols := m.dashboardColumns()
+
+	switch msg.String() {
+	case "left", "h":
+		m.dashCursor--
+	case "right", "l":
+		m.dashCursor++
+	case "up", "k":
+		m.dashCursor -= cols
+	case "down", "j":
+		m.dashCursor += cols
+	case "d", "esc":
+		m.dashboardMode = false
+		return m, nil, true
+	case "enter":
+		if m.dashCursor >= len(cards) {
+			return m, nil, true
+		}
+		name := cards[m.dashCursor].name
+		m.dashboardMode = false
+		m.stopFollow()
+		for i, it := range m.items {
+			if isWorkload(it.Type) && targetOf(it) == name {
+				m.cursor = i
+				if m.cursor < m.listOffset || m.cursor >= m.listOffset+m.listHeight {
+					m.listOffset = maxInt(m.cursor-1, 0)
+				}
+				m.activeTab = 0
+				m.detailView = ""
+				return m, fetchDetailsCmd(client, Namespace, m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(m.items[m.cursor])), true
+			}
+		}
+		return m, nil, true
+	default:
+		return m, nil, false
+	}
+
+	m.dashCursor = ensureCursorInBounds(m.dashCursor, len(cards))
+	return m, nil, true
+}
+
+// dashboardView renders every target as a card in a grid
+func (m model) dashboardView() string {
+	cards := buildDashboardCards(m.items, m.targets, m.targetErrs)
+
+	header := styleTitle.Render("K9s Deck Dashboard") + styleDim.Render(fmt.Sprintf("  %s | %s/%s", m.lastUpd.Format("15:04:05"), Context, Namespace))
+	if throttle := m.throttleIndicator(); throttle != "" {
+		header += "  " + throttle
+	}
+	if m.err != nil {
+		header += "  " + styleErr.Render("Err: "+m.err.Error())
+	}
+	if len(cards) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left, header, "", "Loading resources...")
+	}
+
+	cols := m.dashboardColumns()
+	cursor := ensureCursorInBounds(m.dashCursor, len(cards))
+
+	// Scroll so the selected card's row stays visible
+	visibleRows := maxInt((m.height-HeaderHeight-FooterHeight)/DashboardCardHeight, 1)
+	firstRow := maxInt(cursor/cols-visibleRows+1, 0)
+
+	var rows []string
+	for start := firstRow * cols; start < len(cards) && len(rows) < visibleRows; start += cols {
+		var rendered []string
+		for i := start; i < minInt(start+cols, len(cards)); i++ {
+			style := styleCard
+			if i == cursor {
+				style = styleCardSelected
+			}
+			rendered = append(rendered, style.Render(renderCard(cards[i])))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, rendered...))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, append([]string{header, ""}, rows...)...)
+}
+
+// renderCard lays out the body of one dashboard card
+func renderCard(c dashboardCard) string {
+	width := DashboardCardWidth - 2
+	truncate := func(s string) string {
+		if r := []rune(s); len(r) > width {
+			return string(r[:width-1]) + "…"
+		}
+		return s
+	}
+
+	kind, _ := parseTarget(c.name)
+	lines := []string{styleTitle.Render(truncate(icon(kind) + " " + c.name))}
+	if c.err {
+		lines = append(lines, styleErr.Render("Unavailable"))
+	} else {
+		lines = append(lines,
+			fmt.Sprintf("Replicas: %s ready", c.replicas),
+			fmt.Sprintf("Pods: %s %s %s",
+				lipgloss.NewStyle().Foreground(cGreen).Render(fmt.Sprintf("%d ok", c.ok)),
+				lipgloss.NewStyle().Foreground(cYellow).Render(fmt.Sprintf("%d pending", c.pending)),
+				lipgloss.NewStyle().Foreground(cRed).Render(fmt.Sprintf("%d failing", c.failing))),
+			styleDim.Render(truncate("Image: "+shortImage(c.image))),
+		)
+	}
+
+	if len(c.alerts) == 0 {
+		lines = append(lines, lipgloss.NewStyle().Foreground(cGreen).Render("✓ No alerts"))
+	}
+	for i, a := range c.alerts {
+		if i == MaxDashboardAlerts {
+			lines = append(lines, styleDim.Render(fmt.Sprintf("  +%d more", len(c.alerts)-i)))
+			break
+		}
+		lines = append(lines, lipgloss.NewStyle().Foreground(cYellow).Render(truncate("⚠ "+a)))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- POD DELETION ---
+
+// podDeletedMsg reports a pod deleted with ctrl+k
+type podDeletedMsg struct {
+	pod string
+}
+
+// promptDeletePod deletes the selected pod once the command bar's y/N
+// prompt is answered with y
+func (m *model) promptDeletePod() tea.Cmd {
+	if len(m.items) == 0 || m.items[m.cursor].Type != "POD" {
+		m.statusMsg = "Select a pod to delete it"
+		return clearStatusLater()
+	}
+	if ReadOnly {
+		m.statusMsg = "Deleting pods is disabled in read-only mode"
+		return clearStatusLater()
+	}
+
+	// startCommand asks y/N through the action confirmation first
+	return m.startCommand("delete-pod "+m.items[m.cursor].Name, "", "")
+}
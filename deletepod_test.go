@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/devpopsdotin/k9s-deck/internal/k8s"
+)
+
+func TestDeletePodAsksThroughConfirm(t *testing.T) {
+	old := client
+	client = k8s.NewMockClient()
+	defer func() { client = old }()
+
+	m := initialModel(savedState{}, []string{"web"})
+	m.items = []item{{Type: "DEP", Name: "web"}, {Type: "POD", Name: "web-1"}}
+	m.cursor = 1
+	msg, ok := m.promptDeletePod()().(actionPreviewMsg)
+	if !ok || msg.prompt != "Delete pod web-1? [y/N] " {
+		t.Fatalf("ctrl+k should ask before deleting, got %#v", msg)
+	}
+
+	m.handleActionPreview(msg)
+	if m.shortcutMode != "confirm" {
+		t.Fatalf("shortcutMode = %q, want confirm", m.shortcutMode)
+	}
+	m.confirmAction("n")
+	if m.pendingAction != nil || m.mutating != "" {
+		t.Error("answering n should run nothing")
+	}
+}
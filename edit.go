@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/devpopsdotin/k9s-deck/internal/k8s"
+)
+
+// --- EDIT ---
+
+// editKinds maps the item types backed by a Kubernetes object to the kind
+// they are fetched and applied as. Helm releases, group headers and the
+// computed tabs (logs, events, history) have nothing to apply.
+var editKinds = map[string]string{
+	"DEP": "deployment",
+	"STS": "statefulset",
+	"DS":  "daemonset",
+	"RS":  "replicaset",
+	"POD": "pod",
+	"CM":  "configmap",
+	"SEC": "secret",
+	"SVC": "service",
+}
+
+// editReadyMsg carries the manifest written to path for the editor
+type editReadyMsg struct {
+	kind, name string
+	path       string
+	original   []byte
+}
+
+// editFinishedMsg reports that the editor exited
+type editFinishedMsg struct {
+	editReadyMsg
+	err error
+}
+
+// editAppliedMsg reports the result of applying an edited manifest
+type editAppliedMsg struct {
+	kind, name string
+	path       string // the edited manifest, kept when the apply failed
+	err        error
+}
+
+// startEdit opens the selected object's YAML in $EDITOR
+func (m *model) startEdit() tea.Cmd {
+	m.partialKey = ""
+	if ReadOnly {
+		m.statusMsg = "Editing is disabled in read-only mode"
+		return clearStatusLater()
+	}
+	if len(m.items) == 0 {
+		return nil
+	}
+	it := m.items[m.cursor]
+	kind, ok := editKinds[it.Type]
+	if !ok {
+		m.statusMsg = fmt.Sprintf("%s items can't be edited", it.Type)
+		return clearStatusLater()
+	}
+	if tabName(it.Type, m.activeTab) != TabYAML {
+		m.statusMsg = "Edit works on the YAML tab"
+		return clearStatusLater()
+	}
+	return fetchEditCmd(client, Namespace, kind, it.Name)
+}
+
+// fetchEditCmd writes the live manifest of kind/name to a temporary file,
+// without managed fields or anything the YAML tab renders on top
+func fetchEditCmd(kc k8s.Client, ns string, kind, name string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), CommandTimeout)
+		defer cancel()
+
+		out, err := kc.GetResource(ctx, ns, kind, name, "yaml")
+		if err != nil {
+			return detailsMsg{err: fmt.Errorf("Cannot edit %s/%s: %v", kind, name, err)}
+		}
+		f, err := os.CreateTemp("", fmt.Sprintf("k9s-deck-%s-%s-*.yaml", kind, name))
+		if err != nil {
+			return detailsMsg{err: fmt.Errorf("Cannot edit %s/%s: %v", kind, name, err)}
+		}
+		defer f.Close()
+		if _, err := f.Write(out); err != nil {
+			os.Remove(f.Name())
+			return detailsMsg{err: fmt.Errorf("Cannot edit %s/%s: %v", kind, name, err)}
+		}
+		return editReadyMsg{kind: kind, name: name, path: f.Name(), original: out}
+	}
+}
+
+// editorCommand returns $VISUAL or $EDITOR (falling back to vi), split into
+// the program and its arguments, e.g. "code --wait"
+func editorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{"vi"}
+}
+
+// execEditorCmd suspends the TUI while the editor runs on the manifest
+func execEditorCmd(msg editReadyMsg) tea.Cmd {
+	editor := editorCommand()
+	cmd := exec.Command(editor[0], append(editor[1:], msg.path)...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return editFinishedMsg{editReadyMsg: msg, err: err}
+	})
+}
+
+// handleEditFinished applies the manifest if the editor changed it
+func (m *model) handleEditFinished(msg editFinishedMsg) tea.Cmd {
+	if msg.err != nil {
+		os.Remove(msg.path)
+		m.statusMsg = fmt.Sprintf("Editor failed: %v", msg.err)
+		return clearStatusLater()
+	}
+	edited, err := os.ReadFile(msg.path)
+	if err != nil {
+		os.Remove(msg.path)
+		m.statusMsg = fmt.Sprintf("Cannot read the edited %s/%s: %v", msg.kind, msg.name, err)
+		return clearStatusLater()
+	}
+	if bytes.Equal(bytes.TrimSpace(edited), bytes.TrimSpace(msg.original)) {
+		os.Remove(msg.path)
+		m.statusMsg = fmt.Sprintf("No changes to %s/%s", msg.kind, msg.name)
+		return clearStatusLater()
+	}
+	m.statusMsg = fmt.Sprintf("Applying %s/%s...", msg.kind, msg.name)
+	return applyEditCmd(client, Namespace, msg.kind, msg.name, msg.path, edited)
+}
+
+// applyEditCmd server-side applies an edited manifest
+func applyEditCmd(kc k8s.Client, ns string, kind, name, path string, data []byte) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), LongCommandTimeout)
+		defer cancel()
+		return editAppliedMsg{kind: kind, name: name, path: path, err: kc.ApplyResource(ctx, ns, kind, name, data)}
+	}
+}
+
+// handleEditApplied shows how the apply went. A failed apply keeps the
+// edited manifest so the changes aren't lost.
+func (m *model) handleEditApplied(msg editAppliedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("Apply of %s/%s failed (edits kept in %s): %v", msg.kind, msg.name, msg.path, msg.err)
+		return clearStatusLater()
+	}
+	os.Remove(msg.path)
+	m.statusMsg = fmt.Sprintf("Applied %s/%s", msg.kind, msg.name)
+	cmds := []tea.Cmd{m.refreshCmd(), clearStatusLater()}
+	if len(m.items) > 0 {
+		cmds = append(cmds, fetchDetailsCmd(client, Namespace, m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(m.items[m.cursor])))
+	}
+	return tea.Batch(cmds...)
+}
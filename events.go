@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tidwall/gjson"
+
+	"github.com/devpopsdotin/k9s-deck/internal/k8s"
+)
+
+// --- EVENTS ---
+
+// eventObject identifies an event's involved object as "Kind/name"
+func eventObject(e gjson.Result) string {
+	return e.Get("involvedObject.kind").String() + "/" + e.Get("involvedObject.name").String()
+}
+
+// itemEventObjects are the objects whose events belong to it's Events tab: a
+// pod itself, or a workload with its ReplicaSets and pods (found with
+// selector). Matching whole names avoids picking up "web-api" under "web".
+func itemEventObjects(ctx context.Context, kc k8s.Client, ns string, it item, selector string) map[string]bool {
+	if it.Type == "POD" {
+		return map[string]bool{"Pod/" + it.Name: true}
+	}
+	objects := map[string]bool{"Deployment/" + it.Name: true}
+	switch it.Type {
+	case "STS":
+		objects = map[string]bool{"StatefulSet/" + it.Name: true}
+	case "DS":
+		objects = map[string]bool{"DaemonSet/" + it.Name: true}
+	case "RS":
+		objects = map[string]bool{"ReplicaSet/" + it.Name: true}
+	}
+	if selector == "" {
+		return objects
+	}
+	podOut, err := kc.ListPods(ctx, ns, selector)
+	if err != nil {
+		return objects
+	}
+	gjson.GetBytes(podOut, "items").ForEach(func(_, p gjson.Result) bool {
+		objects["Pod/"+p.Get("metadata.name").String()] = true
+		p.Get("metadata.ownerReferences").ForEach(func(_, ref gjson.Result) bool {
+			if ref.Get("kind").String() == "ReplicaSet" {
+				objects["ReplicaSet/"+ref.Get("name").String()] = true
+			}
+			return true
+		})
+		return true
+	})
+	return objects
+}
+
+// fetchAllEventsCmd fetches every event of the namespace for :events
+func fetchAllEventsCmd(kc k8s.Client, ns string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), CommandTimeout)
+		defer cancel()
+
+		out, err := kc.GetEvents(ctx, ns)
+		if err != nil {
+			return detailsMsg{err: fmt.Errorf("Events error: %v", err)}
+		}
+		return detailsMsg{content: renderAllEvents(out, time.Now()), styled: true}
+	}
+}
+
+// renderAllEvents lists events oldest first, Warnings in red and Normal
+// events dimmed, with the object, reason, count and age of each
+func renderAllEvents(eventsJSON []byte, now time.Time) string {
+	type eventRow struct {
+		at   time.Time
+		warn bool
+		line string
+	}
+	var rows []eventRow
+	gjson.GetBytes(eventsJSON, "items").ForEach(func(_, e gjson.Result) bool {
+		at := eventTimestamp(e)
+		rows = append(rows, eventRow{
+			at:   at,
+			warn: e.Get("type").String() == "Warning",
+			line: fmt.Sprintf("%-6s %-8s %-45s %-22s %5d  %s", k8s.EventAge(now, at), e.Get("type").String(), eventObject(e), e.Get("reason").String(), eventCount(e), e.Get("message").String()),
+		})
+		return true
+	})
+	if len(rows) == 0 {
+		return "No events in namespace " + Namespace + "."
+	}
+	sort.SliceStable(rows, func(a, b int) bool { return rows[a].at.Before(rows[b].at) })
+
+	lines := []string{
+		styleTitle.Render(fmt.Sprintf("Events in %s (%d)", Namespace, len(rows))),
+		fmt.Sprintf("%-6s %-8s %-45s %-22s %5s  %s", "AGE", "TYPE", "OBJECT", "REASON", "COUNT", "MESSAGE"),
+	}
+	for _, r := range rows {
+		if r.warn {
+			lines = append(lines, styleErr.Render(r.line))
+		} else {
+			lines = append(lines, styleDim.Render(r.line))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// eventCount is how often an event happened: count, or series.count for
+// events.k8s.io events, and at least 1
+func eventCount(e gjson.Result) int64 {
+	count := e.Get("count").Int()
+	if series := e.Get("series.count").Int(); series > count {
+		count = series
+	}
+	if count < 1 {
+		count = 1
+	}
+	return count
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tidwall/gjson"
+
+	"github.com/devpopsdotin/k9s-deck/internal/k8s"
+)
+
+// --- FIND PODS IN ALL NAMESPACES ---
+
+// foundPod is a pod matched by :find
+type foundPod struct {
+	namespace, name, status string
+}
+
+// findPodsCmd lists the pods of every namespace whose name contains substr
+func findPodsCmd(kc k8s.Client, substr string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), LongCommandTimeout)
+		defer cancel()
+
+		out, err := kc.ListAllPods(ctx, "")
+		if k8s.IsForbidden(err) {
+			return detailsMsg{content: fmt.Sprintf("Listing pods across all namespaces is not allowed by your RBAC permissions.\n\nUse :ns <namespace> and :pods %s to search one namespace at a time.", substr)}
+		}
+		if err != nil {
+			return detailsMsg{err: fmt.Errorf("Find failed: %v", err)}
+		}
+
+		var found []foundPod
+		gjson.GetBytes(out, "items").ForEach(func(_, p gjson.Result) bool {
+			if name := p.Get("metadata.name").String(); strings.Contains(name, substr) {
+				found = append(found, foundPod{p.Get("metadata.namespace").String(), name, podStatus(p)})
+			}
+			return true
+		})
+		return detailsMsg{content: renderFoundPods(substr, found), styled: true}
+	}
+}
+
+// renderFoundPods lists found as a NAMESPACE/NAME/STATUS table sorted by
+// namespace and name, with statuses colored like the sidebar
+func renderFoundPods(substr string, found []foundPod) string {
+	if len(found) == 0 {
+		return fmt.Sprintf("No pod in any namespace has a name containing %q.", substr)
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].namespace != found[j].namespace {
+			return found[i].namespace < found[j].namespace
+		}
+		return found[i].name < found[j].name
+	})
+
+	nsWidth, nameWidth := len("NAMESPACE"), len("NAME")
+	for _, p := range found {
+		nsWidth = maxInt(nsWidth, len(p.namespace))
+		nameWidth = maxInt(nameWidth, len(p.name))
+	}
+	lines := []string{
+		fmt.Sprintf("%d pod(s) with names containing %q; :find <namespace>/<pod> switches to one", len(found), substr),
+		"",
+		styleDim.Render(fmt.Sprintf("%-*s  %-*s  %s", nsWidth, "NAMESPACE", nameWidth, "NAME", "STATUS")),
+	}
+	for _, p := range found {
+		st := lipgloss.NewStyle().Foreground(cRed)
+		switch podHealth(p.status) {
+		case healthOK:
+			st = st.Foreground(cGreen)
+		case healthPending:
+			st = st.Foreground(cYellow)
+		}
+		lines = append(lines, fmt.Sprintf("%-*s  %-*s  %s", nsWidth, p.namespace, nameWidth, p.name, st.Render(p.status)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// focusPodCmd switches to namespace ns and lists its pods matching pod
+func focusPodCmd(kc k8s.Client, ns, pod string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), CommandTimeout)
+		defer cancel()
+
+		if _, err := kc.GetPod(ctx, ns, pod); err != nil {
+			return namespaceSwitchMsg{namespace: ns, err: err}
+		}
+		deployments, err := kc.ListDeployments(ctx, ns)
+		return namespaceSwitchMsg{namespace: ns, deployments: deployments, focusPod: pod, err: err}
+	}
+}
@@ -0,0 +1,334 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tidwall/gjson"
+
+	"github.com/devpopsdotin/k9s-deck/internal/k8s"
+)
+
+// --- LOG FOLLOW MODE ---
+
+const (
+	DefaultMaxLogLines = 5000 // log lines kept in the details pane (config: maxLogLines, :buffer)
+	MinMaxLogLines     = 100
+	MaxFollowBatch     = 200 // lines applied per update when logs arrive in bursts
+	FollowTailLines    = DefaultLogTailLines
+)
+
+// logFollow is a running log stream for the selected item's Logs tab
+type logFollow struct {
+	id      int // tells a stopped stream's late messages apart
+	item    item
+	cancel  context.CancelFunc
+	lines   <-chan []byte
+	pods    []string // pods a workload's streams were opened for, sorted
+	count   int      // rendered lines currently in rawContent, without the marker
+	dropped int      // oldest lines dropped to stay within MaxLogLines
+}
+
+// followStartedMsg reports that the streams were opened (or failed to)
+type followStartedMsg struct {
+	id      int
+	lines   <-chan []byte
+	pods    []string // the streamed pods of a workload, sorted
+	skipped error    // pods whose stream failed while others opened
+	err     error
+}
+
+// logLineMsg carries newly streamed log lines
+type logLineMsg struct {
+	id    int
+	lines []string
+}
+
+// followEndedMsg reports that every stream closed
+type followEndedMsg struct {
+	id int
+}
+
+// startFollowCmd opens log streams for a pod, or for every pod of a
+// deployment (lines prefixed with [pod/<pod>/<container>] like aggregated logs);
+// scope picks the container, the :since window and timestamps
+func startFollowCmd(ctx context.Context, kc k8s.Client, ns string, id int, it item, selector string, scope logScope) tea.Cmd {
+	return func() tea.Msg {
+		opts := k8s.LogOptions{Container: scope.container, Since: scope.since, Timestamps: scope.timestamps}
+		if it.Type == "POD" {
+			opts.TailLines = scope.tailLines(FollowTailLines)
+			lines, err := kc.StreamPodLogs(ctx, ns, it.Name, true, opts)
+			return followStartedMsg{id: id, lines: lines, err: err}
+		}
+		opts.TailLines = scope.tailLines(DeploymentLogTail)
+
+		podOut, err := kc.ListPods(ctx, ns, selector)
+		if err != nil {
+			return followStartedMsg{id: id, err: err}
+		}
+		var pods []string
+		gjson.GetBytes(podOut, "items.#.metadata.name").ForEach(func(_, v gjson.Result) bool {
+			pods = append(pods, v.String())
+			return true
+		})
+		if len(pods) == 0 {
+			return followStartedMsg{id: id, err: fmt.Errorf("no pods found for selector %s", selector)}
+		}
+		sort.Strings(pods)
+
+		// Fan the per-pod streams into one channel
+		merged := make(chan []byte, 64)
+		var wg sync.WaitGroup
+		var errs []error
+		for _, pod := range pods {
+			lines, err := kc.StreamPodLogs(ctx, ns, pod, true, opts)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", pod, err))
+				continue
+			}
+			container := opts.Container
+			if container == "" {
+				container = "-"
+				if containers, err := kc.GetPodContainers(ctx, ns, pod); err == nil && len(containers) > 0 {
+					container = containers[0]
+				}
+			}
+			prefix := fmt.Sprintf("[pod/%s/%s] ", pod, container)
+			wg.Add(1)
+			go func(lines <-chan []byte) {
+				defer wg.Done()
+				for line := range lines {
+					select {
+					case merged <- append([]byte(prefix), line...):
+					case <-ctx.Done():
+						return
+					}
+				}
+			}(lines)
+		}
+		if len(errs) == len(pods) {
+			close(merged)
+			return followStartedMsg{id: id, err: errors.Join(errs...)}
+		}
+		go func() {
+			wg.Wait()
+			close(merged)
+		}()
+		return followStartedMsg{id: id, lines: merged, pods: pods, skipped: errors.Join(errs...)}
+	}
+}
+
+// waitLogLinesCmd waits for the next line, then takes whatever else is
+// already buffered so bursts cause one re-render instead of hundreds
+func waitLogLinesCmd(id int, lines <-chan []byte) tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-lines
+		if !ok {
+			return followEndedMsg{id: id}
+		}
+		batch := []string{string(line)}
+		for len(batch) < MaxFollowBatch {
+			select {
+			case line, ok := <-lines:
+				if !ok {
+					return logLineMsg{id: id, lines: batch}
+				}
+				batch = append(batch, string(line))
+			default:
+				return logLineMsg{id: id, lines: batch}
+			}
+		}
+		return logLineMsg{id: id, lines: batch}
+	}
+}
+
+// startFollow begins following the selected item's logs
+func (m *model) startFollow() tea.Cmd {
+	if len(m.items) == 0 {
+		return nil
+	}
+	it := m.items[m.cursor]
+	if (it.Type != "POD" && !isWorkload(it.Type)) || tabName(it.Type, m.activeTab) != TabLogs {
+		m.statusMsg = "Follow works on the Logs tab of a pod or workload"
+		return clearStatusLater()
+	}
+	selector := m.selectors[targetOf(it)]
+	if isWorkload(it.Type) && selector == "" {
+		m.statusMsg = "No label selector found for " + targetOf(it)
+		return clearStatusLater()
+	}
+
+	m.stopFollow()
+	// Streams are of the current instance
+	m.previousPod = ""
+	ctx, cancel := context.WithCancel(context.Background())
+	m.followSeq++
+	m.follow = &logFollow{id: m.followSeq, item: it, cancel: cancel}
+	if m.logGrep != nil {
+		m.logGrep.reset()
+	}
+	m.rawContent = ""
+	m.updateViewportContent()
+	return startFollowCmd(ctx, client, Namespace, m.follow.id, it, selector, m.logScopeFor(it))
+}
+
+// restartFollowOnPodChange re-opens a workload's follow when a refresh
+// finds its pods changed, e.g. during a rollout: new pods get streamed and the
+// lines of the replaced ones are dropped instead of lingering in the buffer
+func (m *model) restartFollowOnPodChange(msg dataMsg) tea.Cmd {
+	if m.follow == nil || m.follow.pods == nil || !isWorkload(m.follow.item.Type) {
+		return nil
+	}
+	target := targetOf(m.follow.item)
+	items, ok := msg.targetItems[target]
+	if !ok {
+		// The refresh failed, nothing is known about the pods
+		return nil
+	}
+	var pods []string
+	for _, it := range items {
+		if it.Type == "POD" {
+			pods = append(pods, it.Name)
+		}
+	}
+	sort.Strings(pods)
+	if slices.Equal(pods, m.follow.pods) {
+		return nil
+	}
+	if len(m.items) == 0 || targetOf(m.items[m.cursor]) != target || m.items[m.cursor].Type != m.follow.item.Type {
+		return nil
+	}
+	m.statusMsg = fmt.Sprintf("Pods of %s changed, following the new set", target)
+	return tea.Batch(m.startFollow(), clearStatusLater())
+}
+
+// stopFollow cancels the running stream, if any
+func (m *model) stopFollow() {
+	if m.follow == nil {
+		return
+	}
+	m.follow.cancel()
+	m.follow = nil
+}
+
+// appendLogLines adds streamed lines to the buffer, keeping the view pinned
+// to the bottom unless the user scrolled up
+func (m *model) appendLogLines(lines []string) {
+	atBottom := m.viewport.AtBottom()
+
+	content := strings.Join(lines, "\n")
+	if m.logGrep != nil {
+		if content = m.logGrep.filter(content); content == "" {
+			return
+		}
+	}
+	content = filterLogLevel(content, m.minLogLevel, !HideUnleveledLogs)
+	if content == "" {
+		return
+	}
+	rendered := processLogContent(content, m.follow.item.Type, m.follow.item.Name, m.logFormatMode, m.jsonMode, m.collapseRepeats)
+	body := m.followBody()
+	if body == "" {
+		body = rendered
+	} else {
+		body += "\n" + rendered
+	}
+	m.follow.count += strings.Count(rendered, "\n") + 1
+	m.setFollowBody(body)
+
+	m.updateViewportContent()
+	if atBottom {
+		m.viewport.GotoBottom()
+	}
+}
+
+// followBody returns the streamed lines in rawContent, without the
+// truncation marker
+func (m *model) followBody() string {
+	if m.follow.dropped == 0 {
+		return m.rawContent
+	}
+	_, body, _ := strings.Cut(m.rawContent, "\n")
+	return body
+}
+
+// setFollowBody drops the oldest lines of body beyond MaxLogLines and
+// stores it in rawContent behind the truncation marker
+func (m *model) setFollowBody(body string) {
+	if excess := m.follow.count - MaxLogLines; excess > 0 {
+		parts := strings.SplitN(body, "\n", excess+1)
+		if len(parts) == excess+1 {
+			body = parts[excess]
+			m.follow.count = MaxLogLines
+			m.follow.dropped += excess
+		}
+	}
+	if m.follow.dropped > 0 {
+		body = truncationMarker(m.follow.dropped) + "\n" + body
+	}
+	m.rawContent = body
+}
+
+// truncateLogLines keeps the last MaxLogLines lines of rendered logs, behind
+// a marker saying how many earlier ones were dropped
+func truncateLogLines(content string) string {
+	n := strings.Count(content, "\n") + 1
+	if n <= MaxLogLines {
+		return content
+	}
+	excess := n - MaxLogLines
+	return truncationMarker(excess) + "\n" + strings.SplitN(content, "\n", excess+1)[excess]
+}
+
+// truncationMarker is the dimmed first line of a truncated log buffer
+func truncationMarker(dropped int) string {
+	return styleDim.Render(fmt.Sprintf("%s %d earlier lines truncated (:buffer <n> keeps more)", icon("more"), dropped))
+}
+
+// parseMaxLogLines validates a log buffer size from :buffer or maxLogLines
+func parseMaxLogLines(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number of lines", s)
+	}
+	return n, checkMaxLogLines(n)
+}
+
+// checkMaxLogLines rejects log buffer sizes below MinMaxLogLines
+func checkMaxLogLines(n int) error {
+	if n < MinMaxLogLines {
+		return fmt.Errorf("%d is below the minimum of %d lines", n, MinMaxLogLines)
+	}
+	return nil
+}
+
+// setMaxLogLines changes the log buffer size, trimming a running follow
+// right away and reloading other logs
+func (m *model) setMaxLogLines(n int) tea.Cmd {
+	MaxLogLines = n
+	if m.follow != nil {
+		m.setFollowBody(m.followBody())
+		m.updateViewportContent()
+		return nil
+	}
+	if len(m.items) > 0 && m.detailView == "" {
+		it := m.items[m.cursor]
+		return fetchDetailsCmd(client, Namespace, it, m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(it))
+	}
+	return nil
+}
+
+// clearStatusLater clears the status message after 2 seconds
+func clearStatusLater() tea.Cmd {
+	return tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
+		return clearStatusMsg{}
+	})
+}
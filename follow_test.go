@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devpopsdotin/k9s-deck/internal/k8s"
+)
+
+// followMock serves a pod list for any selector and, per pod, a stream with
+// one line naming it that stays open until the follow stops
+func followMock(pods *[]string) *k8s.MockClient {
+	mock := k8s.NewMockClient()
+	mock.ListPodsFunc = func(ctx context.Context, namespace, selector string) ([]byte, error) {
+		var items []string
+		for _, pod := range *pods {
+			items = append(items, fmt.Sprintf(`{"metadata":{"name":%q}}`, pod))
+		}
+		return []byte(`{"items":[` + strings.Join(items, ",") + `]}`), nil
+	}
+	mock.GetPodContainersFunc = func(ctx context.Context, namespace, podName string) ([]string, error) {
+		return []string{"app"}, nil
+	}
+	mock.StreamPodLogsFunc = func(ctx context.Context, namespace, podName string, follow bool, opts k8s.LogOptions) (<-chan []byte, error) {
+		lines := make(chan []byte, 1)
+		lines <- []byte("hello from " + podName)
+		go func() {
+			<-ctx.Done()
+			close(lines)
+		}()
+		return lines, nil
+	}
+	return mock
+}
+
+// update runs msg through Update, keeping the resulting model
+func update(t *testing.T, m *model, msg any) {
+	t.Helper()
+	next, _ := m.Update(msg)
+	*m = next.(model)
+}
+
+// drainFollow opens the running follow's streams and applies their first
+// line each
+func drainFollow(t *testing.T, m *model, pods int) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	update(t, m, startFollowCmd(ctx, client, Namespace, m.follow.id, m.follow.item, m.selectors[targetOf(m.follow.item)], m.logScopeFor(m.follow.item))())
+	for strings.Count(m.rawContent, "hello") < pods {
+		update(t, m, waitLogLinesCmd(m.follow.id, m.follow.lines)())
+	}
+}
+
+func TestFollowResetsWhenPodsChange(t *testing.T) {
+	pods := []string{"web-1", "web-2"}
+	old := client
+	client = followMock(&pods)
+	defer func() { client = old }()
+
+	m := initialModel(savedState{}, []string{"web"})
+	m.selectors["web"] = "app=web"
+	m.items = []item{{Type: "DEP", Name: "web"}, {Type: "POD", Name: "web-1"}, {Type: "POD", Name: "web-2"}}
+	m.activeTab = tabIndex("DEP", TabLogs)
+	m.startFollow()
+	drainFollow(t, &m, 2)
+	if !strings.Contains(m.rawContent, "hello from web-1") {
+		t.Fatalf("follow should show web-1's lines, got %q", m.rawContent)
+	}
+
+	// A refresh with the same pods keeps the stream and its lines
+	id := m.follow.id
+	update(t, &m, dataMsg{targetItems: map[string][]item{"web": {{Type: "DEP", Name: "web"}, {Type: "POD", Name: "web-2"}, {Type: "POD", Name: "web-1"}}}})
+	if m.follow == nil || m.follow.id != id || !strings.Contains(m.rawContent, "hello from web-1") {
+		t.Fatalf("an unchanged pod set should keep following, got %q", m.rawContent)
+	}
+
+	// web-1 is replaced by web-3 in a rollout
+	pods = []string{"web-2", "web-3"}
+	update(t, &m, dataMsg{targetItems: map[string][]item{"web": {{Type: "DEP", Name: "web"}, {Type: "POD", Name: "web-2"}, {Type: "POD", Name: "web-3"}}}})
+	if m.follow == nil || m.follow.id == id {
+		t.Fatal("a changed pod set should restart the follow")
+	}
+	if strings.Contains(m.rawContent, "web-1") {
+		t.Errorf("the terminated pod's lines should be dropped, got %q", m.rawContent)
+	}
+	drainFollow(t, &m, 2)
+	if strings.Contains(m.rawContent, "web-1") || !strings.Contains(m.rawContent, "hello from web-3") {
+		t.Errorf("follow should show only the new pod set, got %q", m.rawContent)
+	}
+}
+
+func TestFollowUsesLogScope(t *testing.T) {
+	var got k8s.LogOptions
+	mock := followMock(&[]string{"web-1"})
+	mock.StreamPodLogsFunc = func(ctx context.Context, namespace, podName string, follow bool, opts k8s.LogOptions) (<-chan []byte, error) {
+		got = opts
+		lines := make(chan []byte)
+		close(lines)
+		return lines, nil
+	}
+
+	m := initialModel(savedState{}, []string{"web"})
+	it := item{Type: "POD", Name: "web-1"}
+	m.containerPod, m.container, m.logSince = "web-1", "sidecar", 5*time.Minute
+	startFollowCmd(context.Background(), mock, "default", 1, it, "", m.logScopeFor(it))()
+	if got.Container != "sidecar" || got.Since != 5*time.Minute {
+		t.Errorf("follow streamed container %q since %s, want sidecar since 5m", got.Container, got.Since)
+	}
+}
+
+func TestFollowFailsWhenNoStreamOpens(t *testing.T) {
+	mock := followMock(&[]string{"web-1", "web-2"})
+	mock.StreamPodLogsFunc = func(ctx context.Context, namespace, podName string, follow bool, opts k8s.LogOptions) (<-chan []byte, error) {
+		return nil, fmt.Errorf("container is waiting to start")
+	}
+
+	msg := startFollowCmd(context.Background(), mock, "default", 1, item{Type: "DEP", Name: "web"}, "app=web", logScope{})().(followStartedMsg)
+	if msg.err == nil || !strings.Contains(msg.err.Error(), "web-2: container is waiting to start") {
+		t.Errorf("err = %v, want every pod's stream error", msg.err)
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/devpopsdotin/k9s-deck/internal/k8s"
+)
+
+// --- HELM HISTORY ---
+
+// helmHistoryDetails fetches a release's revisions for the History tab;
+// they are rendered by renderHelmHistory in the order chosen with 'o'
+func helmHistoryDetails(ctx context.Context, kc k8s.Client, ns string, release string) detailsMsg {
+	revisions, err := kc.ListHelmRevisions(ctx, ns, release)
+	if err != nil {
+		return detailsMsg{err: fmt.Errorf("History error: %v", err)}
+	}
+	if len(revisions) == 0 {
+		return detailsMsg{content: "Release " + release + " has no history."}
+	}
+	return detailsMsg{helmRevisions: revisions}
+}
+
+// currentHelmRevision returns the revision that is deployed, the one a
+// rollback moves away from (0 if none is, e.g. after a failed install)
+func currentHelmRevision(revisions []k8s.HelmRevision) int {
+	current := 0
+	for _, r := range revisions {
+		if r.Status == "deployed" && r.Revision > current {
+			current = r.Revision
+		}
+	}
+	return current
+}
+
+// helmStatusStyle colors a revision row by its status
+func helmStatusStyle(status string) lipgloss.Style {
+	switch {
+	case status == "deployed":
+		return lipgloss.NewStyle().Foreground(cGreen).Bold(true)
+	case status == "superseded":
+		return styleDim
+	case status == "failed":
+		return styleErr
+	case strings.HasPrefix(status, "pending") || status == "uninstalling":
+		return lipgloss.NewStyle().Foreground(cYellow)
+	}
+	return lipgloss.NewStyle()
+}
+
+// renderHelmHistory renders revisions as a table, newest first unless
+// oldestFirst, with the deployed revision marked and highlighted
+func renderHelmHistory(revisions []k8s.HelmRevision, oldestFirst bool) string {
+	headers := []string{"REVISION", "UPDATED", "STATUS", "CHART", "APP VERSION", "DESCRIPTION"}
+	rows := make([][]string, len(revisions))
+	for i, r := range revisions {
+		updated := ""
+		if !r.Updated.IsZero() {
+			updated = r.Updated.Local().Format(time.DateTime)
+		}
+		rows[i] = []string{fmt.Sprint(r.Revision), updated, r.Status, r.Chart, r.AppVersion, r.Description}
+	}
+
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = len(h)
+		for _, row := range rows {
+			widths[i] = maxInt(widths[i], lipgloss.Width(row[i]))
+		}
+	}
+	// Revision right-aligned, the description last and unpadded
+	format := func(cells []string) string {
+		parts := []string{fmt.Sprintf("%*s", widths[0], cells[0])}
+		for i := 1; i < len(cells)-1; i++ {
+			parts = append(parts, cells[i]+strings.Repeat(" ", widths[i]-lipgloss.Width(cells[i])))
+		}
+		parts = append(parts, cells[len(cells)-1])
+		return strings.Join(parts, "  ")
+	}
+
+	current := currentHelmRevision(revisions)
+	marker := "▶ "
+	if UseASCII {
+		marker = "> "
+	}
+	lines := []string{"  " + styleTitle.Render(format(headers))}
+	for i := range revisions {
+		idx := len(revisions) - 1 - i
+		if oldestFirst {
+			idx = i
+		}
+		r := revisions[idx]
+		prefix := "  "
+		if r.Revision == current {
+			prefix = marker
+		}
+		lines = append(lines, helmStatusStyle(r.Status).Render(prefix+format(rows[idx])))
+	}
+
+	order := "newest first"
+	if oldestFirst {
+		order = "oldest first"
+	}
+	lines = append(lines, "", styleDim.Render(fmt.Sprintf("%d revisions, %s ([o] to reverse)", len(revisions), order)))
+	return strings.Join(lines, "\n")
+}
+
+// helmDiffCmd renders the change between the manifests of two revisions of
+// release. With to at 0 the deployed revision is used.
+func helmDiffCmd(kc k8s.Client, ns string, release string, from, to int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), LongCommandTimeout)
+		defer cancel()
+
+		if to == 0 {
+			revisions, err := kc.ListHelmRevisions(ctx, ns, release)
+			if err != nil {
+				return detailsMsg{err: fmt.Errorf("Cannot read the history of %s: %v", release, err)}
+			}
+			if to = currentHelmRevision(revisions); to == 0 {
+				return detailsMsg{err: fmt.Errorf("Release %s has no deployed revision to diff against", release)}
+			}
+		}
+
+		manifests := make([][]byte, 2)
+		for i, rev := range []int{from, to} {
+			out, err := kc.GetHelmManifest(ctx, ns, release, rev)
+			if err != nil {
+				return detailsMsg{err: fmt.Errorf("Cannot get revision %d of %s: %v", rev, release, err)}
+			}
+			manifests[i] = out
+		}
+
+		header := []string{
+			styleDiffDel.Render(fmt.Sprintf("--- %s revision %d", release, from)),
+			styleDiffAdd.Render(fmt.Sprintf("+++ %s revision %d", release, to)),
+			"",
+		}
+		return detailsMsg{content: renderDiff(header, strings.TrimSpace(string(manifests[0])), strings.TrimSpace(string(manifests[1]))), styled: true}
+	}
+}
+
+// parseHelmDiffArgs reads the revisions of ":helm diff <rev1> [rev2]"
+func parseHelmDiffArgs(args []string) (from, to int, err error) {
+	if len(args) < 1 || len(args) > 2 {
+		return 0, 0, fmt.Errorf("Usage: helm diff <rev1> [rev2] (rev2 defaults to the deployed revision)")
+	}
+	revs := []int{0, 0}
+	for i, a := range args {
+		n, err := strconv.Atoi(a)
+		if err != nil || n < 1 {
+			return 0, 0, fmt.Errorf("%q is not a revision number", a)
+		}
+		revs[i] = n
+	}
+	return revs[0], revs[1], nil
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// --- KEYBINDINGS & HELP ---
+
+// keyBinding documents one shortcut of normal mode
+type keyBinding struct {
+	keys   string // as displayed, e.g. "Ctrl+d/u"
+	desc   string // shown in the help overlay
+	short  string // footer hint label, "" to leave it out of the footer
+	action string // remappable action (see KeyBindings) whose key replaces keys
+}
+
+// label is the binding's key as currently mapped
+func (kb keyBinding) label() string {
+	if kb.action != "" {
+		return keyLabel(kb.action)
+	}
+	return kb.keys
+}
+
+// keyGroup is a titled section of the help overlay
+type keyGroup struct {
+	title    string
+	bindings []keyBinding
+}
+
+// keyMap is the single source of the footer hint and the '?' help overlay
+var keyMap = []keyGroup{
+	{"Navigation", []keyBinding{
+		{"↑/↓ j/k", "Select a resource", "", ""},
+		{"1-6", "Jump to the next Deployment, Helm, ConfigMap, Secret, Pod or Service", "", ""},
+		{"D / P", "Jump to the owning deployment / its first pod", "", ""},
+		{"Enter", "Refresh the details of the selected item", "", ""},
+		{"d", "Toggle the dashboard of all monitored deployments", "Dashboard", ""},
+		{"S", "Show or hide system secrets and ConfigMaps", "", ""},
+		{"n", "Switch namespace", "Namespace", ""},
+		{"C", "Switch context", "Context", ""},
+	}},
+	{"Tabs & Views", []keyBinding{
+		{"Tab", "Cycle the detail tabs (YAML, Events, Logs, Describe, ...)", "View", ""},
+		{"o", "Cycle the pod order (unhealthy first, name, age); on a release, reverse its History", "Order", ""},
+		{"c", "Pick the container whose logs are shown", "Container", ""},
+		{"p", "Pin the current view into the peek pane, or unpin it", "Peek", ""},
+		{"< / >", "Narrow / widen the resource list (saved to the config)", "", ""},
+		{"|", "Stack the list above the details, or put them side by side", "", ""},
+	}},
+	{"Commands", []keyBinding{
+		{":", "Command mode (scale, undo, restart, ns, pods, triage, ...)", "Cmds", ""},
+		{desc: "Filter the details pane, Esc clears", short: "Filter", action: "filter"},
+		{"Ctrl+/", "Search: highlight matches, keep every line", "", ""},
+		{"n / N", "Next / previous search match (while searching)", "", ""},
+		{"\\", "Query the YAML tab with a gjson path, Esc restores it", "", ""},
+		{"Ctrl-F", "Force a refresh", "Refresh", ""},
+		{"z", "Pause or resume automatic refreshes (Ctrl-F still refreshes)", "Pause", ""},
+		{desc: "Restart the deployment; on a pod, delete it so it is recreated (asks first)", short: "Restart", action: "restart"},
+		{desc: "Scale the deployment", short: "Scale", action: "scale"},
+		{desc: "Roll back the Helm release", short: "Rollback", action: "rollback"},
+		{"Ctrl+K", "Delete the selected pod (asks first)", "Delete Pod", ""},
+		{"x", "Open a shell in the selected pod (picks the container first)", "", ""},
+		{"e", "Edit the selected object's YAML in $EDITOR and apply it", "Edit", ""},
+		{desc: "Add a deployment to monitor", short: "Add", action: "addTarget"},
+		{desc: "Remove a monitored deployment", short: "Remove", action: "removeTarget"},
+		{desc: "Copy the details as displayed (filtered) to the clipboard", short: "Yank", action: "yank"},
+		{"Y", "Copy the whole unfiltered details to the clipboard", "", ""},
+		{"yp / ys", "Copy the selected item's name / its workload's label selector", "", ""},
+	}},
+	{"Logs", []keyBinding{
+		{desc: "Toggle formatted and raw logs", short: "Format", action: "toggleFormat"},
+		{"w", "Toggle line wrapping", "Wrap", ""},
+		{"#", "Toggle line numbers (original numbers while filtering)", "", ""},
+		{"t", "Toggle RFC3339 timestamps on log lines", "", ""},
+		{"V", "Toggle the previous (crashed) container's logs of a pod", "", ""},
+		{"F", "Follow the logs live, F/Esc stops", "Follow", ""},
+		{"L", "Cycle the minimum log level", "Level", ""},
+		{"J", "Cycle JSON logs: pretty, flat, columns", "", ""},
+		{"E", "Expand the +N fields of JSON log columns", "", ""},
+		{"U", "Collapse repeated log lines into one with an (xN) count", "", ""},
+	}},
+	{"Scrolling", []keyBinding{
+		{"Ctrl+d/u", "Scroll half a page down / up", "Scroll", ""},
+		{"Ctrl+e/y", "Scroll one line down / up", "", ""},
+		{"PgDn/PgUp", "Scroll a full page down / up", "", ""},
+		{"←/→ h/l", "Scroll sideways while wrapping is off", "", ""},
+	}},
+	{"General", []keyBinding{
+		{"?", "Toggle this help", "Help", ""},
+		{"q", "Quit", "Quit", ""},
+	}},
+}
+
+// podShorts are the footer labels of actions that act on the pod itself
+// while one is selected
+var podShorts = map[string]string{
+	"restart": "Restart Pod",
+}
+
+// footerHint renders the footer's shortcut list from keyMap for the selected
+// item's type
+func footerHint(selectedType string) string {
+	var b strings.Builder
+	for _, group := range keyMap {
+		for _, kb := range group.bindings {
+			short := kb.short
+			if podShort, ok := podShorts[kb.action]; ok && selectedType == "POD" {
+				short = podShort
+			}
+			if short != "" {
+				fmt.Fprintf(&b, " [%s] %s ", kb.label(), short)
+			}
+		}
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
+// helpLines renders keyMap as the help overlay's lines
+func helpLines() []string {
+	keyStyle := lipgloss.NewStyle().Foreground(cPrimary).Bold(true)
+	var lines []string
+	for i, group := range keyMap {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, styleTitle.Render(group.title))
+		for _, kb := range group.bindings {
+			lines = append(lines, "  "+keyStyle.Render(fmt.Sprintf("%-10s", kb.label()))+" "+kb.desc)
+		}
+	}
+	return lines
+}
+
+// helpBodyHeight is how many help lines fit in the overlay
+func (m model) helpBodyHeight() int {
+	// Border, the hint line and the footer below the overlay
+	return maxInt(m.height-FooterHeight-3, 1)
+}
+
+// updateHelp handles keys while the help overlay is shown
+func (m *model) updateHelp(msg tea.KeyMsg) tea.Cmd {
+	maxScroll := maxInt(len(helpLines())-m.helpBodyHeight(), 0)
+	switch msg.String() {
+	case "?", "esc", "q":
+		m.showHelp = false
+	case "ctrl+c":
+		return tea.Quit
+	case "down", "j", "ctrl+e":
+		m.helpScroll++
+	case "up", "k", "ctrl+y":
+		m.helpScroll--
+	case "pgdown", "ctrl+d", " ":
+		m.helpScroll += m.helpBodyHeight()
+	case "pgup", "ctrl+u":
+		m.helpScroll -= m.helpBodyHeight()
+	case "g", "home":
+		m.helpScroll = 0
+	case "G", "end":
+		m.helpScroll = maxScroll
+	}
+	m.helpScroll = maxInt(minInt(m.helpScroll, maxScroll), 0)
+	return nil
+}
+
+// helpView renders the visible part of the help overlay
+func (m model) helpView() string {
+	lines := helpLines()
+	body := m.helpBodyHeight()
+	start := minInt(m.helpScroll, maxInt(len(lines)-body, 0))
+	end := minInt(start+body, len(lines))
+
+	hint := "[?/Esc/q] Close"
+	if len(lines) > body {
+		hint = fmt.Sprintf("[↑↓/jk] Scroll  %s  %d-%d/%d", hint, start+1, end, len(lines))
+	}
+	visible := append(append([]string{}, lines[start:end]...), styleDim.Render(hint))
+
+	width := maxInt(minInt(m.width-4, 80), 20)
+	for i, line := range visible {
+		visible[i] = ansi.Truncate(line, width-4, icon("more"))
+	}
+	return lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(cPrimary).Padding(0, 1).
+		Width(width - 2).Render(strings.Join(visible, "\n"))
+}
+
+// overlayCenter draws fg centered over bg, keeping the background visible
+// around it
+func overlayCenter(bg, fg string, width int) string {
+	bgLines := strings.Split(bg, "\n")
+	fgLines := strings.Split(fg, "\n")
+	fgWidth := lipgloss.Width(fg)
+	top := maxInt((len(bgLines)-len(fgLines))/2, 0)
+	left := maxInt((width-fgWidth)/2, 0)
+
+	for i, fgLine := range fgLines {
+		row := top + i
+		if row >= len(bgLines) {
+			break
+		}
+		bgLine := bgLines[row]
+		if w := lipgloss.Width(bgLine); w < width {
+			bgLine += strings.Repeat(" ", width-w)
+		}
+		bgLines[row] = ansi.Truncate(bgLine, left, "") + fgLine + ansi.TruncateLeft(bgLine, left+lipgloss.Width(fgLine), "")
+	}
+	return strings.Join(bgLines, "\n")
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"strings"
+)
+
+// --- ICONS ---
+
+// UseASCII swaps the emoji icons for ASCII markers (--ascii, config or auto-detected)
+var UseASCII bool
+
+var (
+	emojiIcons = map[string]string{
+		"DEP":      "🚀",
+		"STS":      "💾",
+		"DS":       "📡",
+		"RS":       "🔁",
+		"POD":      "📦",
+		"HELM":     "⚓",
+		"SEC":      "🔒",
+		"CM":       "📜",
+		"SVC":      "🌐",
+		"pin":      "📌",
+		"warn":     "⚠",
+		"more":     "…",
+		"restarts": "↻",
+	}
+	asciiIcons = map[string]string{
+		"DEP":      "[D]",
+		"STS":      "[T]",
+		"DS":       "[A]",
+		"RS":       "[R]",
+		"POD":      "[P]",
+		"HELM":     "[H]",
+		"SEC":      "[S]",
+		"CM":       "[C]",
+		"SVC":      "[N]",
+		"pin":      "[*]",
+		"warn":     "!",
+		"more":     "~",
+		"restarts": "r",
+	}
+)
+
+// icon returns the marker for a resource type or UI glyph in the active icon set
+func icon(name string) string {
+	if UseASCII {
+		return asciiIcons[name]
+	}
+	return emojiIcons[name]
+}
+
+// terminalLacksEmoji guesses whether the terminal can't render emoji: a
+// non-UTF-8 locale or the Linux console. Over SSH the locale is usually
+// what tells, since the remote side can't see the client's fonts.
+func terminalLacksEmoji() bool {
+	if os.Getenv("TERM") == "linux" {
+		return true
+	}
+	for _, env := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := os.Getenv(env); locale != "" {
+			locale = strings.ToLower(locale)
+			return !strings.Contains(locale, "utf-8") && !strings.Contains(locale, "utf8")
+		}
+	}
+	// No locale at all means the C locale
+	return true
+}
@@ -1,7 +1,11 @@
 package k8s
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"log/slog"
+	"os"
 	"os/exec"
 	"time"
 )
@@ -19,23 +23,76 @@ type Client interface {
 	ScaleDeployment(ctx context.Context, namespace, name string, replicas int) error
 	RestartDeployment(ctx context.Context, namespace, name string) error
 	ListDeployments(ctx context.Context, namespace string) ([]string, error)
+	DescribeDeployment(ctx context.Context, namespace, name string) ([]byte, error)
+	GetRolloutStatus(ctx context.Context, namespace, name string) (RolloutStatus, error)
+
+	// StatefulSet operations
+	GetStatefulSet(ctx context.Context, namespace, name string) ([]byte, error)
+	ListStatefulSets(ctx context.Context, namespace string) ([]string, error)
+	ScaleStatefulSet(ctx context.Context, namespace, name string, replicas int) error
+	RestartStatefulSet(ctx context.Context, namespace, name string) error
+
+	// DaemonSet operations (they run one pod per node and can't be scaled)
+	GetDaemonSet(ctx context.Context, namespace, name string) ([]byte, error)
+	ListDaemonSets(ctx context.Context, namespace string) ([]string, error)
+	RestartDaemonSet(ctx context.Context, namespace, name string) error
+
+	// ResolveWorkload returns the kind (one of WorkloadKinds) of the workload
+	// called name, an error wrapping ErrNotFound if there is none
+	ResolveWorkload(ctx context.Context, namespace, name string) (string, error)
 
 	// Pod operations
+	GetPod(ctx context.Context, namespace, name string) ([]byte, error)
 	ListPods(ctx context.Context, namespace, selector string) ([]byte, error)
+	// ListAllPods lists pods across every namespace, an error wrapping
+	// ErrForbidden if RBAC doesn't allow a cluster-wide list
+	ListAllPods(ctx context.Context, selector string) ([]byte, error)
 	GetPodLogs(ctx context.Context, namespace, podName string, tailLines int, allContainers, prefix bool) ([]byte, error)
+	GetPodLogsWithOptions(ctx context.Context, namespace, podName string, opts LogOptions) ([]byte, error)
 	GetPodContainers(ctx context.Context, namespace, podName string) ([]string, error)
+	DescribePod(ctx context.Context, namespace, name string) ([]byte, error)
+	// StreamPodLogs streams one container's logs, opts.Container or the
+	// first; AllContainers, Prefix and Previous don't apply
+	StreamPodLogs(ctx context.Context, namespace, podName string, follow bool, opts LogOptions) (<-chan []byte, error)
+	WatchPods(ctx context.Context, namespace, selector string) (<-chan PodEvent, error)
+	ExecInPod(ctx context.Context, namespace, podName, container string, command []string) ([]byte, error)
+	DeletePod(ctx context.Context, namespace, podName string) error
+	GetPodMetrics(ctx context.Context, namespace, selector string) (map[string]PodMetrics, error)
+	PortForward(ctx context.Context, namespace, podName string, localPort, remotePort int) (<-chan error, error)
 
 	// Helm operations
-	GetHelmHistory(ctx context.Context, namespace, releaseName string) ([]byte, error)
+	ListHelmRevisions(ctx context.Context, namespace, releaseName string) ([]HelmRevision, error)
 	RollbackHelm(ctx context.Context, namespace, releaseName string, revision int) error
+	GetHelmManifest(ctx context.Context, namespace, releaseName string, revision int) ([]byte, error)
+	GetHelmNotes(ctx context.Context, namespace, releaseName string) ([]byte, error)
+	GetHelmHooks(ctx context.Context, namespace, releaseName string) ([]byte, error)
 
 	// Resource operations (Secrets, ConfigMaps)
 	GetSecret(ctx context.Context, namespace, name string) ([]byte, error)
 	GetConfigMap(ctx context.Context, namespace, name string) ([]byte, error)
 	GetResource(ctx context.Context, namespace, kind, name, outputFormat string) ([]byte, error)
+	// ApplyResource server-side applies a YAML or JSON manifest of the object
+	// kind/name (kind as accepted by GetResource) in namespace, refusing one
+	// for another kind or name
+	ApplyResource(ctx context.Context, namespace, kind, name string, data []byte) error
+	ListServices(ctx context.Context, namespace string) ([]byte, error)
 
 	// Event operations
 	GetEvents(ctx context.Context, namespace string) ([]byte, error)
+
+	// Namespace operations
+	ListNamespaces(ctx context.Context) ([]string, error)
+}
+
+// LogOptions controls which pod logs GetPodLogsWithOptions returns
+type LogOptions struct {
+	TailLines     int
+	AllContainers bool
+	Prefix        bool          // prefix each line with [pod/<pod>/<container>]
+	Previous      bool          // logs of the previous (terminated) container instance
+	Container     string        // only this container; takes precedence over AllContainers
+	Since         time.Duration // only logs newer than this, 0 for no limit
+	Timestamps    bool          // start each line with its RFC3339 timestamp
 }
 
 // KubectlClient implements Client using kubectl CLI
@@ -50,9 +107,50 @@ func NewKubectlClient(context string) *KubectlClient {
 	}
 }
 
-// NewClient creates a new Kubernetes client (defaults to client-go)
+// Options tunes the client-go client. Zero values keep client-go's defaults.
+type Options struct {
+	QPS   float32 // sustained requests per second to the API server (default 5)
+	Burst int     // requests allowed above QPS in a burst (default 10)
+}
+
+// EnvBackend selects the Client implementation: "clientgo" (the default) or
+// "kubectl"
+const EnvBackend = "K9S_DECK_BACKEND"
+
+// lookPath finds kubectl for the fallback backend (replaced in tests)
+var lookPath = exec.LookPath
+
+// NewClient creates a new Kubernetes client for the backend chosen by
+// $K9S_DECK_BACKEND, see NewClientWithOptions
 func NewClient(kubeContext string) (Client, error) {
-	return NewClientGoClient(kubeContext)
+	return NewClientWithOptions(kubeContext, Options{})
+}
+
+// NewClientWithOptions creates a new Kubernetes client for the backend chosen
+// by $K9S_DECK_BACKEND, with opts tuning the client-go rate limits:
+//   - "kubectl" returns a *KubectlClient, which never fails to build; its
+//     errors come from the kubectl commands it runs.
+//   - "clientgo" or unset returns a *ClientGoClient. When the kubeconfig can't
+//     be loaded it falls back to a *KubectlClient if kubectl is on PATH, and
+//     otherwise returns the client-go error.
+//   - Any other value is an error.
+func NewClientWithOptions(kubeContext string, opts Options) (Client, error) {
+	switch backend := os.Getenv(EnvBackend); backend {
+	case "kubectl":
+		return NewKubectlClient(kubeContext), nil
+	case "", "clientgo":
+		c, err := NewClientGoClientWithOptions(kubeContext, opts)
+		if err == nil {
+			return c, nil
+		}
+		if _, lookErr := lookPath("kubectl"); lookErr != nil {
+			return nil, err
+		}
+		slog.Warn("client-go config failed, falling back to kubectl", "context", kubeContext, "error", err)
+		return NewKubectlClient(kubeContext), nil
+	default:
+		return nil, fmt.Errorf("unknown %s %q, want clientgo or kubectl", EnvBackend, backend)
+	}
 }
 
 // runCmd executes a command with timeout
@@ -61,6 +159,13 @@ func (c *KubectlClient) runCmd(ctx context.Context, name string, args ...string)
 	return cmd.CombinedOutput()
 }
 
+// runCmdWithInput executes a command with input on its stdin
+func (c *KubectlClient) runCmdWithInput(ctx context.Context, input []byte, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin = bytes.NewReader(input)
+	return cmd.CombinedOutput()
+}
+
 // runCmdWithTimeout executes a command with a specific timeout
 func (c *KubectlClient) runCmdWithTimeout(timeout time.Duration, name string, args ...string) ([]byte, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
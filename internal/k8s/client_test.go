@@ -3,6 +3,10 @@ package k8s
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
 )
 
@@ -100,6 +104,31 @@ func TestMockClient_ListDeployments(t *testing.T) {
 	}
 }
 
+func TestMockClient_GetPod(t *testing.T) {
+	mock := NewMockClient()
+
+	expectedYAML := []byte("apiVersion: v1\nkind: Pod\n")
+	mock.GetPodFunc = func(ctx context.Context, namespace, name string) ([]byte, error) {
+		if name == "test-pod" {
+			return expectedYAML, nil
+		}
+		return nil, errors.New("pod not found")
+	}
+
+	data, err := mock.GetPod(context.Background(), "default", "test-pod")
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if string(data) != string(expectedYAML) {
+		t.Errorf("Expected %s, got %s", expectedYAML, data)
+	}
+
+	_, err = mock.GetPod(context.Background(), "default", "missing")
+	if err == nil {
+		t.Error("Expected error for missing pod")
+	}
+}
+
 func TestMockClient_ListPods(t *testing.T) {
 	mock := NewMockClient()
 
@@ -140,6 +169,31 @@ func TestMockClient_GetPodLogs(t *testing.T) {
 	}
 }
 
+func TestMockClient_GetPodLogsWithOptions(t *testing.T) {
+	mock := NewMockClient()
+
+	expectedLogs := []byte("previous crash output\n")
+	mock.GetPodLogsWithOptionsFunc = func(ctx context.Context, namespace, podName string, opts LogOptions) ([]byte, error) {
+		if podName == "test-pod" && opts.Previous && opts.TailLines == 50 {
+			return expectedLogs, nil
+		}
+		return nil, errors.New("previous terminated container not found")
+	}
+
+	logs, err := mock.GetPodLogsWithOptions(context.Background(), "default", "test-pod", LogOptions{TailLines: 50, Previous: true})
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if string(logs) != string(expectedLogs) {
+		t.Errorf("Expected %s, got %s", expectedLogs, logs)
+	}
+
+	_, err = mock.GetPodLogsWithOptions(context.Background(), "default", "test-pod", LogOptions{TailLines: 50})
+	if err == nil {
+		t.Error("Expected error without Previous, got nil")
+	}
+}
+
 func TestMockClient_GetPodContainers(t *testing.T) {
 	mock := NewMockClient()
 
@@ -160,23 +214,53 @@ func TestMockClient_GetPodContainers(t *testing.T) {
 	}
 }
 
-func TestMockClient_GetHelmHistory(t *testing.T) {
+func TestMockClient_DeletePod(t *testing.T) {
 	mock := NewMockClient()
 
-	expectedHistory := []byte("REVISION\tSTATUS\n1\tdeployed\n2\tsuperseded\n")
-	mock.GetHelmHistoryFunc = func(ctx context.Context, namespace, releaseName string) ([]byte, error) {
-		if releaseName == "my-release" {
-			return expectedHistory, nil
+	deleted := ""
+	mock.DeletePodFunc = func(ctx context.Context, namespace, podName string) error {
+		if podName != "test-pod" {
+			return errors.New("pod not found")
 		}
-		return nil, errors.New("release not found")
+		deleted = podName
+		return nil
 	}
 
-	history, err := mock.GetHelmHistory(context.Background(), "default", "my-release")
-	if err != nil {
+	if err := mock.DeletePod(context.Background(), "default", "test-pod"); err != nil {
 		t.Errorf("Expected no error, got %v", err)
 	}
-	if string(history) != string(expectedHistory) {
-		t.Errorf("Expected %s, got %s", expectedHistory, history)
+	if deleted != "test-pod" {
+		t.Errorf("Expected test-pod to be deleted, got %q", deleted)
+	}
+	if err := mock.DeletePod(context.Background(), "default", "missing"); err == nil {
+		t.Error("Expected error for missing pod, got nil")
+	}
+}
+
+func TestMockClient_StreamPodLogs(t *testing.T) {
+	mock := NewMockClient()
+
+	mock.StreamPodLogsFunc = func(ctx context.Context, namespace, podName string, follow bool, opts LogOptions) (<-chan []byte, error) {
+		if podName != "my-pod" || !follow || !opts.Timestamps || opts.Container != "app" {
+			return nil, errors.New("unexpected stream request")
+		}
+		ch := make(chan []byte, 2)
+		ch <- []byte("2024-05-01T10:00:00.000000001Z line 1")
+		ch <- []byte("2024-05-01T10:00:01.000000002Z line 2")
+		close(ch)
+		return ch, nil
+	}
+
+	lines, err := mock.StreamPodLogs(context.Background(), "default", "my-pod", true, LogOptions{TailLines: 10, Container: "app", Timestamps: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	count := 0
+	for range lines {
+		count++
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 lines, got %d", count)
 	}
 }
 
@@ -201,6 +285,69 @@ func TestMockClient_RollbackHelm(t *testing.T) {
 	}
 }
 
+func TestMockClient_ListHelmRevisions(t *testing.T) {
+	mock := NewMockClient()
+
+	mock.ListHelmRevisionsFunc = func(ctx context.Context, namespace, releaseName string) ([]HelmRevision, error) {
+		if releaseName == "my-release" {
+			return []HelmRevision{{Revision: 1, Status: "superseded"}, {Revision: 2, Status: "deployed"}}, nil
+		}
+		return nil, errors.New("release not found")
+	}
+
+	revisions, err := mock.ListHelmRevisions(context.Background(), "default", "my-release")
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if len(revisions) != 2 || revisions[1].Status != "deployed" {
+		t.Errorf("Expected 2 revisions ending with the deployed one, got %+v", revisions)
+	}
+
+	if _, err := mock.ListHelmRevisions(context.Background(), "default", "other"); err == nil {
+		t.Error("Expected error for unknown release, got nil")
+	}
+}
+
+func TestMockClient_GetHelmNotes(t *testing.T) {
+	mock := NewMockClient()
+
+	expectedNotes := []byte("NOTES:\nVisit http://my-app.local\n")
+	mock.GetHelmNotesFunc = func(ctx context.Context, namespace, releaseName string) ([]byte, error) {
+		if releaseName == "my-release" {
+			return expectedNotes, nil
+		}
+		return nil, errors.New("release not found")
+	}
+
+	notes, err := mock.GetHelmNotes(context.Background(), "default", "my-release")
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if string(notes) != string(expectedNotes) {
+		t.Errorf("Expected %s, got %s", expectedNotes, notes)
+	}
+}
+
+func TestMockClient_GetHelmHooks(t *testing.T) {
+	mock := NewMockClient()
+
+	expectedHooks := []byte("kind: Job\nmetadata:\n  name: migrate\n")
+	mock.GetHelmHooksFunc = func(ctx context.Context, namespace, releaseName string) ([]byte, error) {
+		if releaseName == "my-release" {
+			return expectedHooks, nil
+		}
+		return nil, errors.New("release not found")
+	}
+
+	hooks, err := mock.GetHelmHooks(context.Background(), "default", "my-release")
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if string(hooks) != string(expectedHooks) {
+		t.Errorf("Expected %s, got %s", expectedHooks, hooks)
+	}
+}
+
 func TestMockClient_GetSecret(t *testing.T) {
 	mock := NewMockClient()
 
@@ -261,6 +408,26 @@ func TestMockClient_GetResource(t *testing.T) {
 	}
 }
 
+func TestMockClient_ListServices(t *testing.T) {
+	mock := NewMockClient()
+
+	expectedServices := []byte(`{"items":[{"metadata":{"name":"web"},"spec":{"selector":{"app":"web"}}}]}`)
+	mock.ListServicesFunc = func(ctx context.Context, namespace string) ([]byte, error) {
+		if namespace == "default" {
+			return expectedServices, nil
+		}
+		return nil, errors.New("namespace not found")
+	}
+
+	services, err := mock.ListServices(context.Background(), "default")
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if string(services) != string(expectedServices) {
+		t.Errorf("Expected %s, got %s", expectedServices, services)
+	}
+}
+
 func TestMockClient_GetEvents(t *testing.T) {
 	mock := NewMockClient()
 
@@ -281,6 +448,28 @@ func TestMockClient_GetEvents(t *testing.T) {
 	}
 }
 
+func TestMockClient_ListNamespaces(t *testing.T) {
+	mock := NewMockClient()
+
+	expected := []string{"default", "kube-system", "staging"}
+	mock.ListNamespacesFunc = func(ctx context.Context) ([]string, error) {
+		return expected, nil
+	}
+
+	namespaces, err := mock.ListNamespaces(context.Background())
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if len(namespaces) != len(expected) {
+		t.Fatalf("Expected %d namespaces, got %d", len(expected), len(namespaces))
+	}
+	for i, ns := range namespaces {
+		if ns != expected[i] {
+			t.Errorf("Expected namespace %s at index %d, got %s", expected[i], i, ns)
+		}
+	}
+}
+
 func TestMockClient_NotImplemented(t *testing.T) {
 	mock := NewMockClient()
 
@@ -299,4 +488,88 @@ func TestMockClient_NotImplemented(t *testing.T) {
 	if err == nil {
 		t.Error("Expected error for unimplemented GetEvents, got nil")
 	}
+
+	_, err = mock.ListNamespaces(context.Background())
+	if err == nil {
+		t.Error("Expected error for unimplemented ListNamespaces, got nil")
+	}
+
+	_, err = mock.ListHelmRevisions(context.Background(), "default", "test")
+	if err == nil {
+		t.Error("Expected error for unimplemented ListHelmRevisions, got nil")
+	}
+
+	err = mock.DeletePod(context.Background(), "default", "test")
+	if err == nil {
+		t.Error("Expected error for unimplemented DeletePod, got nil")
+	}
+
+	_, err = mock.ListServices(context.Background(), "default")
+	if err == nil {
+		t.Error("Expected error for unimplemented ListServices, got nil")
+	}
+}
+
+func TestNewClient_Backend(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	config := `apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+- name: c
+  cluster:
+    server: https://127.0.0.1:6443
+contexts:
+- name: dev
+  context:
+    cluster: c
+`
+	if err := os.WriteFile(kubeconfig, []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	defer func(orig func(string) (string, error)) { lookPath = orig }(lookPath)
+
+	tests := []struct {
+		name       string
+		backend    string
+		kubeconfig string
+		hasKubectl bool
+		want       string // concrete type, "" for an error
+	}{
+		{"default", "", kubeconfig, false, "*k8s.ClientGoClient"},
+		{"clientgo", "clientgo", kubeconfig, false, "*k8s.ClientGoClient"},
+		{"kubectl", "kubectl", kubeconfig, false, "*k8s.KubectlClient"},
+		{"kubectl without kubeconfig", "kubectl", missing, false, "*k8s.KubectlClient"},
+		{"fallback to kubectl", "", missing, true, "*k8s.KubectlClient"},
+		{"no fallback without kubectl", "", missing, false, ""},
+		{"unknown backend", "helm", kubeconfig, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvBackend, tt.backend)
+			t.Setenv("KUBECONFIG", tt.kubeconfig)
+			lookPath = func(file string) (string, error) {
+				if tt.hasKubectl {
+					return "/usr/bin/" + file, nil
+				}
+				return "", exec.ErrNotFound
+			}
+
+			c, err := NewClient("dev")
+			if tt.want == "" {
+				if err == nil {
+					t.Errorf("NewClient() = %T, want an error", c)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewClient() error = %v", err)
+			}
+			if got := fmt.Sprintf("%T", c); got != tt.want {
+				t.Errorf("NewClient() = %s, want %s", got, tt.want)
+			}
+		})
+	}
 }
@@ -1,39 +1,54 @@
 package k8s
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
-	"path/filepath"
-	"sort"
+	"math"
+	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
-	"k8s.io/client-go/util/homedir"
+	"k8s.io/client-go/tools/remotecommand"
+	metricsclient "k8s.io/metrics/pkg/client/clientset/versioned"
 	"sigs.k8s.io/yaml"
 )
 
 // ClientGoClient implements Client interface using client-go
 type ClientGoClient struct {
 	clientset *kubernetes.Clientset
-	context   string // kubeconfig context name
+	config    *rest.Config            // streaming transports (port-forward, exec)
+	dynamic   dynamic.Interface       // arbitrary kinds for GetResource
+	mapper    meta.RESTMapper         // resource names to API resources
+	metrics   metricsclient.Interface // metrics.k8s.io (pod usage)
+	context   string                  // kubeconfig context name
+	helm      *HelmSDKClient          // release history and rollbacks
 }
 
 // NewClientGoClient creates a new client-go based client
 func NewClientGoClient(kubeContext string) (*ClientGoClient, error) {
-	kubeconfig := filepath.Join(homedir.HomeDir(), ".kube", "config")
+	return NewClientGoClientWithOptions(kubeContext, Options{})
+}
 
+// NewClientGoClientWithOptions creates a new client-go based client with
+// the rate limiter configured from opts
+func NewClientGoClientWithOptions(kubeContext string, opts Options) (*ClientGoClient, error) {
 	// Load config with specific context
-	configLoadingRules := &clientcmd.ClientConfigLoadingRules{
-		ExplicitPath: kubeconfig,
-	}
+	configLoadingRules := loadingRules()
 	configOverrides := &clientcmd.ConfigOverrides{}
 	if kubeContext != "" {
 		configOverrides.CurrentContext = kubeContext
@@ -47,14 +62,34 @@ func NewClientGoClient(kubeContext string) (*ClientGoClient, error) {
 		return nil, err
 	}
 
+	if opts.QPS > 0 {
+		config.QPS = opts.QPS
+	}
+	if opts.Burst > 0 {
+		config.Burst = opts.Burst
+	}
+
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, err
 	}
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	metrics, err := metricsclient.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
 
 	return &ClientGoClient{
 		clientset: clientset,
+		config:    config,
+		dynamic:   dynamicClient,
+		mapper:    newRESTMapper(clientset.Discovery()),
+		metrics:   metrics,
 		context:   kubeContext,
+		helm:      NewHelmSDKClient(kubeContext),
 	}, nil
 }
 
@@ -169,10 +204,41 @@ func (c *ClientGoClient) ListDeployments(ctx context.Context, namespace string)
 	return names, nil
 }
 
+// DescribeDeployment renders a describe-style summary of a deployment and
+// its recent events
+func (c *ClientGoClient) DescribeDeployment(ctx context.Context, namespace, name string) ([]byte, error) {
+	deployment, err := c.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, HandleK8sError(err, "deployment", name)
+	}
+	events := c.objectEvents(ctx, namespace, "Deployment", name)
+	return FormatDeploymentDescription(deployment, events, time.Now()), nil
+}
+
 // ============================================================================
 // Pod Operations
 // ============================================================================
 
+// GetPod retrieves a pod as YAML
+func (c *ClientGoClient) GetPod(ctx context.Context, namespace, name string) ([]byte, error) {
+	slog.Debug("fetching pod", "pod", name, "namespace", namespace)
+
+	pod, err := c.clientset.CoreV1().Pods(namespace).Get(
+		ctx,
+		name,
+		metav1.GetOptions{},
+	)
+	if err != nil {
+		slog.Error("failed to fetch pod", "pod", name, "namespace", namespace, "error", err)
+		return nil, HandleK8sError(err, "pod", name)
+	}
+
+	// Typed objects come back without apiVersion/kind; set them so the YAML
+	// matches kubectl get pod -o yaml
+	pod.APIVersion, pod.Kind = "v1", "Pod"
+	return yaml.Marshal(pod)
+}
+
 // ListPods lists pods in a namespace with optional label selector
 func (c *ClientGoClient) ListPods(ctx context.Context, namespace, selector string) ([]byte, error) {
 	slog.Debug("listing pods", "namespace", namespace, "selector", selector)
@@ -198,11 +264,47 @@ func (c *ClientGoClient) ListPods(ctx context.Context, namespace, selector strin
 	return data, nil
 }
 
+// ListAllPods lists pods across all namespaces with optional label selector
+func (c *ClientGoClient) ListAllPods(ctx context.Context, selector string) ([]byte, error) {
+	slog.Debug("listing pods in all namespaces", "selector", selector)
+
+	pods, err := c.clientset.CoreV1().Pods(metav1.NamespaceAll).List(
+		ctx,
+		metav1.ListOptions{
+			LabelSelector: selector,
+		},
+	)
+	if err != nil {
+		slog.Error("failed to list pods in all namespaces", "error", err)
+		if IsForbidden(err) {
+			return nil, fmt.Errorf("%w listing pods in all namespaces: %v", ErrForbidden, err)
+		}
+		return nil, err
+	}
+
+	data, err := json.Marshal(pods)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("pods listed in all namespaces", "count", len(pods.Items))
+	return data, nil
+}
+
 // GetPodLogs retrieves logs from a pod
 func (c *ClientGoClient) GetPodLogs(ctx context.Context, namespace, podName string, tailLines int, allContainers, prefix bool) ([]byte, error) {
+	return c.GetPodLogsWithOptions(ctx, namespace, podName, LogOptions{
+		TailLines:     tailLines,
+		AllContainers: allContainers,
+		Prefix:        prefix,
+	})
+}
+
+// GetPodLogsWithOptions retrieves logs from a pod using the given options
+func (c *ClientGoClient) GetPodLogsWithOptions(ctx context.Context, namespace, podName string, opts LogOptions) ([]byte, error) {
 	var logs []byte
 
-	if allContainers {
+	if opts.AllContainers && opts.Container == "" {
 		// Get pod to enumerate containers
 		pod, err := c.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
 		if err != nil {
@@ -211,10 +313,13 @@ func (c *ClientGoClient) GetPodLogs(ctx context.Context, namespace, podName stri
 
 		// Fetch logs for each container
 		for _, container := range pod.Spec.Containers {
-			tailLinesPtr := int64(tailLines)
+			tailLinesPtr := int64(opts.TailLines)
 			podLogOpts := &corev1.PodLogOptions{
-				Container: container.Name,
-				TailLines: &tailLinesPtr,
+				Container:    container.Name,
+				TailLines:    &tailLinesPtr,
+				Previous:     opts.Previous,
+				SinceSeconds: sinceSeconds(opts.Since),
+				Timestamps:   opts.Timestamps,
 			}
 
 			stream, err := c.clientset.CoreV1().Pods(namespace).GetLogs(podName, podLogOpts).Stream(ctx)
@@ -230,7 +335,7 @@ func (c *ClientGoClient) GetPodLogs(ctx context.Context, namespace, podName stri
 			}
 
 			// Add prefix if requested
-			if prefix {
+			if opts.Prefix {
 				lines := strings.Split(string(containerLogs), "\n")
 				for _, line := range lines {
 					if line != "" {
@@ -244,9 +349,13 @@ func (c *ClientGoClient) GetPodLogs(ctx context.Context, namespace, podName stri
 		}
 	} else {
 		// Single container (or default)
-		tailLinesPtr := int64(tailLines)
+		tailLinesPtr := int64(opts.TailLines)
 		podLogOpts := &corev1.PodLogOptions{
-			TailLines: &tailLinesPtr,
+			Container:    opts.Container,
+			TailLines:    &tailLinesPtr,
+			Previous:     opts.Previous,
+			SinceSeconds: sinceSeconds(opts.Since),
+			Timestamps:   opts.Timestamps,
 		}
 
 		stream, err := c.clientset.CoreV1().Pods(namespace).GetLogs(podName, podLogOpts).Stream(ctx)
@@ -264,6 +373,74 @@ func (c *ClientGoClient) GetPodLogs(ctx context.Context, namespace, podName stri
 	return logs, nil
 }
 
+// sinceSeconds converts a LogOptions.Since window for PodLogOptions
+// (nil for no limit, at least one second otherwise)
+func sinceSeconds(since time.Duration) *int64 {
+	if since <= 0 {
+		return nil
+	}
+	seconds := int64(math.Ceil(since.Seconds()))
+	return &seconds
+}
+
+// StreamPodLogs streams the logs of opts.Container, or the pod's first
+// container, line by line until ctx is cancelled or, without follow, the
+// existing logs are read
+func (c *ClientGoClient) StreamPodLogs(ctx context.Context, namespace, podName string, follow bool, opts LogOptions) (<-chan []byte, error) {
+	container := opts.Container
+	if container == "" {
+		pod, err := c.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		if len(pod.Spec.Containers) == 0 {
+			return nil, fmt.Errorf("pod %s has no containers", podName)
+		}
+		container = pod.Spec.Containers[0].Name
+	}
+
+	tailLinesPtr := int64(opts.TailLines)
+	podLogOpts := &corev1.PodLogOptions{
+		Container:    container,
+		TailLines:    &tailLinesPtr,
+		Follow:       follow,
+		SinceSeconds: sinceSeconds(opts.Since),
+		Timestamps:   opts.Timestamps,
+	}
+	stream, err := c.clientset.CoreV1().Pods(namespace).GetLogs(podName, podLogOpts).Stream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	slog.Debug("streaming pod logs", "pod", podName, "container", podLogOpts.Container, "follow", follow)
+	return streamLines(ctx, stream, stream.Close), nil
+}
+
+// WatchPods pushes changes to the pods matching selector until ctx is
+// cancelled. The first watch is opened before returning so RBAC or
+// connection errors surface here.
+func (c *ClientGoClient) WatchPods(ctx context.Context, namespace, selector string) (<-chan PodEvent, error) {
+	start := func(ctx context.Context) (watch.Interface, error) {
+		return c.clientset.CoreV1().Pods(namespace).Watch(ctx, metav1.ListOptions{LabelSelector: selector})
+	}
+	w, err := start(ctx)
+	if err != nil {
+		slog.Debug("failed to watch pods", "namespace", namespace, "selector", selector, "error", err)
+		return nil, err
+	}
+	slog.Debug("watching pods", "namespace", namespace, "selector", selector)
+	return relayPodEvents(ctx, w, start), nil
+}
+
+// DescribePod renders a describe-style summary of a pod and its recent events
+func (c *ClientGoClient) DescribePod(ctx context.Context, namespace, name string) ([]byte, error) {
+	pod, err := c.clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, HandleK8sError(err, "pod", name)
+	}
+	events := c.objectEvents(ctx, namespace, "Pod", name)
+	return FormatPodDescription(pod, events, time.Now()), nil
+}
+
 // GetPodContainers retrieves the list of container names in a pod
 func (c *ClientGoClient) GetPodContainers(ctx context.Context, namespace, podName string) ([]string, error) {
 	pod, err := c.clientset.CoreV1().Pods(namespace).Get(
@@ -284,6 +461,65 @@ func (c *ClientGoClient) GetPodContainers(ctx context.Context, namespace, podNam
 	return names, nil
 }
 
+// DeletePod deletes a pod; its controller (if any) recreates it
+func (c *ClientGoClient) DeletePod(ctx context.Context, namespace, podName string) error {
+	slog.Info("deleting pod", "pod", podName, "namespace", namespace)
+
+	err := c.clientset.CoreV1().Pods(namespace).Delete(ctx, podName, metav1.DeleteOptions{})
+	if err != nil {
+		slog.Error("failed to delete pod", "pod", podName, "namespace", namespace, "error", err)
+		return HandleK8sError(err, "pod", podName)
+	}
+
+	slog.Info("pod deleted successfully", "pod", podName)
+	return nil
+}
+
+// ExecInPod runs a command in a pod container over SPDY and returns its
+// combined output; a non-zero exit status is returned as an error along
+// with the output
+func (c *ClientGoClient) ExecInPod(ctx context.Context, namespace, podName, container string, command []string) ([]byte, error) {
+	slog.Info("exec in pod", "pod", podName, "container", container, "namespace", namespace, "command", command)
+	req := c.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(namespace).Name(podName).SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+	executor, err := remotecommand.NewSPDYExecutor(c.config, http.MethodPost, req.URL())
+	if err != nil {
+		return nil, err
+	}
+
+	// stdout and stderr are copied from separate goroutines
+	out := &lockedBuffer{}
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: out, Stderr: out})
+	if err != nil {
+		return out.Bytes(), HandleK8sError(err, "pod", podName)
+	}
+	return out.Bytes(), nil
+}
+
+// lockedBuffer is a bytes.Buffer safe for concurrent writes
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Bytes()
+}
+
 // ============================================================================
 // Resource Operations (Secrets, ConfigMaps)
 // ============================================================================
@@ -296,7 +532,7 @@ func (c *ClientGoClient) GetSecret(ctx context.Context, namespace, name string)
 		metav1.GetOptions{},
 	)
 	if err != nil {
-		return nil, err
+		return nil, HandleK8sError(err, "secret", name)
 	}
 
 	// Marshal to JSON (matches kubectl get secret -o json)
@@ -318,11 +554,18 @@ func (c *ClientGoClient) GetConfigMap(ctx context.Context, namespace, name strin
 	return yaml.Marshal(configMap)
 }
 
-// GetResource retrieves a generic resource (stub for now)
-func (c *ClientGoClient) GetResource(ctx context.Context, namespace, kind, name, outputFormat string) ([]byte, error) {
-	// For v2.1.0, most resources use typed methods
-	// Dynamic client implementation can be added in future if needed
-	return nil, fmt.Errorf("GetResource not yet implemented in client-go, use typed methods")
+// ListServices lists the services of a namespace as JSON
+func (c *ClientGoClient) ListServices(ctx context.Context, namespace string) ([]byte, error) {
+	slog.Debug("listing services", "namespace", namespace)
+
+	services, err := c.clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		slog.Error("failed to list services", "namespace", namespace, "error", err)
+		return nil, err
+	}
+
+	slog.Debug("services listed", "namespace", namespace, "count", len(services.Items))
+	return json.Marshal(services)
 }
 
 // ============================================================================
@@ -339,31 +582,63 @@ func (c *ClientGoClient) GetEvents(ctx context.Context, namespace string) ([]byt
 		return nil, err
 	}
 
-	// Sort by lastTimestamp (kubectl --sort-by=.lastTimestamp equivalent)
-	sort.Slice(events.Items, func(i, j int) bool {
-		return events.Items[i].LastTimestamp.Before(&events.Items[j].LastTimestamp)
-	})
+	// Sort by lastTimestamp, falling back to eventTime/firstTimestamp
+	SortEvents(events.Items)
 
 	// Marshal to JSON
 	return json.Marshal(events)
 }
 
 // ============================================================================
-// Helm Operations (Delegated to CLI - Hybrid Approach)
+// Namespace Operations
 // ============================================================================
 
-// GetHelmHistory fetches helm release history (uses CLI)
-func (c *ClientGoClient) GetHelmHistory(ctx context.Context, namespace, releaseName string) ([]byte, error) {
-	// Helm operations stay as CLI for v2.1.0 (no good Go SDK)
-	// Delegate to KubectlClient
-	kubectlClient := &KubectlClient{Context: c.context}
-	return kubectlClient.GetHelmHistory(ctx, namespace, releaseName)
+// ListNamespaces lists all namespaces in the cluster
+func (c *ClientGoClient) ListNamespaces(ctx context.Context) ([]string, error) {
+	slog.Debug("listing namespaces")
+
+	namespaces, err := c.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		slog.Error("failed to list namespaces", "error", err)
+		return nil, err
+	}
+
+	names := make([]string, len(namespaces.Items))
+	for i, ns := range namespaces.Items {
+		names[i] = ns.Name
+	}
+
+	slog.Debug("namespaces listed", "count", len(names))
+	return names, nil
+}
+
+// ============================================================================
+// Helm Operations (History/Rollback via the Helm SDK, the rest via CLI)
+// ============================================================================
+
+// ListHelmRevisions fetches the revisions of a release, oldest first (uses the Helm SDK)
+func (c *ClientGoClient) ListHelmRevisions(ctx context.Context, namespace, releaseName string) ([]HelmRevision, error) {
+	return c.helm.History(ctx, namespace, releaseName)
 }
 
-// RollbackHelm rolls back a helm release (uses CLI)
+// RollbackHelm rolls back a helm release (uses the Helm SDK)
 func (c *ClientGoClient) RollbackHelm(ctx context.Context, namespace, releaseName string, revision int) error {
-	// Helm operations stay as CLI for v2.1.0 (no good Go SDK)
-	// Delegate to KubectlClient
+	return c.helm.Rollback(ctx, namespace, releaseName, revision)
+}
+
+// GetHelmManifest fetches the rendered manifest of a release revision (uses the Helm SDK)
+func (c *ClientGoClient) GetHelmManifest(ctx context.Context, namespace, releaseName string, revision int) ([]byte, error) {
+	return c.helm.Manifest(ctx, namespace, releaseName, revision)
+}
+
+// GetHelmNotes fetches a release's rendered NOTES.txt (uses CLI)
+func (c *ClientGoClient) GetHelmNotes(ctx context.Context, namespace, releaseName string) ([]byte, error) {
+	kubectlClient := &KubectlClient{Context: c.context}
+	return kubectlClient.GetHelmNotes(ctx, namespace, releaseName)
+}
+
+// GetHelmHooks fetches a release's hook manifests (uses CLI)
+func (c *ClientGoClient) GetHelmHooks(ctx context.Context, namespace, releaseName string) ([]byte, error) {
 	kubectlClient := &KubectlClient{Context: c.context}
-	return kubectlClient.RollbackHelm(ctx, namespace, releaseName, revision)
+	return kubectlClient.GetHelmHooks(ctx, namespace, releaseName)
 }
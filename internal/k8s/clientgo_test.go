@@ -2,8 +2,12 @@ package k8s
 
 import (
 	"context"
+	"reflect"
 	"testing"
 	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
 )
 
 // TestClientGoClient_Integration tests ClientGoClient against a real cluster
@@ -188,3 +192,71 @@ func TestClientGoClient_OperationTypes(t *testing.T) {
 		}
 	})
 }
+
+// TestClientGoClient_GetResourceDynamic fetches a configmap through the
+// dynamic client and compares it to the typed GetConfigMap
+func TestClientGoClient_GetResourceDynamic(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	client, err := NewClientGoClient("")
+	if err != nil {
+		t.Fatalf("Failed to create ClientGoClient: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Published into every namespace since Kubernetes 1.20
+	testNamespace, name := "default", "kube-root-ca.crt"
+
+	typed, err := client.GetConfigMap(ctx, testNamespace, name)
+	if err != nil {
+		t.Fatalf("GetConfigMap failed: %v", err)
+	}
+	dynamic, err := client.GetResource(ctx, testNamespace, "configmap", name, "yaml")
+	if err != nil {
+		t.Fatalf("GetResource failed: %v", err)
+	}
+
+	var typedCM, dynamicCM corev1.ConfigMap
+	if err := yaml.Unmarshal(typed, &typedCM); err != nil {
+		t.Fatalf("Invalid typed configmap: %v", err)
+	}
+	if err := yaml.Unmarshal(dynamic, &dynamicCM); err != nil {
+		t.Fatalf("Invalid dynamic configmap: %v", err)
+	}
+	if dynamicCM.UID != typedCM.UID || dynamicCM.ResourceVersion != typedCM.ResourceVersion {
+		t.Errorf("Expected the same object, got uid %s/%s resourceVersion %s/%s",
+			dynamicCM.UID, typedCM.UID, dynamicCM.ResourceVersion, typedCM.ResourceVersion)
+	}
+	if !reflect.DeepEqual(dynamicCM.Data, typedCM.Data) {
+		t.Errorf("Expected the same data, got %v and %v", dynamicCM.Data, typedCM.Data)
+	}
+}
+
+func TestSinceSeconds(t *testing.T) {
+	tests := []struct {
+		since time.Duration
+		want  int64 // 0 means nil
+	}{
+		{0, 0},
+		{-time.Minute, 0},
+		{500 * time.Millisecond, 1},
+		{10 * time.Minute, 600},
+		{90*time.Second + time.Millisecond, 91},
+	}
+	for _, tt := range tests {
+		got := sinceSeconds(tt.since)
+		if tt.want == 0 {
+			if got != nil {
+				t.Errorf("sinceSeconds(%v) = %d, want nil", tt.since, *got)
+			}
+			continue
+		}
+		if got == nil || *got != tt.want {
+			t.Errorf("sinceSeconds(%v) = %v, want %d", tt.since, got, tt.want)
+		}
+	}
+}
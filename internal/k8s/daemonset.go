@@ -0,0 +1,94 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// GetDaemonSet fetches daemonset information as JSON
+func (c *KubectlClient) GetDaemonSet(ctx context.Context, namespace, name string) ([]byte, error) {
+	slog.Debug("fetching daemonset", "daemonset", name, "namespace", namespace, "context", c.Context)
+	data, err := c.runCmd(ctx, "kubectl", "get", "daemonset", name,
+		"-n", namespace,
+		"--context", c.Context,
+		"-o", "json")
+	if err != nil {
+		slog.Error("failed to fetch daemonset", "daemonset", name, "namespace", namespace, "error", err)
+		return nil, err
+	}
+	return data, nil
+}
+
+// RestartDaemonSet restarts a daemonset's pods node by node
+func (c *KubectlClient) RestartDaemonSet(ctx context.Context, namespace, name string) error {
+	slog.Info("restarting daemonset", "daemonset", name, "namespace", namespace)
+	_, err := c.runCmd(ctx, "kubectl", "rollout", "restart", "daemonset", name,
+		"-n", namespace,
+		"--context", c.Context)
+	if err != nil {
+		slog.Error("failed to restart daemonset", "daemonset", name, "error", err)
+		return err
+	}
+	return nil
+}
+
+// ListDaemonSets lists all daemonsets in a namespace
+func (c *KubectlClient) ListDaemonSets(ctx context.Context, namespace string) ([]string, error) {
+	out, err := c.runCmd(ctx, "kubectl", "get", "daemonsets",
+		"-n", namespace,
+		"--context", c.Context,
+		"-o", "jsonpath={.items[*].metadata.name}")
+	if err != nil {
+		slog.Error("failed to list daemonsets", "namespace", namespace, "error", err)
+		return nil, err
+	}
+	return strings.Fields(strings.TrimSpace(string(out))), nil
+}
+
+// GetDaemonSet retrieves a daemonset as JSON
+func (c *ClientGoClient) GetDaemonSet(ctx context.Context, namespace, name string) ([]byte, error) {
+	slog.Debug("fetching daemonset", "daemonset", name, "namespace", namespace, "context", c.context)
+	ds, err := c.clientset.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		slog.Error("failed to fetch daemonset", "daemonset", name, "namespace", namespace, "error", err)
+		return nil, HandleK8sError(err, "daemonset", name)
+	}
+	return json.Marshal(ds)
+}
+
+// RestartDaemonSet restarts a daemonset (rollout restart): the controller
+// replaces its pods node by node, as its update strategy allows
+func (c *ClientGoClient) RestartDaemonSet(ctx context.Context, namespace, name string) error {
+	slog.Info("restarting daemonset", "daemonset", name, "namespace", namespace)
+	patchData := []byte(fmt.Sprintf(
+		`{"spec": {"template": {"metadata": {"annotations": {"kubectl.kubernetes.io/restartedAt": "%s"}}}}}`,
+		time.Now().Format(time.RFC3339),
+	))
+	_, err := c.clientset.AppsV1().DaemonSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, patchData, metav1.PatchOptions{})
+	if err != nil {
+		slog.Error("failed to restart daemonset", "daemonset", name, "error", err)
+		return err
+	}
+	return nil
+}
+
+// ListDaemonSets lists all daemonsets in a namespace
+func (c *ClientGoClient) ListDaemonSets(ctx context.Context, namespace string) ([]string, error) {
+	list, err := c.clientset.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		slog.Error("failed to list daemonsets", "namespace", namespace, "error", err)
+		return nil, err
+	}
+	names := make([]string, len(list.Items))
+	for i, ds := range list.Items {
+		names[i] = ds.Name
+	}
+	return names, nil
+}
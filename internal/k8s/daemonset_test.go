@@ -0,0 +1,38 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMockClient_DaemonSetOperations(t *testing.T) {
+	mock := NewMockClient()
+	ctx := context.Background()
+
+	if _, err := mock.GetDaemonSet(ctx, "default", "node-agent"); err == nil {
+		t.Error("Expected error for unimplemented GetDaemonSet, got nil")
+	}
+
+	restarted := ""
+	mock.GetDaemonSetFunc = func(ctx context.Context, namespace, name string) ([]byte, error) {
+		return []byte(`{"status":{"desiredNumberScheduled":3}}`), nil
+	}
+	mock.RestartDaemonSetFunc = func(ctx context.Context, namespace, name string) error {
+		restarted = name
+		return nil
+	}
+	mock.ListDaemonSetsFunc = func(ctx context.Context, namespace string) ([]string, error) {
+		return []string{"node-agent"}, nil
+	}
+
+	if out, err := mock.GetDaemonSet(ctx, "default", "node-agent"); err != nil || len(out) == 0 {
+		t.Errorf("GetDaemonSet = %q, %v", out, err)
+	}
+	if err := mock.RestartDaemonSet(ctx, "default", "node-agent"); err != nil || restarted != "node-agent" {
+		t.Errorf("RestartDaemonSet: err %v, restarted %q", err, restarted)
+	}
+	names, err := mock.ListDaemonSets(ctx, "default")
+	if err != nil || len(names) != 1 {
+		t.Errorf("ListDaemonSets = %v, %v", names, err)
+	}
+}
@@ -0,0 +1,250 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// DescribeEventLimit is how many of an object's most recent events a
+// description lists
+const DescribeEventLimit = 10
+
+// DescribeDeployment describes a deployment (uses kubectl describe)
+func (c *KubectlClient) DescribeDeployment(ctx context.Context, namespace, name string) ([]byte, error) {
+	return c.runCmd(ctx, "kubectl", "describe", "deployment", name,
+		"-n", namespace,
+		"--context", c.Context)
+}
+
+// DescribePod describes a pod (uses kubectl describe)
+func (c *KubectlClient) DescribePod(ctx context.Context, namespace, name string) ([]byte, error) {
+	return c.runCmd(ctx, "kubectl", "describe", "pod", name,
+		"-n", namespace,
+		"--context", c.Context)
+}
+
+// FormatDeploymentDescription renders a kubectl describe-style summary of a
+// deployment: replicas, strategy, containers, conditions and events
+func FormatDeploymentDescription(d *appsv1.Deployment, events []corev1.Event, now time.Time) []byte {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Name:\t%s\n", d.Name)
+	fmt.Fprintf(w, "Namespace:\t%s\n", d.Namespace)
+	fmt.Fprintf(w, "CreationTimestamp:\t%s\n", d.CreationTimestamp.Format(time.RFC1123Z))
+	fmt.Fprintf(w, "Labels:\t%s\n", formatMap(d.Labels))
+	selector := "<none>"
+	if d.Spec.Selector != nil {
+		selector = formatMap(d.Spec.Selector.MatchLabels)
+	}
+	fmt.Fprintf(w, "Selector:\t%s\n", selector)
+
+	desired := int32(1)
+	if d.Spec.Replicas != nil {
+		desired = *d.Spec.Replicas
+	}
+	fmt.Fprintf(w, "Replicas:\t%d desired | %d updated | %d total | %d available | %d unavailable\n",
+		desired, d.Status.UpdatedReplicas, d.Status.Replicas, d.Status.AvailableReplicas, d.Status.UnavailableReplicas)
+	fmt.Fprintf(w, "StrategyType:\t%s\n", d.Spec.Strategy.Type)
+	if ru := d.Spec.Strategy.RollingUpdate; ru != nil && ru.MaxUnavailable != nil && ru.MaxSurge != nil {
+		fmt.Fprintf(w, "RollingUpdateStrategy:\t%s max unavailable, %s max surge\n", ru.MaxUnavailable.String(), ru.MaxSurge.String())
+	}
+	w.Flush()
+
+	buf.WriteString("Pod Template:\n")
+	writeContainers(&buf, d.Spec.Template.Spec.Containers, nil)
+
+	buf.WriteString("Conditions:\n")
+	if len(d.Status.Conditions) == 0 {
+		buf.WriteString("  <none>\n")
+	} else {
+		w = tabwriter.NewWriter(&buf, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "  Type\tStatus\tReason")
+		fmt.Fprintln(w, "  ----\t------\t------")
+		for _, c := range d.Status.Conditions {
+			fmt.Fprintf(w, "  %s\t%s\t%s\n", c.Type, c.Status, c.Reason)
+		}
+		w.Flush()
+	}
+
+	writeEvents(&buf, events, now)
+	return buf.Bytes()
+}
+
+// FormatPodDescription renders a kubectl describe-style summary of a pod:
+// status, containers with their state, conditions and events
+func FormatPodDescription(p *corev1.Pod, events []corev1.Event, now time.Time) []byte {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintf(w, "Name:\t%s\n", p.Name)
+	fmt.Fprintf(w, "Namespace:\t%s\n", p.Namespace)
+	fmt.Fprintf(w, "Node:\t%s\n", valueOrNone(p.Spec.NodeName))
+	if p.Status.StartTime != nil {
+		fmt.Fprintf(w, "Start Time:\t%s\n", p.Status.StartTime.Format(time.RFC1123Z))
+	}
+	fmt.Fprintf(w, "Labels:\t%s\n", formatMap(p.Labels))
+	fmt.Fprintf(w, "Status:\t%s\n", p.Status.Phase)
+	if p.Status.Reason != "" {
+		fmt.Fprintf(w, "Reason:\t%s\n", p.Status.Reason)
+	}
+	fmt.Fprintf(w, "IP:\t%s\n", valueOrNone(p.Status.PodIP))
+	if len(p.OwnerReferences) > 0 {
+		fmt.Fprintf(w, "Controlled By:\t%s/%s\n", p.OwnerReferences[0].Kind, p.OwnerReferences[0].Name)
+	}
+	w.Flush()
+
+	if len(p.Spec.InitContainers) > 0 {
+		buf.WriteString("Init Containers:\n")
+		writeContainers(&buf, p.Spec.InitContainers, p.Status.InitContainerStatuses)
+	}
+	buf.WriteString("Containers:\n")
+	writeContainers(&buf, p.Spec.Containers, p.Status.ContainerStatuses)
+
+	buf.WriteString("Conditions:\n")
+	if len(p.Status.Conditions) == 0 {
+		buf.WriteString("  <none>\n")
+	} else {
+		w = tabwriter.NewWriter(&buf, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "  Type\tStatus")
+		for _, c := range p.Status.Conditions {
+			fmt.Fprintf(w, "  %s\t%s\n", c.Type, c.Status)
+		}
+		w.Flush()
+	}
+
+	writeEvents(&buf, events, now)
+	return buf.Bytes()
+}
+
+// writeContainers lists containers with image, ports, resources and, when
+// statuses are given, their state and restart count
+func writeContainers(buf *bytes.Buffer, containers []corev1.Container, statuses []corev1.ContainerStatus) {
+	if len(containers) == 0 {
+		buf.WriteString("  <none>\n")
+		return
+	}
+	for _, c := range containers {
+		fmt.Fprintf(buf, "  %s:\n", c.Name)
+		w := tabwriter.NewWriter(buf, 0, 8, 2, ' ', 0)
+		fmt.Fprintf(w, "    Image:\t%s\n", c.Image)
+		if len(c.Ports) > 0 {
+			ports := make([]string, len(c.Ports))
+			for i, p := range c.Ports {
+				ports[i] = fmt.Sprintf("%d/%s", p.ContainerPort, p.Protocol)
+			}
+			fmt.Fprintf(w, "    Ports:\t%s\n", strings.Join(ports, ", "))
+		}
+		for _, s := range statuses {
+			if s.Name != c.Name {
+				continue
+			}
+			fmt.Fprintf(w, "    State:\t%s\n", containerState(s.State))
+			if s.LastTerminationState.Terminated != nil {
+				fmt.Fprintf(w, "    Last State:\t%s\n", containerState(s.LastTerminationState))
+			}
+			fmt.Fprintf(w, "    Ready:\t%t\n", s.Ready)
+			fmt.Fprintf(w, "    Restart Count:\t%d\n", s.RestartCount)
+		}
+		fmt.Fprintf(w, "    Requests:\t%s\n", formatResources(c.Resources.Requests))
+		fmt.Fprintf(w, "    Limits:\t%s\n", formatResources(c.Resources.Limits))
+		w.Flush()
+	}
+}
+
+// containerState summarizes a container state ("Running", "Waiting (CrashLoopBackOff)", ...)
+func containerState(s corev1.ContainerState) string {
+	switch {
+	case s.Running != nil:
+		return "Running"
+	case s.Waiting != nil:
+		return fmt.Sprintf("Waiting (%s)", s.Waiting.Reason)
+	case s.Terminated != nil:
+		return fmt.Sprintf("Terminated (%s, exit code %d)", s.Terminated.Reason, s.Terminated.ExitCode)
+	}
+	return "<unknown>"
+}
+
+// writeEvents lists the most recent events, oldest first, with their age
+func writeEvents(buf *bytes.Buffer, events []corev1.Event, now time.Time) {
+	buf.WriteString("Events:\n")
+	if len(events) == 0 {
+		buf.WriteString("  <none>\n")
+		return
+	}
+	sorted := append([]corev1.Event(nil), events...)
+	SortEvents(sorted)
+	if len(sorted) > DescribeEventLimit {
+		sorted = sorted[len(sorted)-DescribeEventLimit:]
+	}
+
+	w := tabwriter.NewWriter(buf, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "  Type\tReason\tAge\tFrom\tMessage")
+	fmt.Fprintln(w, "  ----\t------\t---\t----\t-------")
+	for _, e := range sorted {
+		age := "<unknown>"
+		if at := EventTime(e); !at.IsZero() {
+			age = duration.HumanDuration(now.Sub(at))
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", e.Type, e.Reason, age, e.Source.Component, strings.TrimSpace(e.Message))
+	}
+	w.Flush()
+}
+
+// formatMap renders labels as sorted key=value pairs
+func formatMap(m map[string]string) string {
+	if len(m) == 0 {
+		return "<none>"
+	}
+	pairs := make([]string, 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// formatResources renders a resource list as sorted name=quantity pairs
+func formatResources(r corev1.ResourceList) string {
+	if len(r) == 0 {
+		return "<none>"
+	}
+	pairs := make([]string, 0, len(r))
+	for name, q := range r {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", name, q.String()))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+// valueOrNone returns s, or "<none>" when it is empty
+func valueOrNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
+
+// objectEvents lists the events whose involved object is kind/name
+func (c *ClientGoClient) objectEvents(ctx context.Context, namespace, kind, name string) []corev1.Event {
+	selector := fields.Set{"involvedObject.kind": kind, "involvedObject.name": name}.String()
+	events, err := c.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: selector})
+	if err != nil {
+		// The description is still useful without its events
+		slog.Debug("failed to list events for describe", "kind", kind, "name", name, "error", err)
+		return nil
+	}
+	return events.Items
+}
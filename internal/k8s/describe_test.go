@@ -0,0 +1,126 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestFormatDeploymentDescription(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	replicas := int32(3)
+	maxUnavailable, maxSurge := intstr.FromString("25%"), intstr.FromInt32(1)
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"tier": "front", "app": "web"}},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			Strategy: appsv1.DeploymentStrategy{
+				Type:          appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{MaxUnavailable: &maxUnavailable, MaxSurge: &maxSurge},
+			},
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:  "nginx",
+				Image: "nginx:1.25",
+				Ports: []corev1.ContainerPort{{ContainerPort: 80, Protocol: corev1.ProtocolTCP}},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi"), corev1.ResourceCPU: resource.MustParse("100m")},
+				},
+			}}}},
+		},
+		Status: appsv1.DeploymentStatus{
+			Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 2, UnavailableReplicas: 1,
+			Conditions: []appsv1.DeploymentCondition{{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue, Reason: "MinimumReplicasAvailable"}},
+		},
+	}
+	events := []corev1.Event{{
+		Type: "Normal", Reason: "ScalingReplicaSet", Message: "Scaled up replica set web-abc to 3",
+		Source:        corev1.EventSource{Component: "deployment-controller"},
+		LastTimestamp: metav1.NewTime(now.Add(-5 * time.Minute)),
+	}}
+
+	out := string(FormatDeploymentDescription(d, events, now))
+	for _, want := range []string{
+		"Labels:",
+		"app=web,tier=front",
+		"3 desired | 3 updated | 3 total | 2 available | 1 unavailable",
+		"RollingUpdateStrategy:",
+		"25% max unavailable, 1 max surge",
+		"Image:",
+		"nginx:1.25",
+		"80/TCP",
+		"cpu=100m, memory=128Mi",
+		"MinimumReplicasAvailable",
+		"ScalingReplicaSet",
+		"5m",
+		"deployment-controller",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected description to contain %q, got:\n%s", want, out)
+		}
+	}
+	if !strings.Contains(out, "Limits:") || !strings.Contains(out, "<none>") {
+		t.Errorf("Expected empty limits to render as <none>, got:\n%s", out)
+	}
+}
+
+func TestFormatPodDescription(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	p := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "web-abc-123", Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-abc"}},
+		},
+		Spec: corev1.PodSpec{NodeName: "node-1", Containers: []corev1.Container{{Name: "nginx", Image: "nginx:1.25"}}},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:                 "nginx",
+				RestartCount:         4,
+				State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1}},
+			}},
+		},
+	}
+
+	out := string(FormatPodDescription(p, nil, now))
+	for _, want := range []string{
+		"node-1",
+		"ReplicaSet/web-abc",
+		"Waiting (CrashLoopBackOff)",
+		"Terminated (Error, exit code 1)",
+		"Restart Count:",
+		"IP:",
+		"Events:\n  <none>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected description to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteEvents_Limit(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	var events []corev1.Event
+	for i := 0; i < DescribeEventLimit+5; i++ {
+		events = append(events, corev1.Event{
+			Type: "Normal", Reason: "Reason" + string(rune('A'+i)),
+			LastTimestamp: metav1.NewTime(now.Add(time.Duration(-i) * time.Minute)),
+		})
+	}
+
+	out := string(FormatPodDescription(&corev1.Pod{}, events, now))
+	// The oldest events (highest i) are dropped
+	if strings.Contains(out, "Reason"+string(rune('A'+DescribeEventLimit))) {
+		t.Errorf("Expected only the %d most recent events, got:\n%s", DescribeEventLimit, out)
+	}
+	if !strings.Contains(out, "ReasonA") {
+		t.Errorf("Expected the most recent event, got:\n%s", out)
+	}
+}
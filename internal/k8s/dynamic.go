@@ -0,0 +1,165 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/restmapper"
+	"sigs.k8s.io/yaml"
+)
+
+// newRESTMapper maps resource names ("svc", "jobs", "ingress") to API
+// resources, discovering the cluster's API groups on first use
+func newRESTMapper(dc discovery.DiscoveryInterface) meta.RESTMapper {
+	cached := memory.NewMemCacheClient(dc)
+	return restmapper.NewShortcutExpander(restmapper.NewDeferredDiscoveryRESTMapper(cached), cached, func(warning string) {
+		slog.Debug("rest mapper warning", "warning", warning)
+	})
+}
+
+// resolveResource maps a kind as accepted by kubectl get ("service", "svc",
+// "Service", "ingresses.networking.k8s.io") to its API resource and scope
+func resolveResource(mapper meta.RESTMapper, kind string) (schema.GroupVersionResource, bool, error) {
+	fullySpecified, groupResource := schema.ParseResourceArg(strings.ToLower(kind))
+	var gvr schema.GroupVersionResource
+	var err error
+	if fullySpecified != nil {
+		gvr, err = mapper.ResourceFor(*fullySpecified)
+	}
+	if fullySpecified == nil || err != nil {
+		gvr, err = mapper.ResourceFor(groupResource.WithVersion(""))
+	}
+	if err != nil {
+		return gvr, false, fmt.Errorf("unknown resource type %q: %w", kind, err)
+	}
+
+	gvk, err := mapper.KindFor(gvr)
+	if err != nil {
+		return gvr, false, fmt.Errorf("unknown resource type %q: %w", kind, err)
+	}
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return gvr, false, fmt.Errorf("unknown resource type %q: %w", kind, err)
+	}
+	return gvr, mapping.Scope.Name() == meta.RESTScopeNameNamespace, nil
+}
+
+// GetResource retrieves a resource of any kind (Service, Ingress, Job, PVC,
+// custom resources, ...) as "json" or "yaml", like kubectl get -o
+func (c *ClientGoClient) GetResource(ctx context.Context, namespace, kind, name, outputFormat string) ([]byte, error) {
+	slog.Debug("fetching resource", "kind", kind, "name", name, "namespace", namespace, "context", c.context)
+
+	gvr, namespaced, err := resolveResource(c.mapper, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	var obj *unstructured.Unstructured
+	if namespaced {
+		obj, err = c.dynamic.Resource(gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	} else {
+		obj, err = c.dynamic.Resource(gvr).Get(ctx, name, metav1.GetOptions{})
+	}
+	if err != nil {
+		slog.Error("failed to fetch resource", "kind", kind, "name", name, "namespace", namespace, "error", err)
+		return nil, HandleK8sError(err, strings.ToLower(kind), name)
+	}
+	return formatResource(obj, outputFormat)
+}
+
+// formatResource marshals obj in outputFormat, without managed fields
+// (kubectl hides them by default too)
+func formatResource(obj *unstructured.Unstructured, outputFormat string) ([]byte, error) {
+	obj = obj.DeepCopy()
+	obj.SetManagedFields(nil)
+
+	switch outputFormat {
+	case "json":
+		return json.MarshalIndent(obj.Object, "", "    ")
+	case "yaml":
+		return yaml.Marshal(obj.Object)
+	}
+	return nil, fmt.Errorf("unsupported output format %q (use json or yaml)", outputFormat)
+}
+
+// FieldManager owns the fields k9s-deck sets with server-side apply
+const FieldManager = "k9s-deck"
+
+// manifestObject is the part of a manifest ApplyResource needs to address it
+type manifestObject struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"metadata"`
+}
+
+// parseManifest reads a YAML or JSON manifest, refusing one for another
+// object than name (a renamed copy would be created next to it) or for
+// another namespace than namespace
+func parseManifest(data []byte, namespace, name string) (manifestObject, error) {
+	var obj manifestObject
+	if err := yaml.Unmarshal(data, &obj); err != nil {
+		return obj, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if obj.Metadata.Name == "" {
+		return obj, fmt.Errorf("invalid manifest: metadata.name is missing")
+	}
+	if obj.Metadata.Name != name {
+		return obj, fmt.Errorf("manifest is for %q, not %q (renaming isn't supported)", obj.Metadata.Name, name)
+	}
+	if ns := obj.Metadata.Namespace; ns != "" && ns != namespace {
+		return obj, fmt.Errorf("manifest is for namespace %q, not %q", ns, namespace)
+	}
+	return obj, nil
+}
+
+// ApplyResource server-side applies a YAML or JSON manifest of the object
+// kind/name (kind as accepted by GetResource), taking over fields owned by
+// other managers like kubectl apply --server-side --force-conflicts
+func (c *ClientGoClient) ApplyResource(ctx context.Context, namespace, kind, name string, data []byte) error {
+	obj, err := parseManifest(data, namespace, name)
+	if err != nil {
+		return err
+	}
+	slog.Info("applying resource", "kind", kind, "name", name, "namespace", namespace)
+
+	gvr, namespaced, err := resolveResource(c.mapper, kind)
+	if err != nil {
+		return err
+	}
+	gvk, err := c.mapper.KindFor(gvr)
+	if err != nil {
+		return fmt.Errorf("unknown resource type %q: %w", kind, err)
+	}
+	gv, err := schema.ParseGroupVersion(obj.APIVersion)
+	if err != nil {
+		return fmt.Errorf("invalid manifest: %w", err)
+	}
+	if obj.Kind != gvk.Kind || gv.Group != gvk.Group {
+		return fmt.Errorf("manifest is a %s, not a %s", obj.Kind, gvk.Kind)
+	}
+	force := true
+	opts := metav1.PatchOptions{FieldManager: FieldManager, Force: &force}
+	if namespaced {
+		_, err = c.dynamic.Resource(gvr).Namespace(namespace).Patch(ctx, name, types.ApplyPatchType, data, opts)
+	} else {
+		_, err = c.dynamic.Resource(gvr).Patch(ctx, name, types.ApplyPatchType, data, opts)
+	}
+	if err != nil {
+		slog.Error("failed to apply resource", "kind", kind, "name", name, "error", err)
+		return HandleK8sError(err, strings.ToLower(kind), name)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+// newTestDynamicClient returns a client whose GetResource serves objects
+// from a fake dynamic client, knowing Services, Jobs and Namespaces
+func newTestDynamicClient(objects ...runtime.Object) *ClientGoClient {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Service"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"}, meta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, meta.RESTScopeRoot)
+
+	return &ClientGoClient{
+		dynamic: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), objects...),
+		mapper:  mapper,
+	}
+}
+
+func testObject(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"name":          name,
+			"managedFields": []interface{}{map[string]interface{}{"manager": "kubectl"}},
+		},
+	}}
+	if namespace != "" {
+		obj.SetNamespace(namespace)
+	}
+	return obj
+}
+
+func TestClientGoClient_GetResource(t *testing.T) {
+	c := newTestDynamicClient(
+		testObject("v1", "Service", "default", "web"),
+		testObject("batch/v1", "Job", "default", "migrate"),
+		testObject("v1", "Namespace", "", "staging"),
+	)
+	ctx := context.Background()
+
+	tests := []struct {
+		kind, name, wantKind string
+	}{
+		{"service", "web", "Service"},
+		{"Service", "web", "Service"},
+		{"services", "web", "Service"},
+		{"job", "migrate", "Job"},
+		{"jobs.batch", "migrate", "Job"},
+		{"namespace", "staging", "Namespace"},
+	}
+	for _, tt := range tests {
+		out, err := c.GetResource(ctx, "default", tt.kind, tt.name, "json")
+		if err != nil {
+			t.Errorf("GetResource(%s/%s): unexpected error %v", tt.kind, tt.name, err)
+			continue
+		}
+		var obj map[string]interface{}
+		if err := json.Unmarshal(out, &obj); err != nil {
+			t.Errorf("GetResource(%s/%s): invalid JSON: %v", tt.kind, tt.name, err)
+			continue
+		}
+		if obj["kind"] != tt.wantKind {
+			t.Errorf("GetResource(%s/%s): expected kind %s, got %v", tt.kind, tt.name, tt.wantKind, obj["kind"])
+		}
+		if strings.Contains(string(out), "managedFields") {
+			t.Errorf("GetResource(%s/%s): expected managed fields to be dropped", tt.kind, tt.name)
+		}
+	}
+}
+
+func TestClientGoClient_GetResourceYAML(t *testing.T) {
+	c := newTestDynamicClient(testObject("v1", "Service", "default", "web"))
+
+	out, err := c.GetResource(context.Background(), "default", "service", "web", "yaml")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(string(out), "kind: Service") || !strings.Contains(string(out), "name: web") {
+		t.Errorf("Unexpected YAML:\n%s", out)
+	}
+}
+
+func TestClientGoClient_GetResourceErrors(t *testing.T) {
+	c := newTestDynamicClient(testObject("v1", "Service", "default", "web"))
+	ctx := context.Background()
+
+	if _, err := c.GetResource(ctx, "default", "widget", "web", "json"); err == nil || !strings.Contains(err.Error(), "unknown resource type") {
+		t.Errorf("Expected unknown resource type error, got %v", err)
+	}
+	if _, err := c.GetResource(ctx, "default", "service", "missing", "json"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+	if _, err := c.GetResource(ctx, "other", "service", "web", "json"); err == nil {
+		t.Error("Expected error for a service of another namespace")
+	}
+	if _, err := c.GetResource(ctx, "default", "service", "web", "wide"); err == nil || !strings.Contains(err.Error(), "unsupported output format") {
+		t.Errorf("Expected unsupported output format error, got %v", err)
+	}
+}
+
+func TestParseManifest(t *testing.T) {
+	tests := []struct {
+		manifest, namespace, want, wantErr string
+	}{
+		{"apiVersion: v1\nkind: Service\nmetadata:\n  name: web\n  namespace: default\n", "default", "web", ""},
+		{`{"kind": "Service", "metadata": {"name": "web"}}`, "default", "web", ""},
+		{"kind: Service\nmetadata:\n  namespace: default\n", "default", "", "metadata.name is missing"},
+		{"kind: Service\nmetadata:\n  name: web\n  namespace: prod\n", "default", "", `namespace "prod"`},
+		{"kind: [Service\n", "default", "", "invalid manifest"},
+		{"kind: Service\nmetadata:\n  name: web-copy\n", "default", "", `manifest is for "web-copy", not "web"`},
+	}
+	for _, tt := range tests {
+		obj, err := parseManifest([]byte(tt.manifest), tt.namespace, "web")
+		got := obj.Metadata.Name
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("parseManifest(%q): expected error containing %q, got %v", tt.manifest, tt.wantErr, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parseManifest(%q) = %q, %v, want %q", tt.manifest, got, err, tt.want)
+		}
+	}
+}
+
+func TestClientGoClient_ApplyResourceErrors(t *testing.T) {
+	c := newTestDynamicClient(testObject("v1", "Service", "default", "web"))
+	ctx := context.Background()
+
+	manifest := []byte("apiVersion: v1\nkind: Service\nmetadata:\n  name: web\n")
+	if err := c.ApplyResource(ctx, "default", "widget", "web", manifest); err == nil || !strings.Contains(err.Error(), "unknown resource type") {
+		t.Errorf("Expected unknown resource type error, got %v", err)
+	}
+	if err := c.ApplyResource(ctx, "default", "service", "web", []byte("kind: Service\n")); err == nil || !strings.Contains(err.Error(), "metadata.name is missing") {
+		t.Errorf("Expected missing name error, got %v", err)
+	}
+	if err := c.ApplyResource(ctx, "default", "service", "api", manifest); err == nil || !strings.Contains(err.Error(), `not "api"`) {
+		t.Errorf("Expected error for a manifest of another object, got %v", err)
+	}
+	configMap := []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: web\n")
+	if err := c.ApplyResource(ctx, "default", "service", "web", configMap); err == nil || !strings.Contains(err.Error(), "not a Service") {
+		t.Errorf("Expected error for a manifest of another kind, got %v", err)
+	}
+}
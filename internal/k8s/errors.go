@@ -1,11 +1,79 @@
 package k8s
 
 import (
+	"errors"
 	"fmt"
+	"net"
+	"strings"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
+// ErrThrottled is wrapped by errors caused by API server rate limiting (HTTP 429)
+var ErrThrottled = errors.New("kubernetes API throttled")
+
+// ErrWatchNotSupported is returned by clients that can only poll
+var ErrWatchNotSupported = errors.New("watch not supported by this client")
+
+// ErrNotFound is wrapped by errors for objects that don't exist
+var ErrNotFound = errors.New("not found")
+
+// IsNotFound reports whether err means the object doesn't exist
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound) || k8serrors.IsNotFound(err)
+}
+
+// ErrForbidden is wrapped by errors for requests RBAC doesn't allow
+var ErrForbidden = errors.New("permission denied")
+
+// IsForbidden reports whether err means the request wasn't allowed
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden) || k8serrors.IsForbidden(err)
+}
+
+// IsThrottled reports whether err was caused by API rate limiting, either a
+// 429 from the API server or a request that timed out waiting on the client's
+// own QPS/Burst limiter
+func IsThrottled(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrThrottled) || k8serrors.IsTooManyRequests(err) {
+		return true
+	}
+	return strings.Contains(err.Error(), "client rate limiter Wait")
+}
+
+// unreachableMessages are the ways kubectl reports that it never got an
+// answer from the API server
+var unreachableMessages = []string{
+	"Unable to connect to the server",
+	"connection to the server",
+	"connection refused",
+	"no such host",
+	"authentication failed",
+	"You must be logged in to the server",
+}
+
+// IsUnreachable reports whether err means the API server couldn't be reached
+// or rejected the credentials, so every request fails the same way, as
+// opposed to an error about one object
+func IsUnreachable(err error) bool {
+	if err == nil || IsThrottled(err) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) || k8serrors.IsUnauthorized(err) {
+		return true
+	}
+	for _, msg := range unreachableMessages {
+		if strings.Contains(err.Error(), msg) {
+			return true
+		}
+	}
+	return false
+}
+
 // HandleK8sError provides user-friendly error messages for Kubernetes API errors
 func HandleK8sError(err error, resource, name string) error {
 	if err == nil {
@@ -13,17 +81,21 @@ func HandleK8sError(err error, resource, name string) error {
 	}
 
 	if k8serrors.IsNotFound(err) {
-		return fmt.Errorf("%s '%s' not found", resource, name)
+		return fmt.Errorf("%s '%s' %w", resource, name, ErrNotFound)
 	}
 
 	if k8serrors.IsForbidden(err) {
-		return fmt.Errorf("permission denied accessing %s '%s'", resource, name)
+		return fmt.Errorf("%w accessing %s '%s'", ErrForbidden, resource, name)
 	}
 
 	if k8serrors.IsUnauthorized(err) {
 		return fmt.Errorf("authentication failed")
 	}
 
+	if k8serrors.IsTooManyRequests(err) {
+		return fmt.Errorf("%w accessing %s '%s'", ErrThrottled, resource, name)
+	}
+
 	if k8serrors.IsTimeout(err) || k8serrors.IsServerTimeout(err) {
 		return fmt.Errorf("kubernetes API timeout")
 	}
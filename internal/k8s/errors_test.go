@@ -0,0 +1,115 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestIsThrottled(t *testing.T) {
+	tooMany := k8serrors.NewTooManyRequests("slow down", 1)
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"429 from API server", tooMany, true},
+		{"handled 429", HandleK8sError(tooMany, "deployment", "web"), true},
+		{"client rate limiter", fmt.Errorf("client rate limiter Wait returned an error: %w", errors.New("context deadline exceeded")), true},
+		{"not found", HandleK8sError(k8serrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "deployments"}, "web"), "deployment", "web"), false},
+		{"other", errors.New("connection refused"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsThrottled(tt.err); got != tt.want {
+				t.Errorf("IsThrottled(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	notFound := k8serrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "deployments"}, "web")
+	handled := HandleK8sError(notFound, "deployment", "web")
+	if handled.Error() != "deployment 'web' not found" {
+		t.Errorf("HandleK8sError() = %q, want the message unchanged", handled)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"raw not found", notFound, true},
+		{"handled not found", handled, true},
+		{"wrapped", fmt.Errorf("fetch: %w", handled), true},
+		{"forbidden", HandleK8sError(k8serrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "web", errors.New("rbac")), "pod", "web"), false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNotFound(tt.err); got != tt.want {
+				t.Errorf("IsNotFound(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsForbidden(t *testing.T) {
+	forbidden := k8serrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("rbac"))
+	handled := HandleK8sError(forbidden, "pod", "web")
+	if handled.Error() != "permission denied accessing pod 'web'" {
+		t.Errorf("HandleK8sError() = %q, want the message unchanged", handled)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"raw forbidden", forbidden, true},
+		{"handled forbidden", handled, true},
+		{"wrapped", fmt.Errorf("%w listing pods in all namespaces", ErrForbidden), true},
+		{"not found", HandleK8sError(k8serrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "web"), "pod", "web"), false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsForbidden(tt.err); got != tt.want {
+				t.Errorf("IsForbidden(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsUnreachable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"dial error", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, true},
+		{"kubectl connection refused", errors.New("kubectl get deployment web: The connection to the server localhost:8080 was refused - did you specify the right host or port?"), true},
+		{"kubectl unable to connect", errors.New("Unable to connect to the server: dial tcp: lookup api.example.com: no such host"), true},
+		{"unauthorized", HandleK8sError(k8serrors.NewUnauthorized("expired token"), "deployment", "web"), true},
+		{"not found", HandleK8sError(k8serrors.NewNotFound(schema.GroupResource{Group: "apps", Resource: "deployments"}, "web"), "deployment", "web"), false},
+		{"forbidden", HandleK8sError(k8serrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "web", errors.New("rbac")), "pod", "web"), false},
+		{"client rate limiter", fmt.Errorf("client rate limiter Wait returned an error: %w", context.DeadlineExceeded), false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsUnreachable(tt.err); got != tt.want {
+				t.Errorf("IsUnreachable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
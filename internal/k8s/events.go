@@ -2,6 +2,11 @@ package k8s
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
 )
 
 // GetEvents fetches Kubernetes events for a namespace, sorted by timestamp
@@ -12,3 +17,51 @@ func (c *KubectlClient) GetEvents(ctx context.Context, namespace string) ([]byte
 		"--sort-by=.lastTimestamp",
 		"-o", "json")
 }
+
+// EventTime returns when an event last happened. Events recorded through the
+// events.k8s.io API often only set eventTime, so it falls back to eventTime,
+// then firstTimestamp, then the object's creation time.
+func EventTime(e corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	case !e.FirstTimestamp.IsZero():
+		return e.FirstTimestamp.Time
+	default:
+		return e.CreationTimestamp.Time
+	}
+}
+
+// SortEvents orders events chronologically by EventTime, oldest first
+func SortEvents(events []corev1.Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return EventTime(events[i]).Before(EventTime(events[j]))
+	})
+}
+
+// FormatAge renders d like kubectl's AGE column: 45s, 12m, 3h, 5d. Negative
+// durations (clock skew) render as 0s.
+func FormatAge(d time.Duration) string {
+	switch {
+	case d < 0:
+		return "0s"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
+
+// EventAge is how long before now an event happened, "?" when it has no
+// usable timestamp at all
+func EventAge(now, at time.Time) string {
+	if at.IsZero() {
+		return "?"
+	}
+	return FormatAge(now.Sub(at))
+}
@@ -0,0 +1,94 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSortEvents_MixedTimestampFields(t *testing.T) {
+	base := time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC)
+	events := []corev1.Event{
+		{ObjectMeta: metav1.ObjectMeta{Name: "last"}, LastTimestamp: metav1.NewTime(base.Add(3 * time.Minute))},
+		{ObjectMeta: metav1.ObjectMeta{Name: "event-time"}, EventTime: metav1.NewMicroTime(base.Add(1 * time.Minute))},
+		{ObjectMeta: metav1.ObjectMeta{Name: "first"}, FirstTimestamp: metav1.NewTime(base.Add(2 * time.Minute))},
+		{ObjectMeta: metav1.ObjectMeta{Name: "created", CreationTimestamp: metav1.NewTime(base)}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "newest-event-time"}, EventTime: metav1.NewMicroTime(base.Add(4 * time.Minute))},
+	}
+
+	SortEvents(events)
+
+	want := []string{"created", "event-time", "first", "last", "newest-event-time"}
+	for i, name := range want {
+		if events[i].Name != name {
+			t.Fatalf("position %d: got %q, want %q (order: %v)", i, events[i].Name, name, eventNames(events))
+		}
+	}
+}
+
+func TestEventTime_PrefersLastTimestamp(t *testing.T) {
+	last := time.Date(2024, 12, 1, 10, 5, 0, 0, time.UTC)
+	e := corev1.Event{
+		LastTimestamp: metav1.NewTime(last),
+		EventTime:     metav1.NewMicroTime(last.Add(-time.Hour)),
+	}
+	if got := EventTime(e); !got.Equal(last) {
+		t.Errorf("EventTime() = %v, want %v", got, last)
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{-5 * time.Second, "0s"},
+		{0, "0s"},
+		{45 * time.Second, "45s"},
+		{time.Minute, "1m"},
+		{2*time.Minute + 59*time.Second, "2m"},
+		{time.Hour, "1h"},
+		{3*time.Hour + 30*time.Minute, "3h"},
+		{47 * time.Hour, "47h"},
+		{48 * time.Hour, "2d"},
+		{5*24*time.Hour + 23*time.Hour, "5d"},
+	}
+	for _, tt := range tests {
+		if got := FormatAge(tt.d); got != tt.want {
+			t.Errorf("FormatAge(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestEventAge_FixedNow(t *testing.T) {
+	now := time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		at   time.Time
+		want string
+	}{
+		{"seconds", now.Add(-30 * time.Second), "30s"},
+		{"minutes", now.Add(-2 * time.Minute), "2m"},
+		{"hours", now.Add(-3 * time.Hour), "3h"},
+		{"days", now.Add(-5 * 24 * time.Hour), "5d"},
+		{"future", now.Add(time.Minute), "0s"},
+		{"missing", time.Time{}, "?"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EventAge(now, tt.at); got != tt.want {
+				t.Errorf("EventAge() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func eventNames(events []corev1.Event) []string {
+	names := make([]string, len(events))
+	for i, e := range events {
+		names[i] = e.Name
+	}
+	return names
+}
@@ -2,22 +2,57 @@ package k8s
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+	"sigs.k8s.io/yaml"
 )
 
-// GetHelmHistory fetches the history of a Helm release
-func (c *KubectlClient) GetHelmHistory(ctx context.Context, namespace, releaseName string) ([]byte, error) {
-	slog.Debug("fetching helm history", "release", releaseName, "namespace", namespace)
+// ListHelmRevisions fetches the revisions of a Helm release, oldest first
+func (c *KubectlClient) ListHelmRevisions(ctx context.Context, namespace, releaseName string) ([]HelmRevision, error) {
+	slog.Debug("listing helm revisions", "release", releaseName, "namespace", namespace)
 	data, err := c.runCmd(ctx, "helm", "history", releaseName,
 		"-n", namespace,
-		"--kube-context", c.Context)
+		"--kube-context", c.Context,
+		"--max", strconv.Itoa(HelmHistoryMax),
+		"-o", "json")
 	if err != nil {
-		slog.Error("failed to fetch helm history", "release", releaseName, "error", err)
+		slog.Error("failed to list helm revisions", "release", releaseName, "error", err)
 		return nil, err
 	}
-	slog.Debug("helm history fetched", "release", releaseName)
-	return data, nil
+	return ParseHelmHistoryJSON(data)
+}
+
+// ParseHelmHistoryJSON parses `helm history -o json` output
+func ParseHelmHistoryJSON(data []byte) ([]HelmRevision, error) {
+	var entries []struct {
+		Revision    int       `json:"revision"`
+		Updated     time.Time `json:"updated"`
+		Status      string    `json:"status"`
+		Chart       string    `json:"chart"`
+		AppVersion  string    `json:"app_version"`
+		Description string    `json:"description"`
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("invalid helm history: %w", err)
+	}
+	revisions := make([]HelmRevision, 0, len(entries))
+	for _, e := range entries {
+		revisions = append(revisions, HelmRevision{
+			Revision:    e.Revision,
+			Updated:     e.Updated,
+			Status:      e.Status,
+			Chart:       e.Chart,
+			AppVersion:  e.AppVersion,
+			Description: e.Description,
+		})
+	}
+	return revisions, nil
 }
 
 // RollbackHelm rolls back a Helm release to a specific revision
@@ -33,3 +68,65 @@ func (c *KubectlClient) RollbackHelm(ctx context.Context, namespace, releaseName
 	slog.Info("helm release rolled back successfully", "release", releaseName, "revision", revision)
 	return nil
 }
+
+// GetHelmManifest fetches the rendered manifest of a Helm release revision
+func (c *KubectlClient) GetHelmManifest(ctx context.Context, namespace, releaseName string, revision int) ([]byte, error) {
+	slog.Debug("fetching helm manifest", "release", releaseName, "revision", revision, "namespace", namespace)
+	return c.runCmd(ctx, "helm", "get", "manifest", releaseName,
+		"--revision", strconv.Itoa(revision),
+		"-n", namespace,
+		"--kube-context", c.Context)
+}
+
+// GetHelmNotes fetches the rendered NOTES.txt of a Helm release
+func (c *KubectlClient) GetHelmNotes(ctx context.Context, namespace, releaseName string) ([]byte, error) {
+	slog.Debug("fetching helm notes", "release", releaseName, "namespace", namespace)
+	return c.runCmd(ctx, "helm", "get", "notes", releaseName,
+		"-n", namespace,
+		"--kube-context", c.Context)
+}
+
+// GetHelmHooks fetches the hook manifests of a Helm release (multi-document YAML)
+func (c *KubectlClient) GetHelmHooks(ctx context.Context, namespace, releaseName string) ([]byte, error) {
+	slog.Debug("fetching helm hooks", "release", releaseName, "namespace", namespace)
+	return c.runCmd(ctx, "helm", "get", "hooks", releaseName,
+		"-n", namespace,
+		"--kube-context", c.Context)
+}
+
+// HelmHook is one hook resource of a release
+type HelmHook struct {
+	Kind         string
+	Name         string
+	Events       string // helm.sh/hook, e.g. "pre-install,pre-upgrade"
+	Weight       string // helm.sh/hook-weight
+	DeletePolicy string // helm.sh/hook-delete-policy
+}
+
+// ParseHelmHooks extracts the hooks from `helm get hooks` output.
+// Documents without a helm.sh/hook annotation are skipped.
+func ParseHelmHooks(manifest []byte) ([]HelmHook, error) {
+	var hooks []HelmHook
+	for _, doc := range strings.Split("\n"+string(manifest), "\n---") {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		jsonDoc, err := yaml.YAMLToJSON([]byte(doc))
+		if err != nil {
+			return hooks, fmt.Errorf("invalid hook manifest: %w", err)
+		}
+		annotations := gjson.GetBytes(jsonDoc, "metadata.annotations")
+		events := annotations.Get(gjson.Escape("helm.sh/hook")).String()
+		if events == "" {
+			continue
+		}
+		hooks = append(hooks, HelmHook{
+			Kind:         gjson.GetBytes(jsonDoc, "kind").String(),
+			Name:         gjson.GetBytes(jsonDoc, "metadata.name").String(),
+			Events:       events,
+			Weight:       annotations.Get(gjson.Escape("helm.sh/hook-weight")).String(),
+			DeletePolicy: annotations.Get(gjson.Escape("helm.sh/hook-delete-policy")).String(),
+		})
+	}
+	return hooks, nil
+}
@@ -0,0 +1,82 @@
+package k8s
+
+import (
+	"testing"
+)
+
+func TestParseHelmHooks(t *testing.T) {
+	manifest := []byte(`---
+# Source: app/templates/migrate-job.yaml
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: app-migrate
+  annotations:
+    "helm.sh/hook": pre-install,pre-upgrade
+    "helm.sh/hook-weight": "-5"
+    "helm.sh/hook-delete-policy": hook-succeeded
+---
+# Source: app/templates/tests/test-connection.yaml
+apiVersion: v1
+kind: Pod
+metadata:
+  name: app-test-connection
+  annotations:
+    "helm.sh/hook": test
+---
+# Source: app/templates/plain.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: not-a-hook
+`)
+
+	hooks, err := ParseHelmHooks(manifest)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(hooks) != 2 {
+		t.Fatalf("Expected 2 hooks, got %d: %+v", len(hooks), hooks)
+	}
+
+	want := HelmHook{Kind: "Job", Name: "app-migrate", Events: "pre-install,pre-upgrade", Weight: "-5", DeletePolicy: "hook-succeeded"}
+	if hooks[0] != want {
+		t.Errorf("Expected %+v, got %+v", want, hooks[0])
+	}
+	if hooks[1].Kind != "Pod" || hooks[1].Events != "test" {
+		t.Errorf("Expected test Pod hook, got %+v", hooks[1])
+	}
+}
+
+func TestParseHelmHooks_Empty(t *testing.T) {
+	hooks, err := ParseHelmHooks(nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(hooks) != 0 {
+		t.Errorf("Expected no hooks, got %+v", hooks)
+	}
+}
+
+func TestParseHelmHistoryJSON(t *testing.T) {
+	data := []byte(`[{"revision":1,"updated":"2024-05-01T10:00:00.123456789Z","status":"superseded","chart":"web-1.2.0","app_version":"2.0","description":"Install complete"},` +
+		`{"revision":2,"updated":"2024-05-02T10:00:00Z","status":"deployed","chart":"web-1.3.0","app_version":"2.1","description":"Upgrade complete"}]`)
+
+	revisions, err := ParseHelmHistoryJSON(data)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(revisions) != 2 {
+		t.Fatalf("Expected 2 revisions, got %d", len(revisions))
+	}
+	if revisions[0].Revision != 1 || revisions[0].Chart != "web-1.2.0" || revisions[0].AppVersion != "2.0" {
+		t.Errorf("Unexpected first revision %+v", revisions[0])
+	}
+	if revisions[1].Status != "deployed" || revisions[1].Updated.Day() != 2 {
+		t.Errorf("Unexpected second revision %+v", revisions[1])
+	}
+
+	if _, err := ParseHelmHistoryJSON([]byte("Error: release: not found")); err == nil {
+		t.Error("Expected error for non-JSON output")
+	}
+}
@@ -0,0 +1,172 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"time"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// HelmHistoryMax is how many of a release's latest revisions the history
+// lists (same default as `helm history`)
+const HelmHistoryMax = 256
+
+// HelmRevision is one revision of a Helm release
+type HelmRevision struct {
+	Revision    int
+	Updated     time.Time // zero if never deployed
+	Status      string
+	Chart       string // name-version
+	AppVersion  string
+	Description string
+}
+
+// HelmSDKClient reads and rolls back Helm releases with the Helm Go SDK,
+// so the helm binary is not needed
+type HelmSDKClient struct {
+	context string // kubeconfig context name
+
+	// newConfig builds the action configuration for a namespace
+	// (replaced in tests to use in-memory release storage)
+	newConfig func(namespace string) (*action.Configuration, error)
+}
+
+// NewHelmSDKClient creates a Helm SDK client for a kubeconfig context,
+// reading releases from the storage driver in $HELM_DRIVER (default: secrets)
+func NewHelmSDKClient(kubeContext string) *HelmSDKClient {
+	h := &HelmSDKClient{context: kubeContext}
+	h.newConfig = h.actionConfig
+	return h
+}
+
+// actionConfig initializes the Helm action configuration for namespace
+// from the same kubeconfig and context as the client-go client
+func (h *HelmSDKClient) actionConfig(namespace string) (*action.Configuration, error) {
+	settings := cli.New()
+	// KubeConfig stays empty so the kubeconfig is found like loadingRules does
+	settings.KubeContext = h.context
+	settings.SetNamespace(namespace)
+
+	cfg := new(action.Configuration)
+	logf := func(format string, v ...interface{}) {
+		slog.Debug("helm: " + fmt.Sprintf(format, v...))
+	}
+	if err := cfg.Init(settings.RESTClientGetter(), namespace, os.Getenv("HELM_DRIVER"), logf); err != nil {
+		return nil, fmt.Errorf("failed to initialize helm: %w", err)
+	}
+	return cfg, nil
+}
+
+// History returns the latest revisions of a release, oldest first
+func (h *HelmSDKClient) History(ctx context.Context, namespace, releaseName string) ([]HelmRevision, error) {
+	slog.Debug("fetching helm history", "release", releaseName, "namespace", namespace, "context", h.context)
+	cfg, err := h.newConfig(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var releases []*release.Release
+	err = runWithContext(ctx, func() error {
+		var runErr error
+		releases, runErr = action.NewHistory(cfg).Run(releaseName)
+		return runErr
+	})
+	if err != nil {
+		slog.Error("failed to fetch helm history", "release", releaseName, "error", err)
+		return nil, err
+	}
+
+	sort.Slice(releases, func(i, j int) bool { return releases[i].Version < releases[j].Version })
+	if len(releases) > HelmHistoryMax {
+		releases = releases[len(releases)-HelmHistoryMax:]
+	}
+	revisions := make([]HelmRevision, 0, len(releases))
+	for _, r := range releases {
+		revisions = append(revisions, helmRevision(r))
+	}
+	return revisions, nil
+}
+
+// Rollback rolls a release back to revision
+func (h *HelmSDKClient) Rollback(ctx context.Context, namespace, releaseName string, revision int) error {
+	slog.Info("rolling back helm release", "release", releaseName, "revision", revision)
+	cfg, err := h.newConfig(namespace)
+	if err != nil {
+		return err
+	}
+
+	rollback := action.NewRollback(cfg)
+	rollback.Version = revision
+	if deadline, ok := ctx.Deadline(); ok {
+		rollback.Timeout = time.Until(deadline)
+	}
+	if err := runWithContext(ctx, func() error { return rollback.Run(releaseName) }); err != nil {
+		slog.Error("failed to rollback helm release", "release", releaseName, "error", err)
+		return err
+	}
+	slog.Info("helm release rolled back successfully", "release", releaseName, "revision", revision)
+	return nil
+}
+
+// Manifest returns the rendered manifest of a release revision, the
+// multi-document YAML Helm applied (like helm get manifest --revision)
+func (h *HelmSDKClient) Manifest(ctx context.Context, namespace, releaseName string, revision int) ([]byte, error) {
+	slog.Debug("fetching helm manifest", "release", releaseName, "revision", revision, "namespace", namespace)
+	cfg, err := h.newConfig(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	get := action.NewGet(cfg)
+	get.Version = revision
+	var rel *release.Release
+	err = runWithContext(ctx, func() error {
+		var runErr error
+		rel, runErr = get.Run(releaseName)
+		return runErr
+	})
+	if err != nil {
+		slog.Error("failed to fetch helm manifest", "release", releaseName, "revision", revision, "error", err)
+		return nil, err
+	}
+	return []byte(rel.Manifest), nil
+}
+
+// helmRevision summarizes a release revision like `helm history` does
+func helmRevision(r *release.Release) HelmRevision {
+	rev := HelmRevision{Revision: r.Version, Chart: "MISSING", AppVersion: "MISSING"}
+	if r.Info != nil {
+		rev.Status = r.Info.Status.String()
+		rev.Description = r.Info.Description
+		if !r.Info.LastDeployed.IsZero() {
+			rev.Updated = r.Info.LastDeployed.Time
+		}
+	}
+	if r.Chart != nil && r.Chart.Metadata != nil {
+		rev.Chart = fmt.Sprintf("%s-%s", r.Chart.Metadata.Name, r.Chart.Metadata.Version)
+		rev.AppVersion = r.Chart.Metadata.AppVersion
+	}
+	return rev
+}
+
+// runWithContext runs fn, giving up when ctx is done first. The Helm
+// actions take no context, so fn keeps running in the background then.
+func runWithContext(ctx context.Context, fn func() error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() { done <- fn() }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
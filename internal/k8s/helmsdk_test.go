@@ -0,0 +1,130 @@
+package k8s
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	kubefake "helm.sh/helm/v3/pkg/kube/fake"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	helmtime "helm.sh/helm/v3/pkg/time"
+)
+
+// newTestHelmSDKClient returns a client backed by in-memory release storage
+func newTestHelmSDKClient(t *testing.T, releases ...*release.Release) (*HelmSDKClient, *action.Configuration) {
+	t.Helper()
+	cfg := &action.Configuration{
+		Releases:     storage.Init(driver.NewMemory()),
+		KubeClient:   &kubefake.PrintingKubeClient{Out: io.Discard},
+		Capabilities: chartutil.DefaultCapabilities,
+		Log:          func(format string, v ...interface{}) {},
+	}
+	for _, r := range releases {
+		if err := cfg.Releases.Create(r); err != nil {
+			t.Fatalf("Failed to store release: %v", err)
+		}
+	}
+	h := &HelmSDKClient{newConfig: func(namespace string) (*action.Configuration, error) { return cfg, nil }}
+	return h, cfg
+}
+
+func testRelease(version int, status release.Status, deployed time.Time) *release.Release {
+	return &release.Release{
+		Name:      "web",
+		Namespace: "default",
+		Version:   version,
+		Info: &release.Info{
+			Status:        status,
+			Description:   "Upgrade complete",
+			FirstDeployed: helmtime.Time{Time: deployed},
+			LastDeployed:  helmtime.Time{Time: deployed},
+		},
+		Chart: &chart.Chart{Metadata: &chart.Metadata{Name: "web", Version: "1.2.0", AppVersion: "2.0"}},
+	}
+}
+
+func TestHelmSDKClient_History(t *testing.T) {
+	deployed := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	h, _ := newTestHelmSDKClient(t,
+		testRelease(2, release.StatusDeployed, deployed.Add(time.Hour)),
+		testRelease(1, release.StatusSuperseded, deployed),
+	)
+
+	revisions, err := h.History(context.Background(), "default", "web")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(revisions) != 2 {
+		t.Fatalf("Expected 2 revisions, got %d", len(revisions))
+	}
+
+	want := HelmRevision{Revision: 1, Updated: deployed, Status: "superseded", Chart: "web-1.2.0", AppVersion: "2.0", Description: "Upgrade complete"}
+	if !revisions[0].Updated.Equal(want.Updated) {
+		t.Errorf("Expected updated %v, got %v", want.Updated, revisions[0].Updated)
+	}
+	revisions[0].Updated = want.Updated
+	if revisions[0] != want {
+		t.Errorf("Expected %+v, got %+v", want, revisions[0])
+	}
+	if revisions[1].Revision != 2 || revisions[1].Status != "deployed" {
+		t.Errorf("Expected revision 2 deployed last, got %+v", revisions[1])
+	}
+}
+
+func TestHelmSDKClient_HistoryNotFound(t *testing.T) {
+	h, _ := newTestHelmSDKClient(t)
+	if _, err := h.History(context.Background(), "default", "missing"); err == nil {
+		t.Error("Expected error for a missing release")
+	}
+}
+
+func TestHelmSDKClient_Rollback(t *testing.T) {
+	deployed := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	h, cfg := newTestHelmSDKClient(t,
+		testRelease(1, release.StatusSuperseded, deployed),
+		testRelease(2, release.StatusDeployed, deployed.Add(time.Hour)),
+	)
+
+	if err := h.Rollback(context.Background(), "default", "web", 1); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	last, err := cfg.Releases.Last("web")
+	if err != nil {
+		t.Fatalf("Failed to read last release: %v", err)
+	}
+	if last.Version != 3 || last.Info.Status != release.StatusDeployed {
+		t.Errorf("Expected revision 3 deployed, got revision %d %s", last.Version, last.Info.Status)
+	}
+	if !strings.Contains(last.Info.Description, "Rollback to 1") {
+		t.Errorf("Expected rollback description, got %q", last.Info.Description)
+	}
+}
+
+func TestHelmSDKClient_Manifest(t *testing.T) {
+	deployed := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	first := testRelease(1, release.StatusSuperseded, deployed)
+	first.Manifest = "kind: Deployment\nreplicas: 1\n"
+	second := testRelease(2, release.StatusDeployed, deployed.Add(time.Hour))
+	second.Manifest = "kind: Deployment\nreplicas: 3\n"
+	h, _ := newTestHelmSDKClient(t, first, second)
+
+	out, err := h.Manifest(context.Background(), "default", "web", 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if string(out) != first.Manifest {
+		t.Errorf("Expected the manifest of revision 1, got %q", out)
+	}
+
+	if _, err := h.Manifest(context.Background(), "default", "web", 7); err == nil {
+		t.Error("Expected error for a missing revision")
+	}
+}
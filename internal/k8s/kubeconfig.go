@@ -0,0 +1,34 @@
+package k8s
+
+import (
+	"sort"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// loadingRules finds the kubeconfig the way kubectl does: the files listed in
+// $KUBECONFIG (colon-separated, merged with the first file winning), or
+// ~/.kube/config when it is unset
+func loadingRules() *clientcmd.ClientConfigLoadingRules {
+	return clientcmd.NewDefaultClientConfigLoadingRules()
+}
+
+// ListContexts returns the context names defined in the kubeconfig, sorted
+func ListContexts() ([]string, error) {
+	return listContexts(loadingRules())
+}
+
+// listContexts returns the sorted context names of the kubeconfig rules load
+func listContexts(rules *clientcmd.ClientConfigLoadingRules) ([]string, error) {
+	config, err := rules.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(config.Contexts))
+	for name := range config.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
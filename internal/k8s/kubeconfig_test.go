@@ -0,0 +1,97 @@
+package k8s
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func TestListContexts(t *testing.T) {
+	kubeconfig := `apiVersion: v1
+kind: Config
+current-context: kind-kind
+clusters:
+- name: kind
+  cluster:
+    server: https://127.0.0.1:6443
+users:
+- name: admin
+  user:
+    token: secret
+contexts:
+- name: kind-kind
+  context:
+    cluster: kind
+    user: admin
+- name: arn:aws:eks:eu-west-1:123456789012:cluster/prod
+  context:
+    cluster: kind
+    user: admin
+- name: dev
+  context:
+    cluster: kind
+    user: admin
+`
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(kubeconfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	contexts, err := listContexts(&clientcmd.ClientConfigLoadingRules{ExplicitPath: path})
+	if err != nil {
+		t.Fatalf("listContexts() error = %v", err)
+	}
+	want := []string{"arn:aws:eks:eu-west-1:123456789012:cluster/prod", "dev", "kind-kind"}
+	if len(contexts) != len(want) {
+		t.Fatalf("listContexts() = %v, want %v", contexts, want)
+	}
+	for i := range want {
+		if contexts[i] != want[i] {
+			t.Errorf("listContexts()[%d] = %q, want %q", i, contexts[i], want[i])
+		}
+	}
+}
+
+func TestListContexts_MissingFile(t *testing.T) {
+	if _, err := listContexts(&clientcmd.ClientConfigLoadingRules{ExplicitPath: filepath.Join(t.TempDir(), "missing")}); err == nil {
+		t.Error("Expected error for missing kubeconfig, got nil")
+	}
+}
+
+func TestListContexts_KubeconfigEnv(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, context string) string {
+		path := filepath.Join(dir, name)
+		kubeconfig := `apiVersion: v1
+kind: Config
+clusters:
+- name: c
+  cluster:
+    server: https://127.0.0.1:6443
+contexts:
+- name: ` + context + `
+  context:
+    cluster: c
+`
+		if err := os.WriteFile(path, []byte(kubeconfig), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	t.Setenv("KUBECONFIG", strings.Join([]string{
+		write("dev", "dev"),
+		filepath.Join(dir, "missing"),
+		write("prod", "prod"),
+	}, string(filepath.ListSeparator)))
+
+	contexts, err := ListContexts()
+	if err != nil {
+		t.Fatalf("ListContexts() error = %v", err)
+	}
+	if strings.Join(contexts, ",") != "dev,prod" {
+		t.Errorf("ListContexts() = %v, want the contexts of both files", contexts)
+	}
+}
@@ -0,0 +1,103 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+// ErrMetricsUnavailable is returned when the cluster serves no
+// metrics.k8s.io API (metrics-server is not installed or not ready)
+var ErrMetricsUnavailable = errors.New("metrics API not available")
+
+// PodMetrics is the current resource usage of a pod, summed over its containers
+type PodMetrics struct {
+	CPUMilli    int64 // millicores
+	MemoryBytes int64 // working set
+}
+
+// GetPodMetrics fetches the usage of the pods matching selector ("" for all)
+// from the metrics API (uses kubectl get --raw)
+func (c *KubectlClient) GetPodMetrics(ctx context.Context, namespace, selector string) (map[string]PodMetrics, error) {
+	path := fmt.Sprintf("/apis/metrics.k8s.io/v1beta1/namespaces/%s/pods", namespace)
+	if selector != "" {
+		path += "?labelSelector=" + url.QueryEscape(selector)
+	}
+	out, err := c.runCmd(ctx, "kubectl", "get", "--raw", path,
+		"--context", c.Context)
+	if err != nil {
+		// "Error from server (NotFound): the server could not find the requested resource"
+		if strings.Contains(string(out), "NotFound") || strings.Contains(string(out), "ServiceUnavailable") {
+			return nil, ErrMetricsUnavailable
+		}
+		return nil, fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	var list metricsv1beta1.PodMetricsList
+	if err := json.Unmarshal(out, &list); err != nil {
+		return nil, fmt.Errorf("invalid pod metrics: %w", err)
+	}
+	return SumPodMetrics(list.Items), nil
+}
+
+// GetPodMetrics fetches the usage of the pods matching selector ("" for all)
+// from the metrics API
+func (c *ClientGoClient) GetPodMetrics(ctx context.Context, namespace, selector string) (map[string]PodMetrics, error) {
+	list, err := c.metrics.MetricsV1beta1().PodMetricses(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		if k8serrors.IsNotFound(err) || k8serrors.IsServiceUnavailable(err) {
+			return nil, ErrMetricsUnavailable
+		}
+		slog.Debug("failed to fetch pod metrics", "namespace", namespace, "error", err)
+		return nil, HandleK8sError(err, "pod metrics", namespace)
+	}
+	return SumPodMetrics(list.Items), nil
+}
+
+// SumPodMetrics totals the container usage of each pod, keyed by pod name
+func SumPodMetrics(items []metricsv1beta1.PodMetrics) map[string]PodMetrics {
+	metrics := make(map[string]PodMetrics, len(items))
+	for _, pm := range items {
+		var total PodMetrics
+		for _, c := range pm.Containers {
+			total.CPUMilli += c.Usage.Cpu().MilliValue()
+			total.MemoryBytes += c.Usage.Memory().Value()
+		}
+		metrics[pm.Name] = total
+	}
+	return metrics
+}
+
+// FormatCPU renders millicores like kubectl top ("250m", "1.5")
+func FormatCPU(milli int64) string {
+	if milli < 1000 {
+		return fmt.Sprintf("%dm", milli)
+	}
+	return fmt.Sprintf("%.1f", float64(milli)/1000)
+}
+
+// FormatMemory renders bytes in binary units ("512Ki", "34Mi", "1.2Gi")
+func FormatMemory(bytes int64) string {
+	const (
+		ki = 1 << 10
+		mi = 1 << 20
+		gi = 1 << 30
+	)
+	switch {
+	case bytes >= gi:
+		return fmt.Sprintf("%.1fGi", float64(bytes)/gi)
+	case bytes >= mi:
+		return fmt.Sprintf("%dMi", bytes/mi)
+	case bytes >= ki:
+		return fmt.Sprintf("%dKi", bytes/ki)
+	}
+	return fmt.Sprintf("%d", bytes)
+}
@@ -0,0 +1,87 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+)
+
+func TestSumPodMetrics(t *testing.T) {
+	usage := func(cpu, mem string) corev1.ResourceList {
+		return corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(cpu),
+			corev1.ResourceMemory: resource.MustParse(mem),
+		}
+	}
+	items := []metricsv1beta1.PodMetrics{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1"},
+			Containers: []metricsv1beta1.ContainerMetrics{
+				{Name: "app", Usage: usage("250m", "64Mi")},
+				{Name: "sidecar", Usage: usage("1500000n", "16Mi")},
+			},
+		},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-2"}},
+	}
+
+	metrics := SumPodMetrics(items)
+	if len(metrics) != 2 {
+		t.Fatalf("Expected 2 pods, got %d", len(metrics))
+	}
+	want := PodMetrics{CPUMilli: 252, MemoryBytes: 80 << 20}
+	if metrics["web-1"] != want {
+		t.Errorf("Expected %+v, got %+v", want, metrics["web-1"])
+	}
+	if metrics["web-2"] != (PodMetrics{}) {
+		t.Errorf("Expected zero usage without containers, got %+v", metrics["web-2"])
+	}
+}
+
+func TestFormatCPU(t *testing.T) {
+	tests := map[int64]string{0: "0m", 12: "12m", 999: "999m", 1000: "1.0", 1500: "1.5"}
+	for milli, want := range tests {
+		if got := FormatCPU(milli); got != want {
+			t.Errorf("FormatCPU(%d) = %q, want %q", milli, got, want)
+		}
+	}
+}
+
+func TestFormatMemory(t *testing.T) {
+	tests := map[int64]string{512: "512", 4 << 10: "4Ki", 34 << 20: "34Mi", 1288490189: "1.2Gi"}
+	for bytes, want := range tests {
+		if got := FormatMemory(bytes); got != want {
+			t.Errorf("FormatMemory(%d) = %q, want %q", bytes, got, want)
+		}
+	}
+}
+
+func TestMockClient_GetPodMetrics(t *testing.T) {
+	mock := NewMockClient()
+
+	if _, err := mock.GetPodMetrics(context.Background(), "default", ""); err == nil {
+		t.Error("Expected error for unimplemented GetPodMetrics, got nil")
+	}
+
+	mock.GetPodMetricsFunc = func(ctx context.Context, namespace, selector string) (map[string]PodMetrics, error) {
+		if namespace == "no-metrics" {
+			return nil, ErrMetricsUnavailable
+		}
+		return map[string]PodMetrics{"web-1": {CPUMilli: 5, MemoryBytes: 1 << 20}}, nil
+	}
+
+	metrics, err := mock.GetPodMetrics(context.Background(), "default", "app=web")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if metrics["web-1"].CPUMilli != 5 {
+		t.Errorf("Expected 5m CPU, got %+v", metrics["web-1"])
+	}
+	if _, err := mock.GetPodMetrics(context.Background(), "no-metrics", ""); !errors.Is(err, ErrMetricsUnavailable) {
+		t.Errorf("Expected ErrMetricsUnavailable, got %v", err)
+	}
+}
@@ -8,27 +8,59 @@ import (
 // MockClient is a mock implementation of the Client interface for testing
 type MockClient struct {
 	// Deployment operations
-	GetDeploymentFunc     func(ctx context.Context, namespace, name string) ([]byte, error)
-	ScaleDeploymentFunc   func(ctx context.Context, namespace, name string, replicas int) error
-	RestartDeploymentFunc func(ctx context.Context, namespace, name string) error
-	ListDeploymentsFunc   func(ctx context.Context, namespace string) ([]string, error)
+	GetDeploymentFunc      func(ctx context.Context, namespace, name string) ([]byte, error)
+	ScaleDeploymentFunc    func(ctx context.Context, namespace, name string, replicas int) error
+	RestartDeploymentFunc  func(ctx context.Context, namespace, name string) error
+	ListDeploymentsFunc    func(ctx context.Context, namespace string) ([]string, error)
+	DescribeDeploymentFunc func(ctx context.Context, namespace, name string) ([]byte, error)
+	GetRolloutStatusFunc   func(ctx context.Context, namespace, name string) (RolloutStatus, error)
+
+	// StatefulSet operations
+	GetStatefulSetFunc     func(ctx context.Context, namespace, name string) ([]byte, error)
+	ListStatefulSetsFunc   func(ctx context.Context, namespace string) ([]string, error)
+	ScaleStatefulSetFunc   func(ctx context.Context, namespace, name string, replicas int) error
+	RestartStatefulSetFunc func(ctx context.Context, namespace, name string) error
+
+	// DaemonSet operations
+	GetDaemonSetFunc     func(ctx context.Context, namespace, name string) ([]byte, error)
+	ListDaemonSetsFunc   func(ctx context.Context, namespace string) ([]string, error)
+	RestartDaemonSetFunc func(ctx context.Context, namespace, name string) error
+	ResolveWorkloadFunc  func(ctx context.Context, namespace, name string) (string, error)
 
 	// Pod operations
-	ListPodsFunc         func(ctx context.Context, namespace, selector string) ([]byte, error)
-	GetPodLogsFunc       func(ctx context.Context, namespace, podName string, tailLines int, allContainers, prefix bool) ([]byte, error)
-	GetPodContainersFunc func(ctx context.Context, namespace, podName string) ([]string, error)
+	GetPodFunc                func(ctx context.Context, namespace, name string) ([]byte, error)
+	ListPodsFunc              func(ctx context.Context, namespace, selector string) ([]byte, error)
+	ListAllPodsFunc           func(ctx context.Context, selector string) ([]byte, error)
+	GetPodLogsFunc            func(ctx context.Context, namespace, podName string, tailLines int, allContainers, prefix bool) ([]byte, error)
+	GetPodLogsWithOptionsFunc func(ctx context.Context, namespace, podName string, opts LogOptions) ([]byte, error)
+	GetPodContainersFunc      func(ctx context.Context, namespace, podName string) ([]string, error)
+	DescribePodFunc           func(ctx context.Context, namespace, name string) ([]byte, error)
+	StreamPodLogsFunc         func(ctx context.Context, namespace, podName string, follow bool, opts LogOptions) (<-chan []byte, error)
+	WatchPodsFunc             func(ctx context.Context, namespace, selector string) (<-chan PodEvent, error)
+	ExecInPodFunc             func(ctx context.Context, namespace, podName, container string, command []string) ([]byte, error)
+	DeletePodFunc             func(ctx context.Context, namespace, podName string) error
+	GetPodMetricsFunc         func(ctx context.Context, namespace, selector string) (map[string]PodMetrics, error)
+	PortForwardFunc           func(ctx context.Context, namespace, podName string, localPort, remotePort int) (<-chan error, error)
 
 	// Helm operations
-	GetHelmHistoryFunc func(ctx context.Context, namespace, releaseName string) ([]byte, error)
-	RollbackHelmFunc   func(ctx context.Context, namespace, releaseName string, revision int) error
+	ListHelmRevisionsFunc func(ctx context.Context, namespace, releaseName string) ([]HelmRevision, error)
+	RollbackHelmFunc      func(ctx context.Context, namespace, releaseName string, revision int) error
+	GetHelmManifestFunc   func(ctx context.Context, namespace, releaseName string, revision int) ([]byte, error)
+	GetHelmNotesFunc      func(ctx context.Context, namespace, releaseName string) ([]byte, error)
+	GetHelmHooksFunc      func(ctx context.Context, namespace, releaseName string) ([]byte, error)
 
 	// Resource operations
-	GetSecretFunc    func(ctx context.Context, namespace, name string) ([]byte, error)
-	GetConfigMapFunc func(ctx context.Context, namespace, name string) ([]byte, error)
-	GetResourceFunc  func(ctx context.Context, namespace, kind, name, outputFormat string) ([]byte, error)
+	GetSecretFunc     func(ctx context.Context, namespace, name string) ([]byte, error)
+	GetConfigMapFunc  func(ctx context.Context, namespace, name string) ([]byte, error)
+	GetResourceFunc   func(ctx context.Context, namespace, kind, name, outputFormat string) ([]byte, error)
+	ApplyResourceFunc func(ctx context.Context, namespace, kind, name string, data []byte) error
+	ListServicesFunc  func(ctx context.Context, namespace string) ([]byte, error)
 
 	// Event operations
 	GetEventsFunc func(ctx context.Context, namespace string) ([]byte, error)
+
+	// Namespace operations
+	ListNamespacesFunc func(ctx context.Context) ([]string, error)
 }
 
 // NewMockClient creates a new mock client
@@ -66,8 +98,89 @@ func (m *MockClient) ListDeployments(ctx context.Context, namespace string) ([]s
 	return nil, fmt.Errorf("ListDeploymentsFunc not implemented")
 }
 
+func (m *MockClient) DescribeDeployment(ctx context.Context, namespace, name string) ([]byte, error) {
+	if m.DescribeDeploymentFunc != nil {
+		return m.DescribeDeploymentFunc(ctx, namespace, name)
+	}
+	return nil, fmt.Errorf("DescribeDeploymentFunc not implemented")
+}
+
+func (m *MockClient) GetRolloutStatus(ctx context.Context, namespace, name string) (RolloutStatus, error) {
+	if m.GetRolloutStatusFunc != nil {
+		return m.GetRolloutStatusFunc(ctx, namespace, name)
+	}
+	return RolloutStatus{}, fmt.Errorf("GetRolloutStatusFunc not implemented")
+}
+
+// StatefulSet operations
+
+func (m *MockClient) GetStatefulSet(ctx context.Context, namespace, name string) ([]byte, error) {
+	if m.GetStatefulSetFunc != nil {
+		return m.GetStatefulSetFunc(ctx, namespace, name)
+	}
+	return nil, fmt.Errorf("GetStatefulSetFunc not implemented")
+}
+
+func (m *MockClient) ListStatefulSets(ctx context.Context, namespace string) ([]string, error) {
+	if m.ListStatefulSetsFunc != nil {
+		return m.ListStatefulSetsFunc(ctx, namespace)
+	}
+	return nil, fmt.Errorf("ListStatefulSetsFunc not implemented")
+}
+
+func (m *MockClient) ScaleStatefulSet(ctx context.Context, namespace, name string, replicas int) error {
+	if m.ScaleStatefulSetFunc != nil {
+		return m.ScaleStatefulSetFunc(ctx, namespace, name, replicas)
+	}
+	return fmt.Errorf("ScaleStatefulSetFunc not implemented")
+}
+
+func (m *MockClient) RestartStatefulSet(ctx context.Context, namespace, name string) error {
+	if m.RestartStatefulSetFunc != nil {
+		return m.RestartStatefulSetFunc(ctx, namespace, name)
+	}
+	return fmt.Errorf("RestartStatefulSetFunc not implemented")
+}
+
+// DaemonSet operations
+
+func (m *MockClient) GetDaemonSet(ctx context.Context, namespace, name string) ([]byte, error) {
+	if m.GetDaemonSetFunc != nil {
+		return m.GetDaemonSetFunc(ctx, namespace, name)
+	}
+	return nil, fmt.Errorf("GetDaemonSetFunc not implemented")
+}
+
+func (m *MockClient) ListDaemonSets(ctx context.Context, namespace string) ([]string, error) {
+	if m.ListDaemonSetsFunc != nil {
+		return m.ListDaemonSetsFunc(ctx, namespace)
+	}
+	return nil, fmt.Errorf("ListDaemonSetsFunc not implemented")
+}
+
+func (m *MockClient) RestartDaemonSet(ctx context.Context, namespace, name string) error {
+	if m.RestartDaemonSetFunc != nil {
+		return m.RestartDaemonSetFunc(ctx, namespace, name)
+	}
+	return fmt.Errorf("RestartDaemonSetFunc not implemented")
+}
+
+func (m *MockClient) ResolveWorkload(ctx context.Context, namespace, name string) (string, error) {
+	if m.ResolveWorkloadFunc != nil {
+		return m.ResolveWorkloadFunc(ctx, namespace, name)
+	}
+	return "", fmt.Errorf("ResolveWorkloadFunc not implemented")
+}
+
 // Pod operations
 
+func (m *MockClient) GetPod(ctx context.Context, namespace, name string) ([]byte, error) {
+	if m.GetPodFunc != nil {
+		return m.GetPodFunc(ctx, namespace, name)
+	}
+	return nil, fmt.Errorf("GetPodFunc not implemented")
+}
+
 func (m *MockClient) ListPods(ctx context.Context, namespace, selector string) ([]byte, error) {
 	if m.ListPodsFunc != nil {
 		return m.ListPodsFunc(ctx, namespace, selector)
@@ -75,6 +188,13 @@ func (m *MockClient) ListPods(ctx context.Context, namespace, selector string) (
 	return nil, fmt.Errorf("ListPodsFunc not implemented")
 }
 
+func (m *MockClient) ListAllPods(ctx context.Context, selector string) ([]byte, error) {
+	if m.ListAllPodsFunc != nil {
+		return m.ListAllPodsFunc(ctx, selector)
+	}
+	return nil, fmt.Errorf("ListAllPodsFunc not implemented")
+}
+
 func (m *MockClient) GetPodLogs(ctx context.Context, namespace, podName string, tailLines int, allContainers, prefix bool) ([]byte, error) {
 	if m.GetPodLogsFunc != nil {
 		return m.GetPodLogsFunc(ctx, namespace, podName, tailLines, allContainers, prefix)
@@ -82,6 +202,13 @@ func (m *MockClient) GetPodLogs(ctx context.Context, namespace, podName string,
 	return nil, fmt.Errorf("GetPodLogsFunc not implemented")
 }
 
+func (m *MockClient) GetPodLogsWithOptions(ctx context.Context, namespace, podName string, opts LogOptions) ([]byte, error) {
+	if m.GetPodLogsWithOptionsFunc != nil {
+		return m.GetPodLogsWithOptionsFunc(ctx, namespace, podName, opts)
+	}
+	return nil, fmt.Errorf("GetPodLogsWithOptionsFunc not implemented")
+}
+
 func (m *MockClient) GetPodContainers(ctx context.Context, namespace, podName string) ([]string, error) {
 	if m.GetPodContainersFunc != nil {
 		return m.GetPodContainersFunc(ctx, namespace, podName)
@@ -89,13 +216,62 @@ func (m *MockClient) GetPodContainers(ctx context.Context, namespace, podName st
 	return nil, fmt.Errorf("GetPodContainersFunc not implemented")
 }
 
+func (m *MockClient) DescribePod(ctx context.Context, namespace, name string) ([]byte, error) {
+	if m.DescribePodFunc != nil {
+		return m.DescribePodFunc(ctx, namespace, name)
+	}
+	return nil, fmt.Errorf("DescribePodFunc not implemented")
+}
+
+func (m *MockClient) StreamPodLogs(ctx context.Context, namespace, podName string, follow bool, opts LogOptions) (<-chan []byte, error) {
+	if m.StreamPodLogsFunc != nil {
+		return m.StreamPodLogsFunc(ctx, namespace, podName, follow, opts)
+	}
+	return nil, fmt.Errorf("StreamPodLogsFunc not implemented")
+}
+
+func (m *MockClient) WatchPods(ctx context.Context, namespace, selector string) (<-chan PodEvent, error) {
+	if m.WatchPodsFunc != nil {
+		return m.WatchPodsFunc(ctx, namespace, selector)
+	}
+	return nil, fmt.Errorf("WatchPodsFunc not implemented")
+}
+
+func (m *MockClient) ExecInPod(ctx context.Context, namespace, podName, container string, command []string) ([]byte, error) {
+	if m.ExecInPodFunc != nil {
+		return m.ExecInPodFunc(ctx, namespace, podName, container, command)
+	}
+	return nil, fmt.Errorf("ExecInPodFunc not implemented")
+}
+
+func (m *MockClient) DeletePod(ctx context.Context, namespace, podName string) error {
+	if m.DeletePodFunc != nil {
+		return m.DeletePodFunc(ctx, namespace, podName)
+	}
+	return fmt.Errorf("DeletePodFunc not implemented")
+}
+
+func (m *MockClient) GetPodMetrics(ctx context.Context, namespace, selector string) (map[string]PodMetrics, error) {
+	if m.GetPodMetricsFunc != nil {
+		return m.GetPodMetricsFunc(ctx, namespace, selector)
+	}
+	return nil, fmt.Errorf("GetPodMetricsFunc not implemented")
+}
+
+func (m *MockClient) PortForward(ctx context.Context, namespace, podName string, localPort, remotePort int) (<-chan error, error) {
+	if m.PortForwardFunc != nil {
+		return m.PortForwardFunc(ctx, namespace, podName, localPort, remotePort)
+	}
+	return nil, fmt.Errorf("PortForwardFunc not implemented")
+}
+
 // Helm operations
 
-func (m *MockClient) GetHelmHistory(ctx context.Context, namespace, releaseName string) ([]byte, error) {
-	if m.GetHelmHistoryFunc != nil {
-		return m.GetHelmHistoryFunc(ctx, namespace, releaseName)
+func (m *MockClient) ListHelmRevisions(ctx context.Context, namespace, releaseName string) ([]HelmRevision, error) {
+	if m.ListHelmRevisionsFunc != nil {
+		return m.ListHelmRevisionsFunc(ctx, namespace, releaseName)
 	}
-	return nil, fmt.Errorf("GetHelmHistoryFunc not implemented")
+	return nil, fmt.Errorf("ListHelmRevisionsFunc not implemented")
 }
 
 func (m *MockClient) RollbackHelm(ctx context.Context, namespace, releaseName string, revision int) error {
@@ -105,6 +281,27 @@ func (m *MockClient) RollbackHelm(ctx context.Context, namespace, releaseName st
 	return fmt.Errorf("RollbackHelmFunc not implemented")
 }
 
+func (m *MockClient) GetHelmManifest(ctx context.Context, namespace, releaseName string, revision int) ([]byte, error) {
+	if m.GetHelmManifestFunc != nil {
+		return m.GetHelmManifestFunc(ctx, namespace, releaseName, revision)
+	}
+	return nil, fmt.Errorf("GetHelmManifestFunc not implemented")
+}
+
+func (m *MockClient) GetHelmNotes(ctx context.Context, namespace, releaseName string) ([]byte, error) {
+	if m.GetHelmNotesFunc != nil {
+		return m.GetHelmNotesFunc(ctx, namespace, releaseName)
+	}
+	return nil, fmt.Errorf("GetHelmNotesFunc not implemented")
+}
+
+func (m *MockClient) GetHelmHooks(ctx context.Context, namespace, releaseName string) ([]byte, error) {
+	if m.GetHelmHooksFunc != nil {
+		return m.GetHelmHooksFunc(ctx, namespace, releaseName)
+	}
+	return nil, fmt.Errorf("GetHelmHooksFunc not implemented")
+}
+
 // Resource operations
 
 func (m *MockClient) GetSecret(ctx context.Context, namespace, name string) ([]byte, error) {
@@ -128,6 +325,20 @@ func (m *MockClient) GetResource(ctx context.Context, namespace, kind, name, out
 	return nil, fmt.Errorf("GetResourceFunc not implemented")
 }
 
+func (m *MockClient) ApplyResource(ctx context.Context, namespace, kind, name string, data []byte) error {
+	if m.ApplyResourceFunc != nil {
+		return m.ApplyResourceFunc(ctx, namespace, kind, name, data)
+	}
+	return fmt.Errorf("ApplyResourceFunc not implemented")
+}
+
+func (m *MockClient) ListServices(ctx context.Context, namespace string) ([]byte, error) {
+	if m.ListServicesFunc != nil {
+		return m.ListServicesFunc(ctx, namespace)
+	}
+	return nil, fmt.Errorf("ListServicesFunc not implemented")
+}
+
 // Event operations
 
 func (m *MockClient) GetEvents(ctx context.Context, namespace string) ([]byte, error) {
@@ -136,3 +347,12 @@ func (m *MockClient) GetEvents(ctx context.Context, namespace string) ([]byte, e
 	}
 	return nil, fmt.Errorf("GetEventsFunc not implemented")
 }
+
+// Namespace operations
+
+func (m *MockClient) ListNamespaces(ctx context.Context) ([]string, error) {
+	if m.ListNamespacesFunc != nil {
+		return m.ListNamespacesFunc(ctx)
+	}
+	return nil, fmt.Errorf("ListNamespacesFunc not implemented")
+}
@@ -0,0 +1,23 @@
+package k8s
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// ListNamespaces lists all namespaces in the cluster
+func (c *KubectlClient) ListNamespaces(ctx context.Context) ([]string, error) {
+	slog.Debug("listing namespaces")
+	out, err := c.runCmd(ctx, "kubectl", "get", "namespaces",
+		"--context", c.Context,
+		"-o", "jsonpath={.items[*].metadata.name}")
+	if err != nil {
+		slog.Error("failed to list namespaces", "error", err)
+		return nil, err
+	}
+
+	namespaces := strings.Fields(strings.TrimSpace(string(out)))
+	slog.Debug("namespaces listed", "count", len(namespaces))
+	return namespaces, nil
+}
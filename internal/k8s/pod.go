@@ -1,11 +1,26 @@
 package k8s
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"io"
+	"log/slog"
+	"os/exec"
 	"strings"
 )
 
+// MaxLogLineBytes is the longest log line StreamPodLogs passes on whole
+const MaxLogLineBytes = 1024 * 1024
+
+// GetPod fetches a pod as YAML
+func (c *KubectlClient) GetPod(ctx context.Context, namespace, name string) ([]byte, error) {
+	return c.runCmd(ctx, "kubectl", "get", "pod", name,
+		"-n", namespace,
+		"--context", c.Context,
+		"-o", "yaml")
+}
+
 // ListPods fetches pods matching a label selector as JSON
 func (c *KubectlClient) ListPods(ctx context.Context, namespace, selector string) ([]byte, error) {
 	return c.runCmd(ctx, "kubectl", "get", "pods",
@@ -15,6 +30,20 @@ func (c *KubectlClient) ListPods(ctx context.Context, namespace, selector string
 		"-o", "json")
 }
 
+// ListAllPods lists pods in every namespace using kubectl
+func (c *KubectlClient) ListAllPods(ctx context.Context, selector string) ([]byte, error) {
+	out, err := c.runCmd(ctx, "kubectl", "get", "pods",
+		"--all-namespaces",
+		"--context", c.Context,
+		"-l", selector,
+		"-o", "json")
+	// "Error from server (Forbidden): pods is forbidden: User ... cannot list resource ..."
+	if err != nil && strings.Contains(string(out), "(Forbidden)") {
+		return nil, fmt.Errorf("%w listing pods in all namespaces: %s", ErrForbidden, strings.TrimSpace(string(out)))
+	}
+	return out, err
+}
+
 // GetPodLogs fetches logs from a pod
 func (c *KubectlClient) GetPodLogs(ctx context.Context, namespace, podName string, tailLines int, allContainers, prefix bool) ([]byte, error) {
 	args := []string{"logs", podName,
@@ -33,6 +62,94 @@ func (c *KubectlClient) GetPodLogs(ctx context.Context, namespace, podName strin
 	return c.runCmd(ctx, "kubectl", args...)
 }
 
+// GetPodLogsWithOptions fetches logs from a pod using the given options
+func (c *KubectlClient) GetPodLogsWithOptions(ctx context.Context, namespace, podName string, opts LogOptions) ([]byte, error) {
+	args := []string{"logs", podName,
+		"-n", namespace,
+		"--context", c.Context,
+		fmt.Sprintf("--tail=%d", opts.TailLines)}
+
+	if opts.Container != "" {
+		args = append(args, "-c", opts.Container)
+	} else if opts.AllContainers {
+		args = append(args, "--all-containers=true")
+	}
+
+	if opts.Prefix {
+		args = append(args, "--prefix")
+	}
+
+	if opts.Previous {
+		args = append(args, "--previous")
+	}
+
+	if opts.Since > 0 {
+		args = append(args, "--since="+opts.Since.String())
+	}
+
+	if opts.Timestamps {
+		args = append(args, "--timestamps")
+	}
+
+	return c.runCmd(ctx, "kubectl", args...)
+}
+
+// StreamPodLogs streams a pod's logs line by line via kubectl logs [-f]
+func (c *KubectlClient) StreamPodLogs(ctx context.Context, namespace, podName string, follow bool, opts LogOptions) (<-chan []byte, error) {
+	args := []string{"logs", podName,
+		"-n", namespace,
+		"--context", c.Context,
+		fmt.Sprintf("--tail=%d", opts.TailLines)}
+	if opts.Container != "" {
+		args = append(args, "-c", opts.Container)
+	}
+	if follow {
+		args = append(args, "-f")
+	}
+	if opts.Since > 0 {
+		args = append(args, "--since="+opts.Since.String())
+	}
+	if opts.Timestamps {
+		args = append(args, "--timestamps")
+	}
+
+	cmd := exec.CommandContext(ctx, "kubectl", args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return streamLines(ctx, stdout, cmd.Wait), nil
+}
+
+// streamLines sends each line read from r on the returned channel, which is
+// closed (after calling done) at EOF or once ctx is cancelled
+func streamLines(ctx context.Context, r io.Reader, done func() error) <-chan []byte {
+	lines := make(chan []byte, 64)
+	go func() {
+		defer close(lines)
+		defer func() {
+			if err := done(); err != nil && ctx.Err() == nil {
+				slog.Debug("log stream ended", "error", err)
+			}
+		}()
+
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), MaxLogLineBytes)
+		for scanner.Scan() {
+			line := append([]byte(nil), scanner.Bytes()...)
+			select {
+			case lines <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return lines
+}
+
 // GetPodContainers returns the list of container names in a pod
 func (c *KubectlClient) GetPodContainers(ctx context.Context, namespace, podName string) ([]string, error) {
 	out, err := c.runCmd(ctx, "kubectl", "get", "pod", podName,
@@ -47,13 +164,31 @@ func (c *KubectlClient) GetPodContainers(ctx context.Context, namespace, podName
 	return containerNames, nil
 }
 
-// GetPodsBySelector fetches logs from all pods matching a selector
-func (c *KubectlClient) GetPodsBySelector(ctx context.Context, namespace, selector string, tailLines int) ([]byte, error) {
-	return c.runCmd(ctx, "kubectl", "logs",
-		"-l", selector,
+// ExecInPod runs a command in a pod container and returns its combined output
+func (c *KubectlClient) ExecInPod(ctx context.Context, namespace, podName, container string, command []string) ([]byte, error) {
+	slog.Info("exec in pod", "pod", podName, "container", container, "namespace", namespace, "command", command)
+	args := []string{"exec", podName,
+		"-n", namespace,
+		"--context", c.Context}
+	if container != "" {
+		args = append(args, "-c", container)
+	}
+	args = append(args, "--")
+	args = append(args, command...)
+	return c.runCmd(ctx, "kubectl", args...)
+}
+
+// DeletePod deletes a pod without waiting for it to terminate
+func (c *KubectlClient) DeletePod(ctx context.Context, namespace, podName string) error {
+	slog.Info("deleting pod", "pod", podName, "namespace", namespace)
+	_, err := c.runCmd(ctx, "kubectl", "delete", "pod", podName,
 		"-n", namespace,
 		"--context", c.Context,
-		"--all-containers=true",
-		"--prefix",
-		fmt.Sprintf("--tail=%d", tailLines))
+		"--wait=false")
+	if err != nil {
+		slog.Error("failed to delete pod", "pod", podName, "error", err)
+		return err
+	}
+	slog.Info("pod deleted successfully", "pod", podName)
+	return nil
 }
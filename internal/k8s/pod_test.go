@@ -0,0 +1,55 @@
+package k8s
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStreamLines(t *testing.T) {
+	doneCalled := false
+	lines := streamLines(context.Background(), strings.NewReader("first\nsecond\n\nthird"), func() error {
+		doneCalled = true
+		return nil
+	})
+
+	var got []string
+	for line := range lines {
+		got = append(got, string(line))
+	}
+
+	want := []string{"first", "second", "", "third"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if !doneCalled {
+		t.Error("done was not called after EOF")
+	}
+}
+
+func TestStreamLines_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r, w := io.Pipe()
+	lines := streamLines(ctx, r, func() error { return r.Close() })
+
+	if _, err := w.Write([]byte("hello\n")); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	if line := <-lines; string(line) != "hello" {
+		t.Errorf("Expected hello, got %q", line)
+	}
+
+	// The reader blocks until the source goes away, as a followed stream does
+	cancel()
+	w.Close()
+	select {
+	case _, ok := <-lines:
+		if ok {
+			t.Error("Expected the channel to be closed after cancel")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+}
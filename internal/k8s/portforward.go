@@ -0,0 +1,125 @@
+package k8s
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os/exec"
+	"strings"
+
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// PortForward forwards localPort on 127.0.0.1 to remotePort of a pod until
+// ctx is cancelled (uses kubectl port-forward). It returns once the local
+// port listens; the channel then receives how the forward ended (nil when
+// cancelled) and is closed.
+func (c *KubectlClient) PortForward(ctx context.Context, namespace, podName string, localPort, remotePort int) (<-chan error, error) {
+	slog.Info("port-forwarding", "pod", podName, "namespace", namespace, "local", localPort, "remote", remotePort)
+	cmd := exec.CommandContext(ctx, "kubectl", "port-forward", "pod/"+podName,
+		fmt.Sprintf("%d:%d", localPort, remotePort),
+		"--address", "127.0.0.1",
+		"-n", namespace,
+		"--context", c.Context)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	// kubectl prints "Forwarding from 127.0.0.1:8080 -> 80" once listening
+	scanner := bufio.NewScanner(stdout)
+	if !scanner.Scan() || !strings.HasPrefix(scanner.Text(), "Forwarding from") {
+		_ = cmd.Wait()
+		return nil, fmt.Errorf("port-forward failed: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		defer close(done)
+		_, _ = io.Copy(io.Discard, stdout)
+		err := cmd.Wait()
+		if ctx.Err() != nil {
+			err = nil
+		} else if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = fmt.Errorf("%s", msg)
+		} else if err == nil {
+			err = errors.New("port-forward exited")
+		}
+		done <- err
+	}()
+	return done, nil
+}
+
+// PortForward forwards localPort on 127.0.0.1 to remotePort of a pod over
+// SPDY until ctx is cancelled. It returns once the local port listens; the
+// channel then receives how the forward ended (nil when cancelled, an error
+// when the pod went away) and is closed.
+func (c *ClientGoClient) PortForward(ctx context.Context, namespace, podName string, localPort, remotePort int) (<-chan error, error) {
+	slog.Info("port-forwarding", "pod", podName, "namespace", namespace, "local", localPort, "remote", remotePort)
+	transport, upgrader, err := spdy.RoundTripperFor(c.config)
+	if err != nil {
+		return nil, err
+	}
+	url := c.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").Namespace(namespace).Name(podName).SubResource("portforward").URL()
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, url)
+
+	stop := make(chan struct{})
+	ready := make(chan struct{})
+	fw, err := portforward.NewOnAddresses(dialer, []string{"127.0.0.1"},
+		[]string{fmt.Sprintf("%d:%d", localPort, remotePort)}, stop, ready, io.Discard, io.Discard)
+	if err != nil {
+		return nil, err
+	}
+
+	var fwErr error
+	finished := make(chan struct{})
+	go func() {
+		fwErr = fw.ForwardPorts()
+		close(finished)
+	}()
+	go func() {
+		select {
+		case <-ctx.Done():
+			close(stop)
+		case <-finished:
+		}
+	}()
+
+	select {
+	case <-ready:
+	case <-finished:
+		err := fwErr
+		if err == nil {
+			err = errors.New("port-forward stopped before it was ready")
+		}
+		return nil, HandleK8sError(err, "pod", podName)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		defer close(done)
+		<-finished
+		err := fwErr
+		if ctx.Err() != nil {
+			err = nil
+		} else if err == nil {
+			err = errors.New("port-forward stopped")
+		}
+		done <- err
+	}()
+	return done, nil
+}
@@ -0,0 +1,34 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMockClient_PortForward(t *testing.T) {
+	mock := NewMockClient()
+
+	if _, err := mock.PortForward(context.Background(), "default", "web-1", 8080, 80); err == nil {
+		t.Error("Expected error for unimplemented PortForward, got nil")
+	}
+
+	mock.PortForwardFunc = func(ctx context.Context, namespace, podName string, localPort, remotePort int) (<-chan error, error) {
+		done := make(chan error, 1)
+		go func() {
+			defer close(done)
+			<-ctx.Done()
+			done <- nil
+		}()
+		return done, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done, err := mock.PortForward(ctx, "default", "web-1", 8080, 80)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Expected a cancelled forward to end without error, got %v", err)
+	}
+}
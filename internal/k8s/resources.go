@@ -2,6 +2,9 @@ package k8s
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+	"strings"
 )
 
 // GetSecret fetches a secret as JSON
@@ -20,6 +23,14 @@ func (c *KubectlClient) GetConfigMap(ctx context.Context, namespace, name string
 		"-o", "yaml")
 }
 
+// ListServices fetches the services of a namespace as a JSON list
+func (c *KubectlClient) ListServices(ctx context.Context, namespace string) ([]byte, error) {
+	return c.runCmd(ctx, "kubectl", "get", "services",
+		"-n", namespace,
+		"--context", c.Context,
+		"-o", "json")
+}
+
 // GetResource is a generic method to fetch any Kubernetes resource
 // kind: "deployment", "pod", "configmap", etc.
 // outputFormat: "yaml", "json", etc.
@@ -29,3 +40,26 @@ func (c *KubectlClient) GetResource(ctx context.Context, namespace, kind, name,
 		"--context", c.Context,
 		"-o", outputFormat)
 }
+
+// ApplyResource server-side applies a YAML or JSON manifest of the object
+// kind/name; kind is compared by name as kubectl has no REST mapper here
+func (c *KubectlClient) ApplyResource(ctx context.Context, namespace, kind, name string, data []byte) error {
+	obj, err := parseManifest(data, namespace, name)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(obj.Kind, kind) {
+		return fmt.Errorf("manifest is a %s, not a %s", obj.Kind, kind)
+	}
+	slog.Info("applying resource", "kind", kind, "name", name, "namespace", namespace)
+	out, err := c.runCmdWithInput(ctx, data, "kubectl", "apply", "-f", "-",
+		"--server-side", "--force-conflicts",
+		"--field-manager", FieldManager,
+		"-n", namespace,
+		"--context", c.Context)
+	if err != nil {
+		slog.Error("failed to apply resource", "kind", kind, "name", name, "error", err)
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
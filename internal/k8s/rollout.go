@@ -0,0 +1,101 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ErrProgressDeadlineExceeded is returned when a deployment's rollout made
+// no progress within its progressDeadlineSeconds
+var ErrProgressDeadlineExceeded = errors.New("progress deadline exceeded")
+
+// RolloutPhase is where a deployment's rollout stands
+type RolloutPhase string
+
+const (
+	RolloutProgressing RolloutPhase = "progressing" // waiting for the rollout
+	RolloutComplete    RolloutPhase = "complete"    // every replica updated and available
+	RolloutFailed      RolloutPhase = "failed"      // stuck past the progress deadline
+)
+
+// RolloutStatus summarizes a deployment's rollout the way kubectl rollout
+// status does
+type RolloutStatus struct {
+	Phase     RolloutPhase
+	Message   string // e.g. "2 of 3 updated replicas are available"
+	Desired   int32
+	Updated   int32
+	Ready     int32
+	Available int32
+}
+
+// GetRolloutStatus reports the rollout progress of a deployment (uses kubectl get)
+func (c *KubectlClient) GetRolloutStatus(ctx context.Context, namespace, name string) (RolloutStatus, error) {
+	out, err := c.GetDeployment(ctx, namespace, name)
+	if err != nil {
+		return RolloutStatus{}, err
+	}
+	var deployment appsv1.Deployment
+	if err := json.Unmarshal(out, &deployment); err != nil {
+		return RolloutStatus{}, fmt.Errorf("invalid deployment: %w", err)
+	}
+	return DeploymentRolloutStatus(&deployment)
+}
+
+// GetRolloutStatus reports the rollout progress of a deployment
+func (c *ClientGoClient) GetRolloutStatus(ctx context.Context, namespace, name string) (RolloutStatus, error) {
+	deployment, err := c.clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return RolloutStatus{}, HandleK8sError(err, "deployment", name)
+	}
+	return DeploymentRolloutStatus(deployment)
+}
+
+// DeploymentRolloutStatus derives the rollout status from a deployment's
+// .status, following kubectl's rollout status checks. A rollout stuck past
+// its progress deadline returns ErrProgressDeadlineExceeded.
+func DeploymentRolloutStatus(d *appsv1.Deployment) (RolloutStatus, error) {
+	desired := int32(1)
+	if d.Spec.Replicas != nil {
+		desired = *d.Spec.Replicas
+	}
+	s := d.Status
+	status := RolloutStatus{
+		Phase:     RolloutProgressing,
+		Desired:   desired,
+		Updated:   s.UpdatedReplicas,
+		Ready:     s.ReadyReplicas,
+		Available: s.AvailableReplicas,
+	}
+
+	if d.Generation > s.ObservedGeneration {
+		status.Message = "waiting for the deployment spec update to be observed"
+		return status, nil
+	}
+	for _, cond := range s.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Status == corev1.ConditionFalse && cond.Reason == "ProgressDeadlineExceeded" {
+			status.Phase = RolloutFailed
+			status.Message = cond.Message
+			return status, fmt.Errorf("deployment %s: %w", d.Name, ErrProgressDeadlineExceeded)
+		}
+	}
+
+	switch {
+	case s.UpdatedReplicas < desired:
+		status.Message = fmt.Sprintf("%d of %d new replicas have been updated", s.UpdatedReplicas, desired)
+	case s.Replicas > s.UpdatedReplicas:
+		status.Message = fmt.Sprintf("%d old replicas are pending termination", s.Replicas-s.UpdatedReplicas)
+	case s.AvailableReplicas < s.UpdatedReplicas:
+		status.Message = fmt.Sprintf("%d of %d updated replicas are available", s.AvailableReplicas, s.UpdatedReplicas)
+	default:
+		status.Phase = RolloutComplete
+		status.Message = fmt.Sprintf("%d of %d replicas updated and available", s.AvailableReplicas, desired)
+	}
+	return status, nil
+}
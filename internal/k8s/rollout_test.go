@@ -0,0 +1,109 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDeploymentRolloutStatus(t *testing.T) {
+	replicas := int32(3)
+	deployment := func(generation, observed int64, status appsv1.DeploymentStatus) *appsv1.Deployment {
+		status.ObservedGeneration = observed
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Generation: generation},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     status,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		deployment  *appsv1.Deployment
+		wantPhase   RolloutPhase
+		wantMessage string
+		wantErr     error
+	}{
+		{
+			name:        "spec update not observed yet",
+			deployment:  deployment(2, 1, appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3}),
+			wantPhase:   RolloutProgressing,
+			wantMessage: "waiting for the deployment spec update to be observed",
+		},
+		{
+			name:        "new replicas being updated",
+			deployment:  deployment(2, 2, appsv1.DeploymentStatus{Replicas: 4, UpdatedReplicas: 1, AvailableReplicas: 3}),
+			wantPhase:   RolloutProgressing,
+			wantMessage: "1 of 3 new replicas have been updated",
+		},
+		{
+			name:        "old replicas terminating",
+			deployment:  deployment(2, 2, appsv1.DeploymentStatus{Replicas: 4, UpdatedReplicas: 3, AvailableReplicas: 3}),
+			wantPhase:   RolloutProgressing,
+			wantMessage: "1 old replicas are pending termination",
+		},
+		{
+			name:        "updated replicas not available yet",
+			deployment:  deployment(2, 2, appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 2}),
+			wantPhase:   RolloutProgressing,
+			wantMessage: "2 of 3 updated replicas are available",
+		},
+		{
+			name:        "complete",
+			deployment:  deployment(2, 2, appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 3}),
+			wantPhase:   RolloutComplete,
+			wantMessage: "3 of 3 replicas updated and available",
+		},
+		{
+			name: "progress deadline exceeded",
+			deployment: deployment(2, 2, appsv1.DeploymentStatus{Replicas: 4, UpdatedReplicas: 1, AvailableReplicas: 3,
+				Conditions: []appsv1.DeploymentCondition{{
+					Type:    appsv1.DeploymentProgressing,
+					Status:  corev1.ConditionFalse,
+					Reason:  "ProgressDeadlineExceeded",
+					Message: `ReplicaSet "web-7d9" has timed out progressing.`,
+				}}}),
+			wantPhase:   RolloutFailed,
+			wantMessage: `ReplicaSet "web-7d9" has timed out progressing.`,
+			wantErr:     ErrProgressDeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DeploymentRolloutStatus(tt.deployment)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got.Phase != tt.wantPhase {
+				t.Errorf("Phase = %q, want %q", got.Phase, tt.wantPhase)
+			}
+			if got.Message != tt.wantMessage {
+				t.Errorf("Message = %q, want %q", got.Message, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestMockClient_GetRolloutStatus(t *testing.T) {
+	mock := NewMockClient()
+
+	if _, err := mock.GetRolloutStatus(context.Background(), "default", "web"); err == nil {
+		t.Error("Expected error for unimplemented GetRolloutStatus, got nil")
+	}
+
+	mock.GetRolloutStatusFunc = func(ctx context.Context, namespace, name string) (RolloutStatus, error) {
+		return RolloutStatus{Phase: RolloutComplete, Desired: 2, Updated: 2, Available: 2}, nil
+	}
+	status, err := mock.GetRolloutStatus(context.Background(), "default", "web")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if status.Phase != RolloutComplete {
+		t.Errorf("Expected complete, got %+v", status)
+	}
+}
@@ -0,0 +1,139 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// GetStatefulSet fetches statefulset information as JSON
+func (c *KubectlClient) GetStatefulSet(ctx context.Context, namespace, name string) ([]byte, error) {
+	slog.Debug("fetching statefulset", "statefulset", name, "namespace", namespace, "context", c.Context)
+	data, err := c.runCmd(ctx, "kubectl", "get", "statefulset", name,
+		"-n", namespace,
+		"--context", c.Context,
+		"-o", "json")
+	if err != nil {
+		slog.Error("failed to fetch statefulset", "statefulset", name, "namespace", namespace, "error", err)
+		return nil, err
+	}
+	return data, nil
+}
+
+// ScaleStatefulSet scales a statefulset to the specified number of replicas
+func (c *KubectlClient) ScaleStatefulSet(ctx context.Context, namespace, name string, replicas int) error {
+	slog.Info("scaling statefulset", "statefulset", name, "namespace", namespace, "replicas", replicas)
+	_, err := c.runCmd(ctx, "kubectl", "scale", "statefulset", name,
+		"--replicas="+fmt.Sprintf("%d", replicas),
+		"-n", namespace,
+		"--context", c.Context)
+	if err != nil {
+		slog.Error("failed to scale statefulset", "statefulset", name, "error", err)
+		return err
+	}
+	return nil
+}
+
+// RestartStatefulSet restarts a statefulset's pods, highest ordinal first
+func (c *KubectlClient) RestartStatefulSet(ctx context.Context, namespace, name string) error {
+	slog.Info("restarting statefulset", "statefulset", name, "namespace", namespace)
+	_, err := c.runCmd(ctx, "kubectl", "rollout", "restart", "statefulset", name,
+		"-n", namespace,
+		"--context", c.Context)
+	if err != nil {
+		slog.Error("failed to restart statefulset", "statefulset", name, "error", err)
+		return err
+	}
+	return nil
+}
+
+// ListStatefulSets lists all statefulsets in a namespace
+func (c *KubectlClient) ListStatefulSets(ctx context.Context, namespace string) ([]string, error) {
+	out, err := c.runCmd(ctx, "kubectl", "get", "statefulsets",
+		"-n", namespace,
+		"--context", c.Context,
+		"-o", "jsonpath={.items[*].metadata.name}")
+	if err != nil {
+		slog.Error("failed to list statefulsets", "namespace", namespace, "error", err)
+		return nil, err
+	}
+	return strings.Fields(strings.TrimSpace(string(out))), nil
+}
+
+// GetStatefulSet retrieves a statefulset as JSON
+func (c *ClientGoClient) GetStatefulSet(ctx context.Context, namespace, name string) ([]byte, error) {
+	slog.Debug("fetching statefulset", "statefulset", name, "namespace", namespace, "context", c.context)
+	sts, err := c.clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		slog.Error("failed to fetch statefulset", "statefulset", name, "namespace", namespace, "error", err)
+		return nil, HandleK8sError(err, "statefulset", name)
+	}
+	return json.Marshal(sts)
+}
+
+// ScaleStatefulSet scales a statefulset through its scale subresource
+func (c *ClientGoClient) ScaleStatefulSet(ctx context.Context, namespace, name string, replicas int) error {
+	slog.Info("scaling statefulset", "statefulset", name, "namespace", namespace, "replicas", replicas)
+	statefulSets := c.clientset.AppsV1().StatefulSets(namespace)
+	scale, err := statefulSets.GetScale(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return HandleK8sError(err, "statefulset", name)
+	}
+	scale.Spec.Replicas = int32(replicas)
+	if _, err := statefulSets.UpdateScale(ctx, name, scale, metav1.UpdateOptions{}); err != nil {
+		slog.Error("failed to scale statefulset", "statefulset", name, "error", err)
+		return err
+	}
+	return nil
+}
+
+// RestartStatefulSet restarts a statefulset (rollout restart): the
+// controller replaces its pods one at a time, highest ordinal first
+func (c *ClientGoClient) RestartStatefulSet(ctx context.Context, namespace, name string) error {
+	slog.Info("restarting statefulset", "statefulset", name, "namespace", namespace)
+	patchData := []byte(fmt.Sprintf(
+		`{"spec": {"template": {"metadata": {"annotations": {"kubectl.kubernetes.io/restartedAt": "%s"}}}}}`,
+		time.Now().Format(time.RFC3339),
+	))
+	_, err := c.clientset.AppsV1().StatefulSets(namespace).Patch(ctx, name, types.StrategicMergePatchType, patchData, metav1.PatchOptions{})
+	if err != nil {
+		slog.Error("failed to restart statefulset", "statefulset", name, "error", err)
+		return err
+	}
+	return nil
+}
+
+// ListStatefulSets lists all statefulsets in a namespace
+func (c *ClientGoClient) ListStatefulSets(ctx context.Context, namespace string) ([]string, error) {
+	list, err := c.clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		slog.Error("failed to list statefulsets", "namespace", namespace, "error", err)
+		return nil, err
+	}
+	names := make([]string, len(list.Items))
+	for i, sts := range list.Items {
+		names[i] = sts.Name
+	}
+	return names, nil
+}
+
+// StatefulSetOrdinal returns the ordinal of a statefulset's pod, e.g. 10 for
+// "db-10" of "db", and false for a pod that is not one of its own
+func StatefulSetOrdinal(statefulSet, podName string) (int, bool) {
+	suffix, found := strings.CutPrefix(podName, statefulSet+"-")
+	if !found {
+		return 0, false
+	}
+	ordinal, err := strconv.Atoi(suffix)
+	if err != nil || ordinal < 0 {
+		return 0, false
+	}
+	return ordinal, true
+}
@@ -0,0 +1,62 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStatefulSetOrdinal(t *testing.T) {
+	tests := []struct {
+		statefulSet, pod string
+		want             int
+		wantOK           bool
+	}{
+		{"db", "db-0", 0, true},
+		{"db", "db-10", 10, true},
+		{"db", "db-replica-0", 0, false},
+		{"db", "db-", 0, false},
+		{"db", "db-abc12", 0, false},
+		{"db", "web-1", 0, false},
+		{"my-db", "my-db-2", 2, true},
+	}
+	for _, tt := range tests {
+		got, ok := StatefulSetOrdinal(tt.statefulSet, tt.pod)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("StatefulSetOrdinal(%q, %q) = %d, %v, want %d, %v", tt.statefulSet, tt.pod, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestMockClient_StatefulSetOperations(t *testing.T) {
+	mock := NewMockClient()
+	ctx := context.Background()
+
+	if err := mock.ScaleStatefulSet(ctx, "default", "db", 3); err == nil {
+		t.Error("Expected error for unimplemented ScaleStatefulSet, got nil")
+	}
+
+	var scaled int
+	restarted := ""
+	mock.ScaleStatefulSetFunc = func(ctx context.Context, namespace, name string, replicas int) error {
+		scaled = replicas
+		return nil
+	}
+	mock.RestartStatefulSetFunc = func(ctx context.Context, namespace, name string) error {
+		restarted = name
+		return nil
+	}
+	mock.ListStatefulSetsFunc = func(ctx context.Context, namespace string) ([]string, error) {
+		return []string{"db", "cache"}, nil
+	}
+
+	if err := mock.ScaleStatefulSet(ctx, "default", "db", 3); err != nil || scaled != 3 {
+		t.Errorf("ScaleStatefulSet: err %v, scaled to %d", err, scaled)
+	}
+	if err := mock.RestartStatefulSet(ctx, "default", "db"); err != nil || restarted != "db" {
+		t.Errorf("RestartStatefulSet: err %v, restarted %q", err, restarted)
+	}
+	names, err := mock.ListStatefulSets(ctx, "default")
+	if err != nil || len(names) != 2 {
+		t.Errorf("ListStatefulSets = %v, %v", names, err)
+	}
+}
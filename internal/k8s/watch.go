@@ -0,0 +1,82 @@
+package k8s
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// watchRestartDelay is how long to wait before re-opening a watch the API
+// server closed (watches expire after a few minutes)
+var watchRestartDelay = time.Second
+
+// PodEvent is a pod change pushed by WatchPods
+type PodEvent struct {
+	Type watch.EventType // watch.Added, watch.Modified or watch.Deleted
+	Pod  *corev1.Pod
+}
+
+// WatchPods is not available through kubectl; callers keep polling
+func (c *KubectlClient) WatchPods(ctx context.Context, namespace, selector string) (<-chan PodEvent, error) {
+	return nil, ErrWatchNotSupported
+}
+
+// relayPodEvents forwards the pod events of w, re-opening the watch with
+// restart whenever the server closes it. The channel is closed once ctx is
+// cancelled or a restart fails.
+func relayPodEvents(ctx context.Context, w watch.Interface, restart func(ctx context.Context) (watch.Interface, error)) <-chan PodEvent {
+	events := make(chan PodEvent, 64)
+	go func() {
+		defer close(events)
+		for {
+			forwardPodEvents(ctx, w, events)
+			w.Stop()
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(watchRestartDelay):
+			}
+
+			var err error
+			if w, err = restart(ctx); err != nil {
+				slog.Debug("pod watch restart failed", "error", err)
+				return
+			}
+		}
+	}()
+	return events
+}
+
+// forwardPodEvents sends w's pod events until the watch ends or ctx is done
+func forwardPodEvents(ctx context.Context, w watch.Interface, events chan<- PodEvent) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok := <-w.ResultChan():
+			if !ok {
+				return
+			}
+			switch ev.Type {
+			case watch.Added, watch.Modified, watch.Deleted:
+				pod, isPod := ev.Object.(*corev1.Pod)
+				if !isPod {
+					continue
+				}
+				select {
+				case events <- PodEvent{Type: ev.Type, Pod: pod}:
+				case <-ctx.Done():
+					return
+				}
+			case watch.Error:
+				// Usually "resource version too old": start over
+				slog.Debug("pod watch error", "object", ev.Object)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func testPod(name string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+// nextPodEvent waits for an event, failing the test if none arrives
+func nextPodEvent(t *testing.T, events <-chan PodEvent) (PodEvent, bool) {
+	t.Helper()
+	select {
+	case ev, ok := <-events:
+		return ev, ok
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for a pod event")
+		return PodEvent{}, false
+	}
+}
+
+func TestRelayPodEvents(t *testing.T) {
+	watchRestartDelay = time.Millisecond
+	defer func() { watchRestartDelay = time.Second }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first, second := watch.NewFake(), watch.NewFake()
+	restarts := 0
+	restart := func(ctx context.Context) (watch.Interface, error) {
+		restarts++
+		if restarts == 1 {
+			return second, nil
+		}
+		return nil, errors.New("forbidden")
+	}
+
+	events := relayPodEvents(ctx, first, restart)
+
+	go first.Add(testPod("web-1"))
+	if ev, _ := nextPodEvent(t, events); ev.Type != watch.Added || ev.Pod.Name != "web-1" {
+		t.Errorf("Expected ADDED web-1, got %s %v", ev.Type, ev.Pod)
+	}
+	go first.Modify(testPod("web-1"))
+	if ev, _ := nextPodEvent(t, events); ev.Type != watch.Modified {
+		t.Errorf("Expected MODIFIED, got %s", ev.Type)
+	}
+
+	// An error event re-opens the watch
+	go first.Error(&metav1.Status{Message: "too old resource version"})
+	go second.Delete(testPod("web-1"))
+	if ev, _ := nextPodEvent(t, events); ev.Type != watch.Deleted || ev.Pod.Name != "web-1" {
+		t.Errorf("Expected DELETED web-1 from the restarted watch, got %s %v", ev.Type, ev.Pod)
+	}
+
+	// A failed restart closes the channel
+	second.Stop()
+	if _, ok := nextPodEvent(t, events); ok {
+		t.Error("Expected channel to be closed after a failed restart")
+	}
+	if restarts != 2 {
+		t.Errorf("Expected 2 restarts, got %d", restarts)
+	}
+}
+
+func TestRelayPodEvents_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := watch.NewFake()
+	events := relayPodEvents(ctx, w, func(ctx context.Context) (watch.Interface, error) {
+		t.Error("Expected no restart after cancel")
+		return nil, ctx.Err()
+	})
+
+	cancel()
+	if _, ok := nextPodEvent(t, events); ok {
+		t.Error("Expected channel to be closed after cancel")
+	}
+}
+
+func TestKubectlClient_WatchPods(t *testing.T) {
+	c := NewKubectlClient("test")
+	if _, err := c.WatchPods(context.Background(), "default", "app=web"); !errors.Is(err, ErrWatchNotSupported) {
+		t.Errorf("Expected ErrWatchNotSupported, got %v", err)
+	}
+}
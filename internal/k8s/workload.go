@@ -0,0 +1,102 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WorkloadKinds are the kinds ResolveWorkload probes for, in order: a name
+// shared by several kinds resolves to the first
+var WorkloadKinds = []string{"Deployment", "StatefulSet", "DaemonSet", "ReplicaSet"}
+
+// ResolveWorkload finds which kind of workload name is (uses one kubectl get
+// over every kind, ignoring the ones that don't exist)
+func (c *KubectlClient) ResolveWorkload(ctx context.Context, namespace, name string) (string, error) {
+	refs := make([]string, len(WorkloadKinds))
+	for i, kind := range WorkloadKinds {
+		refs[i] = strings.ToLower(kind) + "/" + name
+	}
+	args := append([]string{"get"}, refs...)
+	args = append(args, "-n", namespace, "--context", c.Context, "--ignore-not-found", "-o", "jsonpath={.items[*].kind}")
+	out, err := c.runCmd(ctx, "kubectl", args...)
+	if err != nil {
+		return "", fmt.Errorf("%v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return firstWorkloadKind(strings.Fields(string(out)), name)
+}
+
+// ResolveWorkload finds which kind of workload name is, trying each of
+// WorkloadKinds in turn
+func (c *ClientGoClient) ResolveWorkload(ctx context.Context, namespace, name string) (string, error) {
+	apps := c.clientset.AppsV1()
+	probes := map[string]func() error{
+		"Deployment": func() error {
+			_, err := apps.Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+			return err
+		},
+		"StatefulSet": func() error {
+			_, err := apps.StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+			return err
+		},
+		"DaemonSet": func() error {
+			_, err := apps.DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+			return err
+		},
+		"ReplicaSet": func() error {
+			_, err := apps.ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+			return err
+		},
+	}
+	for _, kind := range WorkloadKinds {
+		err := probes[kind]()
+		if err == nil {
+			slog.Debug("resolved workload", "name", name, "kind", kind, "namespace", namespace)
+			return kind, nil
+		}
+		if !k8serrors.IsNotFound(err) {
+			return "", HandleK8sError(err, strings.ToLower(kind), name)
+		}
+	}
+	return firstWorkloadKind(nil, name)
+}
+
+// firstWorkloadKind picks the kind that comes first in WorkloadKinds
+func firstWorkloadKind(found []string, name string) (string, error) {
+	for _, kind := range WorkloadKinds {
+		for _, f := range found {
+			if f == kind {
+				return kind, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no deployment, statefulset, daemonset or replicaset '%s': %w", name, ErrNotFound)
+}
+
+// LabelSelector renders a workload's spec.selector, both matchLabels and
+// matchExpressions, as a label selector string for ListPods, e.g.
+// "app=web,env in (prod,staging),tier!=cache". A workload without a
+// selector yields "".
+func LabelSelector(workloadJSON []byte) (string, error) {
+	var obj struct {
+		Spec struct {
+			Selector *metav1.LabelSelector `json:"selector"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(workloadJSON, &obj); err != nil {
+		return "", fmt.Errorf("invalid workload JSON: %w", err)
+	}
+	if obj.Spec.Selector == nil {
+		return "", nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(obj.Spec.Selector)
+	if err != nil {
+		return "", fmt.Errorf("invalid spec.selector: %w", err)
+	}
+	return selector.String(), nil
+}
@@ -0,0 +1,86 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFirstWorkloadKind(t *testing.T) {
+	tests := []struct {
+		name    string
+		found   []string
+		want    string
+		wantErr bool
+	}{
+		{"deployment", []string{"Deployment"}, "Deployment", false},
+		{"statefulset", []string{"StatefulSet"}, "StatefulSet", false},
+		{"deployment wins over its replicaset name", []string{"ReplicaSet", "Deployment"}, "Deployment", false},
+		{"daemonset before replicaset", []string{"ReplicaSet", "DaemonSet"}, "DaemonSet", false},
+		{"unknown kinds ignored", []string{"Job"}, "", true},
+		{"nothing", nil, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := firstWorkloadKind(tt.found, "db")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("firstWorkloadKind() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !IsNotFound(err) {
+				t.Errorf("error %v should wrap ErrNotFound", err)
+			}
+			if got != tt.want {
+				t.Errorf("firstWorkloadKind() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMockClient_ResolveWorkload(t *testing.T) {
+	mock := NewMockClient()
+	if _, err := mock.ResolveWorkload(context.Background(), "default", "db"); err == nil {
+		t.Error("Expected error for unimplemented ResolveWorkload, got nil")
+	}
+
+	mock.ResolveWorkloadFunc = func(ctx context.Context, namespace, name string) (string, error) {
+		return "StatefulSet", nil
+	}
+	kind, err := mock.ResolveWorkload(context.Background(), "default", "db")
+	if err != nil || kind != "StatefulSet" {
+		t.Errorf("ResolveWorkload = %q, %v", kind, err)
+	}
+}
+
+func TestLabelSelector(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    string
+		wantErr bool
+	}{
+		{"matchLabels", `{"spec":{"selector":{"matchLabels":{"tier":"web","app":"shop"}}}}`, "app=shop,tier=web", false},
+		{"in and notin", `{"spec":{"selector":{"matchExpressions":[
+			{"key":"env","operator":"In","values":["staging","prod"]},
+			{"key":"tier","operator":"NotIn","values":["cache"]}]}}}`, "env in (prod,staging),tier notin (cache)", false},
+		{"exists and does not exist", `{"spec":{"selector":{"matchExpressions":[
+			{"key":"canary","operator":"DoesNotExist"},
+			{"key":"app","operator":"Exists"}]}}}`, "app,!canary", false},
+		{"both", `{"spec":{"selector":{"matchLabels":{"app":"shop"},"matchExpressions":[
+			{"key":"env","operator":"In","values":["prod"]}]}}}`, "app=shop,env in (prod)", false},
+		{"empty selector", `{"spec":{"selector":{}}}`, "", false},
+		{"no selector", `{"spec":{}}`, "", false},
+		{"unknown operator", `{"spec":{"selector":{"matchExpressions":[{"key":"env","operator":"Near","values":["prod"]}]}}}`, "", true},
+		{"in without values", `{"spec":{"selector":{"matchExpressions":[{"key":"env","operator":"In"}]}}}`, "", true},
+		{"not JSON", `spec:`, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LabelSelector([]byte(tt.json))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LabelSelector() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("LabelSelector() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,17 +1,57 @@
 package logger
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"os/user"
+	"path/filepath"
 )
 
+const (
+	// EnvLogFile overrides the log file location
+	EnvLogFile = "K9S_DECK_LOG_FILE"
+
+	// MaxSize is the size at which the log file is rotated to <path>.1
+	MaxSize = 10 * 1024 * 1024
+
+	// tailChunkSize bounds how much of the log file Tail reads from the end
+	tailChunkSize = 256 * 1024
+)
+
+// logPath is the file the logger was initialized with
+var logPath string
+
 // Init initializes the structured logger to write to a file
 // This avoids interfering with the TUI output
-func Init() error {
-	logFile, err := os.OpenFile("/tmp/k9s-deck.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+// path may be empty, in which case $K9S_DECK_LOG_FILE or DefaultPath is used
+func Init(path string) error {
+	if path == "" {
+		path = os.Getenv(EnvLogFile)
+	}
+	if path == "" {
+		var err error
+		if path, err = DefaultPath(); err != nil {
+			return err
+		}
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("cannot create log directory %s: %w", dir, err)
+	}
+
+	logFile, err := newRotatingFile(path, MaxSize)
 	if err != nil {
-		return err
+		if errors.Is(err, os.ErrPermission) {
+			return fmt.Errorf("log directory %s is not writable: %w", dir, err)
+		}
+		return fmt.Errorf("cannot open log file %s: %w", path, err)
 	}
+	logPath = path
 
 	handler := slog.NewJSONHandler(logFile, &slog.HandlerOptions{
 		Level: getLogLevel(),
@@ -22,6 +62,71 @@ func Init() error {
 	return nil
 }
 
+// DefaultPath returns the per-user log file under the XDG state directory
+// ($XDG_STATE_HOME, falling back to ~/.local/state)
+func DefaultPath() (string, error) {
+	stateDir := os.Getenv("XDG_STATE_HOME")
+	if stateDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("cannot determine log directory: %w", err)
+		}
+		stateDir = filepath.Join(home, ".local", "state")
+	}
+
+	// Include the username so shared machines don't collide
+	suffix := fmt.Sprintf("%d", os.Getpid())
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		suffix = filepath.Base(u.Username)
+	}
+	return filepath.Join(stateDir, "k9s-deck", "k9s-deck-"+suffix+".log"), nil
+}
+
+// Path returns the file the logger writes to
+func Path() string {
+	return logPath
+}
+
+// Tail returns the last n lines of the log file
+func Tail(n int) ([]byte, error) {
+	if logPath == "" {
+		return nil, fmt.Errorf("logger not initialized")
+	}
+	f, err := os.Open(logPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	// Only read the end of the file - the log can grow large
+	offset := info.Size() - tailChunkSize
+	if offset < 0 {
+		offset = 0
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := bytes.Split(bytes.TrimRight(data, "\n"), []byte("\n"))
+	if offset > 0 && len(lines) > 1 {
+		// First line is likely cut in half by the seek
+		lines = lines[1:]
+	}
+	if n > 0 && len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return bytes.Join(lines, []byte("\n")), nil
+}
+
 // getLogLevel returns the log level from environment variable
 // Defaults to INFO if not set
 func getLogLevel() slog.Level {
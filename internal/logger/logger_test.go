@@ -0,0 +1,85 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDefaultPath_XDGStateHome(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", dir)
+
+	path, err := DefaultPath()
+	if err != nil {
+		t.Fatalf("DefaultPath() error = %v", err)
+	}
+	if !strings.HasPrefix(path, filepath.Join(dir, "k9s-deck")+string(filepath.Separator)) {
+		t.Errorf("DefaultPath() = %q, want it under %q", path, dir)
+	}
+	if !strings.HasSuffix(path, ".log") {
+		t.Errorf("DefaultPath() = %q, want .log suffix", path)
+	}
+}
+
+func TestInit_UnwritableDirectory(t *testing.T) {
+	dir := t.TempDir()
+	blocker := filepath.Join(dir, "file")
+	if err := os.WriteFile(blocker, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// A regular file in place of the directory can never be created
+	err := Init(filepath.Join(blocker, "sub", "k9s-deck.log"))
+	if err == nil {
+		t.Fatal("Init() expected error for unusable directory")
+	}
+	if !strings.Contains(err.Error(), "log directory") {
+		t.Errorf("Init() error = %q, want it to mention the log directory", err)
+	}
+}
+
+func TestRotatingFile_Rotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+
+	w, err := newRotatingFile(path, 10)
+	if err != nil {
+		t.Fatalf("newRotatingFile() error = %v", err)
+	}
+	if _, err := w.Write([]byte("first-line\n")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("second\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	rotated, err := os.ReadFile(path + ".1")
+	if err != nil {
+		t.Fatalf("expected rotated file: %v", err)
+	}
+	if string(rotated) != "first-line\n" {
+		t.Errorf("rotated content = %q", rotated)
+	}
+	current, _ := os.ReadFile(path)
+	if string(current) != "second\n" {
+		t.Errorf("current content = %q", current)
+	}
+}
+
+func TestTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	if err := os.WriteFile(path, []byte("a\nb\nc\nd\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	logPath = path
+	defer func() { logPath = "" }()
+
+	out, err := Tail(2)
+	if err != nil {
+		t.Fatalf("Tail() error = %v", err)
+	}
+	if string(out) != "c\nd" {
+		t.Errorf("Tail(2) = %q, want %q", out, "c\nd")
+	}
+}
@@ -0,0 +1,143 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// JSONLogMode is how formatted logs render JSON lines
+type JSONLogMode int
+
+const (
+	JSONPretty          JSONLogMode = iota // indented and highlighted
+	JSONFlat                               // one line of dotted-path pairs
+	JSONColumns                            // LogFields in aligned columns, "+N fields" for the rest
+	JSONColumnsExpanded                    // JSONColumns with the rest spelled out
+)
+
+// MaxLogColumnWidth caps the width a JSON log column is padded to
+const MaxLogColumnWidth = 40
+
+// LogFields are the flattened JSON keys shown as columns, in order (set
+// from the config's logFields)
+var LogFields = []string{"level", "msg", "ts"}
+
+// logFieldAliases are the keys common loggers use for the default fields
+var logFieldAliases = map[string][]string{
+	"level": {"level", "lvl", "severity", "log.level"},
+	"msg":   {"msg", "message"},
+	"ts":    {"ts", "time", "timestamp", "@timestamp"},
+}
+
+// logFieldMatches reports whether the flattened key holds field
+func logFieldMatches(field, key string) bool {
+	if strings.EqualFold(field, key) {
+		return true
+	}
+	for _, alias := range logFieldAliases[strings.ToLower(field)] {
+		if strings.EqualFold(alias, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// JSONLogColumns splits a JSON log into the values of LogFields ("" for a
+// missing one) and its remaining key/value pairs, ok=false if it isn't JSON
+func JSONLogColumns(line string) (columns []string, rest [][2]string, ok bool) {
+	dec := json.NewDecoder(strings.NewReader(line))
+	dec.UseNumber()
+	var pairs [][2]string
+	if err := flattenJSONValue(dec, "", &pairs); err != nil || dec.More() {
+		return nil, nil, false
+	}
+
+	used := make([]bool, len(pairs))
+	columns = make([]string, len(LogFields))
+	for i, field := range LogFields {
+		for j, kv := range pairs {
+			if used[j] || !logFieldMatches(field, kv[0]) {
+				continue
+			}
+			used[j] = true
+			columns[i] = kv[1]
+			if unquoted, err := strconv.Unquote(kv[1]); err == nil {
+				columns[i] = unquoted
+			}
+			break
+		}
+	}
+	for j, kv := range pairs {
+		if !used[j] {
+			rest = append(rest, kv)
+		}
+	}
+	return columns, rest, true
+}
+
+// JSONColumnWidths measures each LogFields column over the JSON lines of a
+// log, so FormatJSONColumns can line them up
+func JSONColumnWidths(lines []string) []int {
+	widths := make([]int, len(LogFields))
+	for _, line := range lines {
+		info := ParseLogLine(line)
+		if !DetectJSONLog(info.LogContent) {
+			continue
+		}
+		columns, _, ok := JSONLogColumns(info.LogContent)
+		if !ok {
+			continue
+		}
+		for i, value := range columns {
+			if w := lipgloss.Width(value); w > widths[i] {
+				widths[i] = min(w, MaxLogColumnWidth)
+			}
+		}
+	}
+	return widths
+}
+
+// FormatJSONColumns renders a JSON log as its LogFields padded to widths,
+// the level colored and the timestamp dimmed, followed by a "+N fields"
+// marker or, expanded, the remaining pairs. Lines that aren't valid JSON
+// are returned unchanged.
+func FormatJSONColumns(line string, widths []int, expanded bool) string {
+	columns, rest, ok := JSONLogColumns(line)
+	if !ok {
+		return line
+	}
+
+	dim := lipgloss.NewStyle().Foreground(Colors.Gray)
+	parts := make([]string, 0, len(columns)+1)
+	for i, value := range columns {
+		if value == "" && widths[i] == 0 {
+			// No line has this field
+			continue
+		}
+		value += strings.Repeat(" ", max(widths[i]-lipgloss.Width(value), 0))
+		switch {
+		case logFieldMatches("level", LogFields[i]):
+			value = lipgloss.NewStyle().Foreground(GetLogLevelColor(strings.TrimSpace(value))).Bold(true).Render(value)
+		case logFieldMatches("ts", LogFields[i]):
+			value = dim.Render(value)
+		}
+		parts = append(parts, value)
+	}
+
+	switch {
+	case len(rest) == 0:
+	case expanded:
+		for _, kv := range rest {
+			parts = append(parts, dim.Render(kv[0]+"=")+kv[1])
+		}
+	case len(rest) == 1:
+		parts = append(parts, dim.Render("+1 field"))
+	default:
+		parts = append(parts, dim.Render(fmt.Sprintf("+%d fields", len(rest))))
+	}
+	return strings.TrimRight(strings.Join(parts, " "), " ")
+}
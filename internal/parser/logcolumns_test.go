@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJSONLogColumns(t *testing.T) {
+	tests := []struct {
+		name        string
+		line        string
+		wantColumns []string
+		wantRest    [][2]string
+		wantOK      bool
+	}{
+		{
+			name:        "default fields",
+			line:        `{"ts":"12:00:01","level":"info","msg":"listening on :80","port":80}`,
+			wantColumns: []string{"info", "listening on :80", "12:00:01"},
+			wantRest:    [][2]string{{"port", "80"}},
+			wantOK:      true,
+		},
+		{
+			name:        "aliases",
+			line:        `{"severity":"WARN","message":"slow","time":"t1"}`,
+			wantColumns: []string{"WARN", "slow", "t1"},
+			wantOK:      true,
+		},
+		{
+			name:        "missing fields",
+			line:        `{"msg":"hi","req":{"id":7}}`,
+			wantColumns: []string{"", "hi", ""},
+			wantRest:    [][2]string{{"req.id", "7"}},
+			wantOK:      true,
+		},
+		{
+			name:   "invalid JSON",
+			line:   `{"level":`,
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, rest, ok := JSONLogColumns(tt.line)
+			if ok != tt.wantOK {
+				t.Fatalf("JSONLogColumns() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !reflect.DeepEqual(columns, tt.wantColumns) {
+				t.Errorf("JSONLogColumns() columns = %q, want %q", columns, tt.wantColumns)
+			}
+			if !reflect.DeepEqual(rest, tt.wantRest) {
+				t.Errorf("JSONLogColumns() rest = %q, want %q", rest, tt.wantRest)
+			}
+		})
+	}
+}
+
+func TestFormatJSONColumns(t *testing.T) {
+	line := `{"level":"info","msg":"started","port":80,"tls":false}`
+	widths := []int{5, 10, 0}
+
+	tests := []struct {
+		name     string
+		line     string
+		expanded bool
+		want     string
+	}{
+		{"collapsed", line, false, "info  started    +2 fields"},
+		{"expanded", line, true, "info  started    port=80 tls=false"},
+		{"one extra field", `{"level":"error","msg":"boom","code":1}`, false, "error boom       +1 field"},
+		{"nothing extra", `{"level":"info","msg":"ok","ts":"t1"}`, false, "info  ok         t1"},
+		{"not JSON", "plain text", false, "plain text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatJSONColumns(tt.line, widths, tt.expanded); got != tt.want {
+				t.Errorf("FormatJSONColumns() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
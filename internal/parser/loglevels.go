@@ -0,0 +1,143 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// BuiltinLogLevels are the level keywords detected without configuration
+var BuiltinLogLevels = []string{"FATAL", "ERROR", "ERR", "WARN", "WARNING", "INFO", "DEBUG", "TRACE"}
+
+// customLogLevels maps extra level keywords (upper case) to their color: a
+// built-in level (error, warn, ...), a color name (red, yellow, green, gray)
+// or a lipgloss color like "#ff5f00" or "202"
+var customLogLevels = map[string]string{}
+
+var (
+	logLevelKeywordRegex = regexp.MustCompile(`^\w+$`)
+	hexColorRegex        = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+)
+
+// logLevelPattern matches any of keywords as a whole word, case-insensitively
+func logLevelPattern(keywords []string) *regexp.Regexp {
+	quoted := make([]string, len(keywords))
+	for i, k := range keywords {
+		quoted[i] = regexp.QuoteMeta(k)
+	}
+	return regexp.MustCompile(`(?i)\b(` + strings.Join(quoted, "|") + `)\b`)
+}
+
+// SetLogLevels adds level keywords to the detected levels, e.g.
+// {"CRITICAL": "red", "NOTICE": "info"}, replacing earlier ones. A keyword
+// that isn't a single word is an error; a color lipgloss can't use falls back
+// to the default text color and is returned as a warning.
+func SetLogLevels(levels map[string]string) (warning error, err error) {
+	keywords := append([]string(nil), BuiltinLogLevels...)
+	custom := make(map[string]string, len(levels))
+	var bad []string
+	names := make([]string, 0, len(levels))
+	for keyword := range levels {
+		names = append(names, keyword)
+	}
+	sort.Strings(names)
+	for _, keyword := range names {
+		color := levels[keyword]
+		if !logLevelKeywordRegex.MatchString(keyword) {
+			return nil, fmt.Errorf("logLevels: %q is not a single word", keyword)
+		}
+		keyword = strings.ToUpper(keyword)
+		color = strings.TrimSpace(color)
+		if !ValidLevelColor(color) {
+			bad = append(bad, fmt.Sprintf("%s: %q", keyword, color))
+			color = ""
+		}
+		custom[keyword] = color
+		if !slices.Contains(keywords, keyword) {
+			keywords = append(keywords, keyword)
+		}
+	}
+
+	customLogLevels = custom
+	logLevelRegex = logLevelPattern(keywords)
+	if len(bad) > 0 {
+		return fmt.Errorf("logLevels: unknown colors (%s), using the default text color", strings.Join(bad, ", ")), nil
+	}
+	return nil, nil
+}
+
+// ValidLevelColor reports whether color is a built-in level, a color name,
+// a hex color or an ANSI color number
+func ValidLevelColor(color string) bool {
+	if slices.Contains(BuiltinLogLevels, strings.ToUpper(color)) || hexColorRegex.MatchString(color) {
+		return true
+	}
+	switch strings.ToLower(color) {
+	case "red", "yellow", "green", "gray", "grey":
+		return true
+	}
+	n, err := strconv.Atoi(color)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// levelColor resolves a customLogLevels color, "" being the default text color
+func levelColor(color string) lipgloss.Color {
+	if slices.Contains(BuiltinLogLevels, strings.ToUpper(color)) {
+		return builtinLogLevelColor(strings.ToUpper(color))
+	}
+	switch strings.ToLower(color) {
+	case "":
+		return Colors.Text
+	case "red":
+		return Colors.Red
+	case "yellow":
+		return Colors.Yellow
+	case "green":
+		return Colors.Green
+	case "gray", "grey":
+		return Colors.Gray
+	}
+	return lipgloss.Color(color)
+}
+
+// LogLevelRank orders log levels by severity, 0 for unknown. Custom levels
+// rank like the built-in level they're colored as; otherwise red ones rank
+// as errors, yellow ones as warnings and the rest as info.
+func LogLevelRank(level string) int {
+	if color, ok := customLogLevels[strings.ToUpper(level)]; ok {
+		switch {
+		case slices.Contains(BuiltinLogLevels, strings.ToUpper(color)):
+			return builtinLogLevelRank(color)
+		case strings.EqualFold(color, "red"):
+			return builtinLogLevelRank("ERROR")
+		case strings.EqualFold(color, "yellow"):
+			return builtinLogLevelRank("WARN")
+		}
+		return builtinLogLevelRank("INFO")
+	}
+	return builtinLogLevelRank(level)
+}
+
+// builtinLogLevelRank orders the built-in levels by severity, 0 for others
+func builtinLogLevelRank(level string) int {
+	switch strings.ToUpper(level) {
+	case "TRACE":
+		return 1
+	case "DEBUG":
+		return 2
+	case "INFO":
+		return 3
+	case "WARN", "WARNING":
+		return 4
+	case "ERROR", "ERR":
+		return 5
+	case "FATAL":
+		return 6
+	}
+	return 0
+}
@@ -0,0 +1,111 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestSetLogLevels(t *testing.T) {
+	t.Cleanup(func() { SetLogLevels(nil) })
+
+	warning, err := SetLogLevels(map[string]string{
+		"CRITICAL": "red",
+		"notice":   "info",
+		"FINE":     "#5f87af",
+		"SEVERE":   "202",
+		"BOGUS":    "not-a-color",
+	})
+	if err != nil {
+		t.Fatalf("SetLogLevels() error = %v", err)
+	}
+	if warning == nil || !strings.Contains(warning.Error(), `BOGUS: "not-a-color"`) {
+		t.Errorf("SetLogLevels() warning = %v, want one naming BOGUS", warning)
+	}
+
+	colors := []struct {
+		level string
+		want  lipgloss.Color
+	}{
+		{"CRITICAL", Colors.Red},
+		{"critical", Colors.Red},
+		{"NOTICE", GetLogLevelColor("INFO")},
+		{"FINE", lipgloss.Color("#5f87af")},
+		{"SEVERE", lipgloss.Color("202")},
+		{"BOGUS", GetLogLevelColor("")},
+		// The defaults are kept
+		{"ERROR", Colors.Red},
+		{"WARN", Colors.Yellow},
+	}
+	for _, tt := range colors {
+		if got := GetLogLevelColor(tt.level); got != tt.want {
+			t.Errorf("GetLogLevelColor(%q) = %q, want %q", tt.level, got, tt.want)
+		}
+	}
+
+	for line, want := range map[string]string{
+		"disk full, CRITICAL":       "CRITICAL",
+		"Notice: config reloaded":   "NOTICE",
+		"ERROR: connection refused": "ERROR",
+		"nothing to see":            "",
+	} {
+		if got := ParseLogLine(line).LogLevel; got != want {
+			t.Errorf("ParseLogLine(%q).LogLevel = %q, want %q", line, got, want)
+		}
+	}
+}
+
+func TestLogLevelRank(t *testing.T) {
+	t.Cleanup(func() { SetLogLevels(nil) })
+	if _, err := SetLogLevels(map[string]string{"CRITICAL": "red", "NOTICE": "info", "HINT": "yellow", "AUDIT": "#5f87af"}); err != nil {
+		t.Fatal(err)
+	}
+
+	for level, want := range map[string]int{
+		"trace":    1,
+		"DEBUG":    2,
+		"INFO":     3,
+		"WARNING":  4,
+		"ERR":      5,
+		"FATAL":    6,
+		"CRITICAL": 5, // red ranks as an error
+		"notice":   3, // colored as info
+		"HINT":     4, // yellow ranks as a warning
+		"AUDIT":    3, // any other color ranks as info
+		"VERBOSE":  0,
+	} {
+		if got := LogLevelRank(level); got != want {
+			t.Errorf("LogLevelRank(%q) = %d, want %d", level, got, want)
+		}
+	}
+}
+
+func TestSetLogLevels_InvalidKeyword(t *testing.T) {
+	t.Cleanup(func() { SetLogLevels(nil) })
+
+	if _, err := SetLogLevels(map[string]string{"NOT A WORD": "red"}); err == nil {
+		t.Error("SetLogLevels() should reject a keyword with spaces")
+	}
+}
+
+func TestValidLevelColor(t *testing.T) {
+	for color, want := range map[string]bool{
+		"red":     true,
+		"Grey":    true,
+		"error":   true,
+		"#fff":    true,
+		"#5f87af": true,
+		"0":       true,
+		"255":     true,
+		"256":     false,
+		"-1":      false,
+		"#ggg":    false,
+		"crimson": false,
+		"":        false,
+	} {
+		if got := ValidLevelColor(color); got != want {
+			t.Errorf("ValidLevelColor(%q) = %v, want %v", color, got, want)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CollapseRepeatedLines merges runs of consecutive lines with the same
+// content after the pod prefix and timestamp into their first line, returning
+// the kept lines and how often each was repeated. Blank lines are never merged.
+func CollapseRepeatedLines(lines []string) (kept []string, counts []int) {
+	var last string
+	lastBlank := true
+	for _, line := range lines {
+		content := ParseLogLine(line).LogContent
+		blank := strings.TrimSpace(line) == ""
+		if !blank && !lastBlank && content == last {
+			counts[len(counts)-1]++
+			continue
+		}
+		kept = append(kept, line)
+		counts = append(counts, 1)
+		last, lastBlank = content, blank
+	}
+	return kept, counts
+}
+
+// RepeatSuffix marks a line shown once for count identical lines, like
+// dmesg's " (x12)"; "" for a single line
+func RepeatSuffix(count int) string {
+	if count < 2 {
+		return ""
+	}
+	return fmt.Sprintf(" (x%d)", count)
+}
@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCollapseRepeatedLines(t *testing.T) {
+	tests := []struct {
+		name       string
+		lines      []string
+		wantKept   []string
+		wantCounts []int
+	}{
+		{
+			name:       "consecutive repeats merge",
+			lines:      []string{"a", "a", "a", "b"},
+			wantKept:   []string{"a", "b"},
+			wantCounts: []int{3, 1},
+		},
+		{
+			name:       "count restarts after a different line",
+			lines:      []string{"a", "a", "b", "a"},
+			wantKept:   []string{"a", "b", "a"},
+			wantCounts: []int{2, 1, 1},
+		},
+		{
+			name:       "pod prefixes are ignored",
+			lines:      []string{"[pod/web-1/app] boom", "[pod/web-2/app] boom"},
+			wantKept:   []string{"[pod/web-1/app] boom"},
+			wantCounts: []int{2},
+		},
+		{
+			name:       "blank lines are kept",
+			lines:      []string{"", "", "a"},
+			wantKept:   []string{"", "", "a"},
+			wantCounts: []int{1, 1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kept, counts := CollapseRepeatedLines(tt.lines)
+			if !reflect.DeepEqual(kept, tt.wantKept) || !reflect.DeepEqual(counts, tt.wantCounts) {
+				t.Errorf("CollapseRepeatedLines() = %q %v, want %q %v", kept, counts, tt.wantKept, tt.wantCounts)
+			}
+		})
+	}
+}
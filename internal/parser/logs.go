@@ -4,8 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os/exec"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -21,14 +21,23 @@ const (
 	CommandTimeout      = 2 * time.Second
 )
 
-// Color palette
-var (
-	cRed    = lipgloss.Color("196") // Red
-	cYellow = lipgloss.Color("220") // Yellow
-	cGray   = lipgloss.Color("240") // Gray
+// LogColors are the colors formatted logs are rendered in
+type LogColors struct {
+	Red, Yellow, Green, Gray lipgloss.Color
+	Info, Trace, Text        lipgloss.Color   // level colors, Text for lines without one
+	Pods                     []lipgloss.Color // pod prefixes, picked by name hash
+}
 
-	// Pod color palette for log prefixes
-	podColorPalette = []lipgloss.Color{
+// Colors are the log colors in use; the app sets them from its theme
+var Colors = LogColors{
+	Red:    lipgloss.Color("196"),
+	Yellow: lipgloss.Color("220"),
+	Green:  lipgloss.Color("42"),
+	Gray:   lipgloss.Color("240"),
+	Info:   lipgloss.Color("39"),  // Cyan
+	Trace:  lipgloss.Color("238"), // Darker gray
+	Text:   lipgloss.Color("255"), // Default white
+	Pods: []lipgloss.Color{
 		lipgloss.Color("39"),  // Cyan
 		lipgloss.Color("42"),  // Green
 		lipgloss.Color("220"), // Yellow
@@ -39,13 +48,15 @@ var (
 		lipgloss.Color("82"),  // Light Green
 		lipgloss.Color("213"), // Pink
 		lipgloss.Color("228"), // Light Yellow
-	}
-)
+	},
+}
 
 // Regex patterns
 var (
-	logLevelRegex  = regexp.MustCompile(`(?i)\b(FATAL|ERROR|ERR|WARN|WARNING|INFO|DEBUG|TRACE)\b`)
+	logLevelRegex  = logLevelPattern(BuiltinLogLevels) // extended by SetLogLevels
 	podPrefixRegex = regexp.MustCompile(`^\[([^/]+)/([^/]+)/([^\]]+)\]\s*(.*)$`)
+	// RFC3339(Nano) timestamp starting a line fetched with --timestamps
+	logTimestampRegex = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:\d{2})) ?(.*)$`)
 )
 
 // LogLineInfo contains parsed information from a log line
@@ -54,9 +65,11 @@ type LogLineInfo struct {
 	PodPrefix     string // e.g., "nginx-deployment-5c7588df-abc123/nginx"
 	PodName       string
 	ContainerName string
+	Timestamp     string // RFC3339 timestamp from --timestamps, "" without
 	LogContent    string
 	LogLevel      string // ERROR, WARN, INFO, DEBUG, etc.
 	IsJSON        bool
+	IsLogfmt      bool // key=value pairs, see DetectLogfmt
 }
 
 // MultiContainerCache caches pod container information
@@ -88,8 +101,18 @@ func ParseLogLine(line string) LogLineInfo {
 		info.LogContent = matches[4]
 	}
 
-	// Detect log level
-	if levelMatches := logLevelRegex.FindStringSubmatch(info.LogContent); len(levelMatches) > 1 {
+	// Separate the leading timestamp kubectl/the API add with --timestamps
+	if matches := logTimestampRegex.FindStringSubmatch(info.LogContent); len(matches) == 3 {
+		info.Timestamp = matches[1]
+		info.LogContent = matches[2]
+	}
+
+	// Detect log level: a logfmt level= field wins over the first level word
+	pairs, isLogfmt := parseLogfmt(strings.TrimSpace(info.LogContent))
+	info.IsLogfmt = isLogfmt
+	if level := logfmtLevel(pairs); isLogfmt && level != "" {
+		info.LogLevel = strings.ToUpper(level)
+	} else if levelMatches := logLevelRegex.FindStringSubmatch(info.LogContent); len(levelMatches) > 1 {
 		info.LogLevel = strings.ToUpper(levelMatches[1])
 	}
 
@@ -105,30 +128,38 @@ func ParseLogLine(line string) LogLineInfo {
 func GetPodColor(podName string) lipgloss.Color {
 	hash := 0
 	for _, c := range podName {
-		hash = (hash*31 + int(c)) % len(podColorPalette)
+		hash = (hash*31 + int(c)) % len(Colors.Pods)
 	}
 	if hash < 0 {
 		hash = -hash
 	}
-	return podColorPalette[hash%len(podColorPalette)]
+	return Colors.Pods[hash%len(Colors.Pods)]
 }
 
 // GetLogLevelColor returns the color for a log level
 func GetLogLevelColor(level string) lipgloss.Color {
 	normalized := strings.ToUpper(strings.TrimSpace(level))
-	switch normalized {
+	if color, ok := customLogLevels[normalized]; ok {
+		return levelColor(color)
+	}
+	return builtinLogLevelColor(normalized)
+}
+
+// builtinLogLevelColor returns the color of a built-in level (upper case)
+func builtinLogLevelColor(level string) lipgloss.Color {
+	switch level {
 	case "FATAL", "ERROR", "ERR":
-		return cRed
+		return Colors.Red
 	case "WARN", "WARNING":
-		return cYellow
+		return Colors.Yellow
 	case "INFO":
-		return lipgloss.Color("39") // Cyan
+		return Colors.Info
 	case "DEBUG":
-		return cGray
+		return Colors.Gray
 	case "TRACE":
-		return lipgloss.Color("238") // Darker gray
+		return Colors.Trace
 	default:
-		return lipgloss.Color("255") // Default white
+		return Colors.Text
 	}
 }
 
@@ -154,9 +185,34 @@ func ShortenPodPrefix(podName, containerName string) string {
 	return fmt.Sprintf("[%s-%s]", replicaSetHash, podSuffix)
 }
 
+// Pod prefix styles of aggregated log lines
+const (
+	PrefixFull    = "full"     // [web-55c74d7f8-zn5fd]
+	PrefixShort   = "short"    // [55c74d7f8-zn5fd], the deployment name dropped
+	PrefixPodOnly = "pod-only" // [zn5fd]
+)
+
+// LogPrefixStyle is how aggregated log lines name their pod (set from the
+// config's logPrefixStyle)
+var LogPrefixStyle = PrefixShort
+
+// PodPrefixLabel names a pod in a log prefix in style. Names that don't follow
+// the deployment-rshash-podhash pattern (e.g. StatefulSet pods like db-0) are
+// shown whole in every style.
+func PodPrefixLabel(podName, containerName, style string) string {
+	parts := strings.Split(podName, "-")
+	switch {
+	case style == PrefixFull || len(parts) < 3:
+		return fmt.Sprintf("[%s]", podName)
+	case style == PrefixPodOnly:
+		return fmt.Sprintf("[%s]", parts[len(parts)-1])
+	}
+	return ShortenPodPrefix(podName, containerName)
+}
+
 // FormatPodPrefix formats pod prefix with color and icon
 func FormatPodPrefix(podName, containerName string) string {
-	shortened := ShortenPodPrefix(podName, containerName)
+	shortened := PodPrefixLabel(podName, containerName, LogPrefixStyle)
 	color := GetPodColor(podName)
 	icon := "●"
 
@@ -218,9 +274,190 @@ func PrettyPrintJSONLog(line string) string {
 	return string(pretty)
 }
 
+// jsonLevelKeys are the flattened keys that hold a structured log's level
+var jsonLevelKeys = map[string]bool{"level": true, "lvl": true, "severity": true, "log.level": true}
+
+// FlattenJSONLog renders a JSON log as a single line of dotted-path pairs
+// (user.id=42 req.method=GET) in the original key order, with the level
+// value colored. Lines that aren't valid JSON are returned unchanged.
+func FlattenJSONLog(line string) string {
+	dec := json.NewDecoder(strings.NewReader(line))
+	dec.UseNumber()
+	var pairs [][2]string
+	if err := flattenJSONValue(dec, "", &pairs); err != nil || dec.More() {
+		return line
+	}
+
+	keyStyle := lipgloss.NewStyle().Foreground(Colors.Gray)
+	parts := make([]string, 0, len(pairs))
+	for _, kv := range pairs {
+		key, value := kv[0], kv[1]
+		if jsonLevelKeys[strings.ToLower(key)] {
+			value = lipgloss.NewStyle().Foreground(GetLogLevelColor(value)).Bold(true).Render(value)
+		}
+		if key == "" {
+			parts = append(parts, value)
+			continue
+		}
+		parts = append(parts, keyStyle.Render(key+"=")+value)
+	}
+	return strings.Join(parts, " ")
+}
+
+// flattenJSONValue reads one value from dec, appending a key/value pair for
+// every scalar under prefix (objects add ".key", arrays add ".index")
+func flattenJSONValue(dec *json.Decoder, prefix string, pairs *[][2]string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	join := func(key string) string {
+		if prefix == "" {
+			return key
+		}
+		return prefix + "." + key
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				if err := flattenJSONValue(dec, join(fmt.Sprint(keyTok)), pairs); err != nil {
+					return err
+				}
+			}
+		case '[':
+			for i := 0; dec.More(); i++ {
+				if err := flattenJSONValue(dec, join(fmt.Sprint(i)), pairs); err != nil {
+					return err
+				}
+			}
+		}
+		// Consume the closing delimiter
+		_, err := dec.Token()
+		return err
+	case string:
+		value := t
+		if t == "" || strings.ContainsAny(t, " \t\n\"=") {
+			value = fmt.Sprintf("%q", t)
+		}
+		*pairs = append(*pairs, [2]string{prefix, value})
+	case nil:
+		*pairs = append(*pairs, [2]string{prefix, "null"})
+	default:
+		*pairs = append(*pairs, [2]string{prefix, fmt.Sprint(t)})
+	}
+	return nil
+}
+
+// logfmtPair is one key=value of a logfmt line; raw keeps the value as
+// written (quotes included), value is unquoted
+type logfmtPair struct {
+	key, raw, value string
+}
+
+// parseLogfmt splits a logfmt line (level=error msg="boom" ts=...) into its
+// pairs. It fails on anything else, including free text mixed with pairs, so
+// plain log lines that happen to contain an "=" aren't taken for logfmt.
+func parseLogfmt(line string) ([]logfmtPair, bool) {
+	var pairs []logfmtPair
+	i := 0
+	for {
+		for i < len(line) && (line[i] == ' ' || line[i] == '\t') {
+			i++
+		}
+		if i == len(line) {
+			break
+		}
+
+		start := i
+		for i < len(line) && line[i] != '=' && line[i] != ' ' && line[i] != '"' {
+			i++
+		}
+		if i == start || i == len(line) || line[i] != '=' {
+			return nil, false
+		}
+		key := line[start:i]
+		i++ // '='
+
+		start = i
+		value := ""
+		if i < len(line) && line[i] == '"' {
+			for i++; i < len(line) && line[i] != '"'; i++ {
+				if line[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(line) {
+				return nil, false // unterminated quote
+			}
+			i++
+			unquoted, err := strconv.Unquote(line[start:i])
+			if err != nil {
+				return nil, false
+			}
+			value = unquoted
+		} else {
+			for i < len(line) && line[i] != ' ' && line[i] != '\t' {
+				i++
+			}
+			value = line[start:i]
+		}
+		pairs = append(pairs, logfmtPair{key: key, raw: line[start:i], value: value})
+	}
+	// A single pair is more likely prose ("retrying, attempt=3") than logfmt
+	return pairs, len(pairs) >= 2
+}
+
+// DetectLogfmt checks if a line is logfmt (at least two key=value pairs and nothing else)
+func DetectLogfmt(line string) bool {
+	_, ok := parseLogfmt(strings.TrimSpace(line))
+	return ok
+}
+
+// logfmtLevel returns the value of a logfmt line's level key, "" without one
+func logfmtLevel(pairs []logfmtPair) string {
+	for _, p := range pairs {
+		if jsonLevelKeys[strings.ToLower(p.key)] {
+			return p.value
+		}
+	}
+	return ""
+}
+
+// FormatLogfmtLog renders a logfmt line with dimmed keys and the level value
+// colored and padded to five columns, so the pairs after it line up from one
+// line to the next. Lines that aren't logfmt are returned unchanged.
+func FormatLogfmtLog(line string) string {
+	pairs, ok := parseLogfmt(strings.TrimSpace(line))
+	if !ok {
+		return line
+	}
+
+	keyStyle := lipgloss.NewStyle().Foreground(Colors.Gray)
+	parts := make([]string, 0, len(pairs))
+	for _, p := range pairs {
+		value := p.raw
+		if jsonLevelKeys[strings.ToLower(p.key)] {
+			value = lipgloss.NewStyle().Foreground(GetLogLevelColor(p.value)).Bold(true).Render(fmt.Sprintf("%-5s", p.raw))
+		}
+		parts = append(parts, keyStyle.Render(p.key+"=")+value)
+	}
+	return strings.Join(parts, " ")
+}
+
+// ContainerLister returns the container names of a pod (k8s.Client satisfies it)
+type ContainerLister interface {
+	GetPodContainers(ctx context.Context, namespace, podName string) ([]string, error)
+}
+
 // DetectMultiContainer checks if a pod has multiple containers (with caching)
-// Note: This function requires kubectl and cluster context
-func DetectMultiContainer(podName, namespace, kubeContext string, cache *MultiContainerCache) (bool, error) {
+func DetectMultiContainer(client ContainerLister, podName, namespace string, cache *MultiContainerCache) (bool, error) {
 	// Check cache first
 	cache.mu.RLock()
 	if result, exists := cache.cache[podName]; exists {
@@ -229,20 +466,15 @@ func DetectMultiContainer(podName, namespace, kubeContext string, cache *MultiCo
 	}
 	cache.mu.RUnlock()
 
-	// Query kubectl
+	// Query via client
 	ctx, cancel := context.WithTimeout(context.Background(), CommandTimeout)
 	defer cancel()
 
-	cmd := exec.CommandContext(ctx, "kubectl", "get", "pod", podName,
-		"-n", namespace, "--context", kubeContext,
-		"-o", "jsonpath={.spec.containers[*].name}")
-
-	out, err := cmd.CombinedOutput()
+	containerNames, err := client.GetPodContainers(ctx, namespace, podName)
 	if err != nil {
 		return false, err
 	}
 
-	containerNames := strings.Fields(string(out))
 	isMulti := len(containerNames) > 1
 
 	// Cache result
@@ -255,15 +487,32 @@ func DetectMultiContainer(podName, namespace, kubeContext string, cache *MultiCo
 
 // ProcessLogContent is the master log processing function
 // highlightFunc should be a function that applies syntax highlighting (e.g., from syntax package)
-func ProcessLogContent(content, resourceType, resourceName string, formatMode bool, highlightFunc func(string, string) string) string {
-	if !formatMode {
+// jsonMode picks how JSON logs render: pretty-printed, flattened or in columns
+// collapse shows runs of identical lines once with an " (xN)" count
+func ProcessLogContent(content, resourceType, resourceName string, formatMode bool, jsonMode JSONLogMode, collapse bool, highlightFunc func(string, string) string) string {
+	if !formatMode && !collapse {
 		return content // Raw mode - return unchanged
 	}
 
 	lines := strings.Split(content, "\n")
+	var counts []int
+	if collapse {
+		lines, counts = CollapseRepeatedLines(lines)
+	}
+	if !formatMode {
+		for i := range lines {
+			lines[i] += RepeatSuffix(counts[i])
+		}
+		return strings.Join(lines, "\n")
+	}
 	processed := make([]string, 0, len(lines))
 
-	for _, line := range lines {
+	var widths []int
+	if jsonMode == JSONColumns || jsonMode == JSONColumnsExpanded {
+		widths = JSONColumnWidths(lines)
+	}
+
+	for i, line := range lines {
 		if strings.TrimSpace(line) == "" {
 			processed = append(processed, line)
 			continue
@@ -272,36 +521,43 @@ func ProcessLogContent(content, resourceType, resourceName string, formatMode bo
 		// Parse line structure
 		info := ParseLogLine(line)
 
+		// Pod prefix and timestamp lead the (re)formatted content
+		var lead string
+		if info.PodPrefix != "" {
+			lead = FormatPodPrefix(info.PodName, info.ContainerName) + " "
+		}
+		if info.Timestamp != "" {
+			lead += lipgloss.NewStyle().Foreground(Colors.Gray).Render(info.Timestamp) + " "
+		}
+
 		// Check if JSON
 		if DetectJSONLog(info.LogContent) {
 			// Format as JSON
-			formatted := PrettyPrintJSONLog(info.LogContent)
-
-			// Apply syntax highlighting if function provided
-			if highlightFunc != nil {
-				formatted = highlightFunc(formatted, "json")
-			}
-
-			if info.PodPrefix != "" {
-				prefix := FormatPodPrefix(info.PodName, info.ContainerName)
-				processed = append(processed, prefix+" "+formatted)
-			} else {
-				processed = append(processed, formatted)
+			var formatted string
+			switch jsonMode {
+			case JSONFlat:
+				formatted = FlattenJSONLog(info.LogContent)
+			case JSONColumns, JSONColumnsExpanded:
+				formatted = FormatJSONColumns(info.LogContent, widths, jsonMode == JSONColumnsExpanded)
+			default:
+				formatted = PrettyPrintJSONLog(info.LogContent)
+
+				// Apply syntax highlighting if function provided
+				if highlightFunc != nil {
+					formatted = highlightFunc(formatted, "json")
+				}
 			}
-		} else {
+			processed = append(processed, lead+formatted)
+		} else if info.IsLogfmt {
+			processed = append(processed, lead+FormatLogfmtLog(info.LogContent))
+		} else if lead != "" {
 			// Standard text log with level coloring
-			formattedLine := line
-
-			// Add pod prefix formatting if present
-			if info.PodPrefix != "" {
-				prefix := FormatPodPrefix(info.PodName, info.ContainerName)
-				colorizedContent := ColorizeLogLevel(info.LogContent)
-				formattedLine = prefix + " " + colorizedContent
-			} else {
-				formattedLine = ColorizeLogLevel(line)
-			}
-
-			processed = append(processed, formattedLine)
+			processed = append(processed, lead+ColorizeLogLevel(info.LogContent))
+		} else {
+			processed = append(processed, ColorizeLogLevel(line))
+		}
+		if counts != nil && counts[i] > 1 {
+			processed[len(processed)-1] += lipgloss.NewStyle().Foreground(Colors.Gray).Render(RepeatSuffix(counts[i]))
 		}
 	}
 
@@ -1,8 +1,11 @@
 package parser
 
 import (
+	"context"
 	"strings"
 	"testing"
+
+	"github.com/charmbracelet/x/ansi"
 )
 
 func TestParseLogLine(t *testing.T) {
@@ -89,6 +92,31 @@ func TestParseLogLine(t *testing.T) {
 				IsJSON:        false,
 			},
 		},
+		{
+			name:  "log line with pod prefix and timestamp",
+			input: "[pod/app-xyz789/app] 2024-05-01T12:00:00.123456789Z ERROR: disk full",
+			wantInfo: LogLineInfo{
+				OriginalLine:  "[pod/app-xyz789/app] 2024-05-01T12:00:00.123456789Z ERROR: disk full",
+				PodPrefix:     "app-xyz789/app",
+				PodName:       "app-xyz789",
+				ContainerName: "app",
+				Timestamp:     "2024-05-01T12:00:00.123456789Z",
+				LogContent:    "ERROR: disk full",
+				LogLevel:      "ERROR",
+				IsJSON:        false,
+			},
+		},
+		{
+			name:  "json log line with timestamp",
+			input: `2024-05-01T12:00:00+02:00 {"level":"info"}`,
+			wantInfo: LogLineInfo{
+				OriginalLine: `2024-05-01T12:00:00+02:00 {"level":"info"}`,
+				Timestamp:    "2024-05-01T12:00:00+02:00",
+				LogContent:   `{"level":"info"}`,
+				LogLevel:     "INFO",
+				IsJSON:       true,
+			},
+		},
 		{
 			name:  "log line with FATAL level",
 			input: "FATAL: Application crashed",
@@ -120,6 +148,9 @@ func TestParseLogLine(t *testing.T) {
 			if got.ContainerName != tt.wantInfo.ContainerName {
 				t.Errorf("ContainerName = %q, want %q", got.ContainerName, tt.wantInfo.ContainerName)
 			}
+			if got.Timestamp != tt.wantInfo.Timestamp {
+				t.Errorf("Timestamp = %q, want %q", got.Timestamp, tt.wantInfo.Timestamp)
+			}
 			if got.LogContent != tt.wantInfo.LogContent {
 				t.Errorf("LogContent = %q, want %q", got.LogContent, tt.wantInfo.LogContent)
 			}
@@ -157,6 +188,34 @@ func TestDetectJSONLog(t *testing.T) {
 	}
 }
 
+func TestPodPrefixLabel(t *testing.T) {
+	tests := []struct {
+		name    string
+		podName string
+		style   string
+		want    string
+	}{
+		{"full", "web-55c74d7f8-zn5fd", PrefixFull, "[web-55c74d7f8-zn5fd]"},
+		{"short", "web-55c74d7f8-zn5fd", PrefixShort, "[55c74d7f8-zn5fd]"},
+		{"pod-only", "web-55c74d7f8-zn5fd", PrefixPodOnly, "[zn5fd]"},
+		{"unknown style is short", "web-55c74d7f8-zn5fd", "", "[55c74d7f8-zn5fd]"},
+		// Names without the deployment-rshash-podhash pattern are kept whole
+		{"statefulset pod short", "db-0", PrefixShort, "[db-0]"},
+		{"statefulset pod pod-only", "db-0", PrefixPodOnly, "[db-0]"},
+		{"single part", "standalone", PrefixPodOnly, "[standalone]"},
+		{"empty", "", PrefixShort, "[]"},
+		{"empty parts", "a--b", PrefixShort, "[-b]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PodPrefixLabel(tt.podName, "app", tt.style); got != tt.want {
+				t.Errorf("PodPrefixLabel(%q, %q) = %q, want %q", tt.podName, tt.style, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestShortenPodPrefix(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -241,6 +300,174 @@ func TestPrettyPrintJSONLog(t *testing.T) {
 	}
 }
 
+func TestFlattenJSONLog(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "nested objects use dotted paths in original order",
+			input: `{"level":"info","user":{"id":42},"req":{"method":"GET"}}`,
+			want:  `level=info user.id=42 req.method=GET`,
+		},
+		{
+			name:  "arrays are indexed",
+			input: `{"tags":["a","b"]}`,
+			want:  `tags.0=a tags.1=b`,
+		},
+		{
+			name:  "strings with spaces are quoted",
+			input: `{"msg":"request failed","ok":false,"err":null}`,
+			want:  `msg="request failed" ok=false err=null`,
+		},
+		{
+			name:  "invalid json",
+			input: `{invalid`,
+			want:  `{invalid`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FlattenJSONLog(tt.input)
+			if got != tt.want {
+				t.Errorf("FlattenJSONLog() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectLogfmt(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{"simple pairs", `level=info msg=started port=8080`, true},
+		{"quoted value with spaces", `level=error msg="connection refused" retry=3`, true},
+		{"escaped quote in value", `msg="say \"hi\"" level=debug`, true},
+		{"empty quoted value", `msg="" level=info`, true},
+		{"surrounding whitespace", `  ts=2024-05-01T12:00:00Z level=warn  `, true},
+		{"single pair", `attempt=3`, false},
+		{"free text mixed with pairs", `Starting server port=8080 tls=false`, false},
+		{"pairs followed by free text", `level=info msg=ok done`, false},
+		{"unterminated quote", `level=info msg="oops`, false},
+		{"json", `{"level":"info","msg":"a=b"}`, false},
+		{"plain text", "ERROR: Something went wrong", false},
+		{"empty string", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DetectLogfmt(tt.input)
+			if got != tt.want {
+				t.Errorf("DetectLogfmt(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLogfmt(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []logfmtPair
+	}{
+		{
+			name:  "bare values",
+			input: `level=info port=8080`,
+			want:  []logfmtPair{{"level", "info", "info"}, {"port", "8080", "8080"}},
+		},
+		{
+			name:  "quoted value with embedded spaces",
+			input: `msg="request failed: timeout" level=error`,
+			want:  []logfmtPair{{"msg", `"request failed: timeout"`, "request failed: timeout"}, {"level", "error", "error"}},
+		},
+		{
+			name:  "escapes are unquoted",
+			input: `msg="a \"b\" c" path=/x=y`,
+			want:  []logfmtPair{{"msg", `"a \"b\" c"`, `a "b" c`}, {"path", "/x=y", "/x=y"}},
+		},
+		{
+			name:  "empty values",
+			input: `err= msg=""`,
+			want:  []logfmtPair{{"err", "", ""}, {"msg", `""`, ""}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseLogfmt(tt.input)
+			if !ok {
+				t.Fatalf("parseLogfmt(%q) failed", tt.input)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseLogfmt(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("pair %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseLogLineLogfmt(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantLevel  string
+		wantLogfmt bool
+	}{
+		{"level field", `ts=2024-05-01T12:00:00Z level=warn msg="disk almost full"`, "WARN", true},
+		{"level field beats level words in the message", `level=info msg="retrying after ERROR"`, "INFO", true},
+		{"lvl alias", `lvl=debug msg=tick`, "DEBUG", true},
+		{"no level field falls back to the regex", `msg="ERROR in handler" code=500`, "ERROR", true},
+		{"with pod prefix", `[pod/app-abc123-xyz/app] level=error msg=boom`, "ERROR", true},
+		{"mixed line is plain text", `INFO listening on addr=:8080`, "INFO", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseLogLine(tt.input)
+			if got.LogLevel != tt.wantLevel {
+				t.Errorf("LogLevel = %q, want %q", got.LogLevel, tt.wantLevel)
+			}
+			if got.IsLogfmt != tt.wantLogfmt {
+				t.Errorf("IsLogfmt = %v, want %v", got.IsLogfmt, tt.wantLogfmt)
+			}
+		})
+	}
+}
+
+func TestFormatLogfmtLog(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string // in order, ANSI stripped
+	}{
+		{"keys and quoted values are kept", `level=info msg="server started" port=8080`, []string{"level=info ", `msg="server started"`, "port=8080"}},
+		{"level padded so the pairs align", `level=warn msg=x`, []string{"level=warn  msg=x"}},
+		{"not logfmt is unchanged", `plain text line`, []string{"plain text line"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ansi.Strip(FormatLogfmtLog(tt.input))
+			rest := got
+			for _, part := range tt.want {
+				idx := strings.Index(rest, part)
+				if idx < 0 {
+					t.Fatalf("FormatLogfmtLog(%q) = %q, want %q in order", tt.input, got, part)
+				}
+				rest = rest[idx+len(part):]
+			}
+		})
+	}
+}
+
 func TestProcessLogContent(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -248,6 +475,8 @@ func TestProcessLogContent(t *testing.T) {
 		resourceType string
 		resourceName string
 		formatMode   bool
+		jsonMode     JSONLogMode
+		collapse     bool
 		wantContains []string
 	}{
 		{
@@ -274,11 +503,46 @@ func TestProcessLogContent(t *testing.T) {
 			formatMode:   true,
 			wantContains: []string{},
 		},
+		{
+			name:         "flat json stays on one line",
+			content:      `{"level":"error","req":{"path":"/"}}`,
+			resourceType: "POD",
+			resourceName: "test-pod",
+			formatMode:   true,
+			jsonMode:     JSONFlat,
+			wantContains: []string{"level=error req.path=/"},
+		},
+		{
+			name:         "json columns line up",
+			content:      "{\"level\":\"info\",\"msg\":\"started\",\"port\":80}\n{\"level\":\"error\",\"msg\":\"boom\"}",
+			resourceType: "POD",
+			resourceName: "test-pod",
+			formatMode:   true,
+			jsonMode:     JSONColumns,
+			wantContains: []string{"info  started +1 field", "error boom"},
+		},
+		{
+			name:         "repeated lines collapse",
+			content:      "[pod/web-1/app] retrying\n[pod/web-2/app] retrying\n[pod/web-1/app] ok",
+			resourceType: "DEP",
+			resourceName: "web",
+			formatMode:   true,
+			collapse:     true,
+			wantContains: []string{"retrying (x2)", "ok"},
+		},
+		{
+			name:         "repeated lines collapse in raw mode",
+			content:      "a\na\na\nb\na",
+			resourceType: "POD",
+			resourceName: "test-pod",
+			collapse:     true,
+			wantContains: []string{"a (x3)\nb\na"},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := ProcessLogContent(tt.content, tt.resourceType, tt.resourceName, tt.formatMode, nil)
+			got := ProcessLogContent(tt.content, tt.resourceType, tt.resourceName, tt.formatMode, tt.jsonMode, tt.collapse, nil)
 
 			for _, want := range tt.wantContains {
 				if !strings.Contains(got, want) {
@@ -305,7 +569,7 @@ func TestGetPodColor(t *testing.T) {
 
 	// Verify it's from the palette
 	found := false
-	for _, c := range podColorPalette {
+	for _, c := range Colors.Pods {
 		if c == color3 {
 			found = true
 			break
@@ -321,12 +585,12 @@ func TestGetLogLevelColor(t *testing.T) {
 		level string
 		want  string // We'll just check it returns a non-empty color
 	}{
-		{"ERROR", string(cRed)},
-		{"error", string(cRed)}, // Case insensitive
-		{"WARN", string(cYellow)},
-		{"WARNING", string(cYellow)},
+		{"ERROR", string(Colors.Red)},
+		{"error", string(Colors.Red)}, // Case insensitive
+		{"WARN", string(Colors.Yellow)},
+		{"WARNING", string(Colors.Yellow)},
 		{"INFO", "39"},
-		{"DEBUG", string(cGray)},
+		{"DEBUG", string(Colors.Gray)},
 		{"UNKNOWN", "255"},
 	}
 
@@ -339,3 +603,31 @@ func TestGetLogLevelColor(t *testing.T) {
 		})
 	}
 }
+
+type fakeContainerLister struct {
+	containers []string
+	calls      int
+}
+
+func (f *fakeContainerLister) GetPodContainers(ctx context.Context, namespace, podName string) ([]string, error) {
+	f.calls++
+	return f.containers, nil
+}
+
+func TestDetectMultiContainer(t *testing.T) {
+	lister := &fakeContainerLister{containers: []string{"app", "sidecar"}}
+	cache := NewMultiContainerCache()
+
+	for i := 0; i < 2; i++ {
+		isMulti, err := DetectMultiContainer(lister, "web-abc123", "default", cache)
+		if err != nil {
+			t.Fatalf("DetectMultiContainer() error = %v", err)
+		}
+		if !isMulti {
+			t.Error("DetectMultiContainer() = false, want true")
+		}
+	}
+	if lister.calls != 1 {
+		t.Errorf("GetPodContainers called %d times, want 1 (cached)", lister.calls)
+	}
+}
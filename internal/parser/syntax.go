@@ -2,21 +2,63 @@ package parser
 
 import (
 	"bytes"
+	"fmt"
+	"strings"
 
+	"github.com/alecthomas/chroma/v2/formatters"
 	"github.com/alecthomas/chroma/v2/quick"
 	"github.com/alecthomas/chroma/v2/styles"
 )
 
-func init() {
-	// Initialize chroma styles
-	_ = styles.Get("dracula")
+// Environment variables selecting the Chroma style and terminal formatter,
+// read by the app along with its config
+const (
+	EnvSyntaxStyle     = "K9S_DECK_SYNTAX_STYLE"
+	EnvSyntaxFormatter = "K9S_DECK_SYNTAX_FORMATTER"
+)
+
+// Defaults used when nothing (valid) is configured
+const (
+	DefaultSyntaxStyle     = "dracula"
+	DefaultSyntaxFormatter = "terminal256"
+)
+
+// SyntaxFormatters are the terminal formatters that can be selected:
+// 8 and 16 colors, the 256-color palette and truecolor (terminal16m)
+var SyntaxFormatters = []string{"terminal", "terminal8", "terminal16", "terminal256", "terminal16m"}
+
+// ResolveSyntaxStyle returns the registered Chroma style matching name
+// (case-insensitively), e.g. "monokai" or "solarized-light"
+func ResolveSyntaxStyle(name string) (string, error) {
+	for _, style := range styles.Names() {
+		if strings.EqualFold(style, name) {
+			return style, nil
+		}
+	}
+	return "", fmt.Errorf("unknown syntax style %q (not a Chroma style)", name)
+}
+
+// ResolveSyntaxFormatter validates a terminal formatter name
+func ResolveSyntaxFormatter(name string) (string, error) {
+	name = strings.ToLower(name)
+	for _, formatter := range SyntaxFormatters {
+		if formatter == name && formatters.Get(name) != nil {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("unknown syntax formatter %q (available: %s)", name, strings.Join(SyntaxFormatters, ", "))
 }
 
 // Highlight applies syntax highlighting to content using chroma
 // format can be "json", "yaml", etc.
 func Highlight(content, format string) string {
+	return HighlightWith(content, format, DefaultSyntaxStyle, DefaultSyntaxFormatter)
+}
+
+// HighlightWith highlights content with the given Chroma style and formatter
+func HighlightWith(content, format, style, formatter string) string {
 	var buf bytes.Buffer
-	err := quick.Highlight(&buf, content, format, "terminal256", "dracula")
+	err := quick.Highlight(&buf, content, format, formatter, style)
 	if err != nil {
 		return content
 	}
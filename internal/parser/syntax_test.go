@@ -0,0 +1,56 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveSyntaxStyle(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "dracula", want: "dracula"},
+		{name: "Monokai", want: "monokai"},
+		{name: "solarized-light", want: "solarized-light"},
+		{name: "no-such-style", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ResolveSyntaxStyle(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ResolveSyntaxStyle(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ResolveSyntaxStyle(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestResolveSyntaxFormatter(t *testing.T) {
+	for _, name := range []string{"terminal", "terminal16", "terminal256", "Terminal16m"} {
+		if _, err := ResolveSyntaxFormatter(name); err != nil {
+			t.Errorf("ResolveSyntaxFormatter(%q) unexpected error: %v", name, err)
+		}
+	}
+	for _, name := range []string{"html", "json", "terminal512"} {
+		if _, err := ResolveSyntaxFormatter(name); err == nil {
+			t.Errorf("ResolveSyntaxFormatter(%q) expected an error", name)
+		}
+	}
+}
+
+func TestHighlightWith(t *testing.T) {
+	truecolor := HighlightWith(`{"a": 1}`, "json", "dracula", "terminal16m")
+	if !strings.Contains(truecolor, "38;2;") {
+		t.Errorf("Expected 24-bit color escapes from terminal16m, got %q", truecolor)
+	}
+	palette := HighlightWith(`{"a": 1}`, "json", "dracula", "terminal256")
+	if !strings.Contains(palette, "38;5;") {
+		t.Errorf("Expected 256-color escapes from terminal256, got %q", palette)
+	}
+	if got := HighlightWith("a: 1", "yaml", "dracula", "no-such-formatter"); got != "a: 1" {
+		t.Errorf("Expected content unchanged on error, got %q", got)
+	}
+}
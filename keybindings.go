@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// --- KEYBINDINGS ---
+
+// KeyBindings are the normal mode shortcuts that can be remapped in the
+// config's keybindings section. Restart is pressed twice (rr by default).
+type KeyBindings struct {
+	Scale        string
+	Rollback     string
+	Restart      string
+	AddTarget    string
+	RemoveTarget string
+	ToggleFormat string
+	Filter       string
+	Yank         string
+}
+
+// Keys are the active bindings, the defaults unless the config remaps them
+var Keys = defaultKeyBindings()
+
+func defaultKeyBindings() KeyBindings {
+	return KeyBindings{
+		Scale:        "s",
+		Rollback:     "R",
+		Restart:      "r",
+		AddTarget:    "+",
+		RemoveTarget: "-",
+		ToggleFormat: "f",
+		Filter:       "/",
+		Yank:         "y",
+	}
+}
+
+// binding returns the key of a config action name ("scale", "addTarget", ...)
+func (k *KeyBindings) binding(action string) (*string, bool) {
+	switch action {
+	case "scale":
+		return &k.Scale, true
+	case "rollback":
+		return &k.Rollback, true
+	case "restart":
+		return &k.Restart, true
+	case "addTarget":
+		return &k.AddTarget, true
+	case "removeTarget":
+		return &k.RemoveTarget, true
+	case "toggleFormat":
+		return &k.ToggleFormat, true
+	case "filter":
+		return &k.Filter, true
+	case "yank":
+		return &k.Yank, true
+	}
+	return nil, false
+}
+
+// keyActions are the action names accepted in the config, in display order
+var keyActions = []string{"scale", "rollback", "restart", "addTarget", "removeTarget", "toggleFormat", "filter", "yank"}
+
+// fixedKeys are handled by normal mode (or the details viewport) and can't
+// be taken by a remapped action
+var fixedKeys = []string{
+	"q", "ctrl+c", "?", ":", "esc", "enter", "tab",
+	"up", "down", "k", "j", "left", "right", "h", "l",
+	"ctrl+d", "ctrl+u", "ctrl+e", "ctrl+y", "pgdown", "pgup", " ", "b", "u",
+	"1", "2", "3", "4", "5", "6", "D", "P",
+	"c", "d", "e", "t", "w", "x", "F", "J", "L", "S", "o", "p", "n", "N", "C", "Y", "V", "z", "#",
+	"ctrl+f", "ctrl+k", "ctrl+_", "\\", "<", ">", "|", "E", "U",
+}
+
+// parseKeyBindings applies the config's action -> key overrides to the
+// defaults. A remapped key must not clash with another action or a fixed key.
+func parseKeyBindings(overrides map[string]string) (KeyBindings, error) {
+	keys := defaultKeyBindings()
+
+	// Sorted so the first problem reported doesn't depend on map order
+	actions := make([]string, 0, len(overrides))
+	for action := range overrides {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	for _, action := range actions {
+		key := overrides[action]
+		field, ok := keys.binding(action)
+		if !ok {
+			return defaultKeyBindings(), fmt.Errorf("keybindings: unknown action %q (available: %s)", action, strings.Join(keyActions, ", "))
+		}
+		if key == "" {
+			return defaultKeyBindings(), fmt.Errorf("keybindings: %s needs a key", action)
+		}
+		*field = key
+	}
+
+	boundTo := make(map[string]string)
+	for _, action := range keyActions {
+		field, _ := keys.binding(action)
+		if containsString(fixedKeys, *field) {
+			return defaultKeyBindings(), fmt.Errorf("keybindings: %s: %q is already a built-in shortcut", action, *field)
+		}
+		if other, taken := boundTo[*field]; taken {
+			return defaultKeyBindings(), fmt.Errorf("keybindings: %s and %s are both bound to %q", other, action, *field)
+		}
+		boundTo[*field] = action
+	}
+	return keys, nil
+}
+
+// keyLabel renders an action's key for the footer and help overlay
+func keyLabel(action string) string {
+	field, ok := Keys.binding(action)
+	if !ok {
+		return ""
+	}
+	if action == "restart" {
+		return *field + *field
+	}
+	return *field
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/devpopsdotin/k9s-deck/internal/k8s"
+)
+
+// --- CONTEXT SWITCHING ---
+
+// contextSwitchMsg carries a client built for another kubeconfig context
+type contextSwitchMsg struct {
+	context     string
+	client      k8s.Client
+	deployments []string // deployments of the current namespace in the new context
+	listErr     error
+	err         error // building the client failed, the old one stays
+}
+
+// switchContextCmd builds a client for name and lists ns's deployments with
+// it; the switch itself happens in Update
+func switchContextCmd(name, ns string) tea.Cmd {
+	return func() tea.Msg {
+		newClient, err := k8s.NewClientWithOptions(name, clientOpts)
+		if err != nil {
+			return contextSwitchMsg{context: name, err: err}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), CommandTimeout)
+		defer cancel()
+
+		deployments, err := newClient.ListDeployments(ctx, ns)
+		return contextSwitchMsg{context: name, client: newClient, deployments: deployments, listErr: err}
+	}
+}
+
+// switchContext swaps the global client for the one in msg. Targets carry
+// over like with :ns; if the namespace has no deployments there they are
+// kept as they are so the sidebar shows what is missing.
+func (m *model) switchContext(msg contextSwitchMsg) tea.Cmd {
+	if msg.err != nil {
+		m.rawContent = fmt.Sprintf("Cannot switch to context '%s', staying on '%s': %v", msg.context, Context, msg.err)
+		m.updateViewportContent()
+		return nil
+	}
+
+	Context = msg.context
+	client = msg.client
+	if len(msg.deployments) > 0 {
+		m.setTargets(carryOverTargets(m.targets, msg.deployments))
+	}
+	m.resetClusterState()
+
+	switch {
+	case msg.listErr != nil:
+		m.statusMsg = fmt.Sprintf("Switched to context %s (listing deployments failed: %v)", Context, msg.listErr)
+	case len(msg.deployments) == 0:
+		m.statusMsg = fmt.Sprintf("Switched to context %s (no deployments in %s, use :ns)", Context, Namespace)
+	default:
+		m.statusMsg = "Switched to context " + Context
+	}
+	return tea.Batch(m.refreshCmd(), m.restartMetrics(), clearStatusLater(), m.scheduleConfigSave())
+}
+
+// contextSuggestions lists the kubeconfig contexts for the context prompt
+func contextSuggestions() []string {
+	contexts, err := k8s.ListContexts()
+	if err != nil {
+		return []string{}
+	}
+	return contexts
+}
@@ -0,0 +1,71 @@
+package main
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// --- LAYOUT ---
+
+const (
+	// StackedLayoutWidth is the terminal width below which the list is stacked
+	// above the details instead of beside them, unless '|' chose a layout
+	StackedLayoutWidth = 80
+	// MinStackedListRows is the fewest resource rows the stacked list shows
+	MinStackedListRows = 3
+)
+
+// Layouts chosen with '|'; "" picks one from the width
+const (
+	layoutSideBySide = "side"
+	layoutStacked    = "stacked"
+)
+
+// stacked reports whether the list is drawn above the details, full width
+func (m model) stacked() bool {
+	if m.layout == "" {
+		return m.width < StackedLayoutWidth
+	}
+	return m.layout == layoutStacked
+}
+
+// listPaneHeight is the height of the stacked list pane: its header lines and
+// a third of the screen's remaining rows for the resources
+func (m model) listPaneHeight() int {
+	return HeaderHeight + maxInt((m.height-FooterHeight)/3-HeaderHeight, MinStackedListRows)
+}
+
+// layoutPanes sizes the list and the details viewport for the current
+// layout and terminal size
+func (m *model) layoutPanes() {
+	if m.stacked() {
+		m.listHeight = m.listPaneHeight() - HeaderHeight
+		m.viewport.Width = maxInt(m.width-4, 0)
+	} else {
+		m.listHeight = maxInt(m.height-HeaderHeight-FooterHeight-UILayoutPadding, 1)
+		m.viewport.Width = maxInt(m.width-m.leftPaneWidth()-4, 0)
+	}
+	m.viewport.Height = m.viewportHeight()
+	m.scrollToCursor()
+}
+
+// detailsOrigin is the screen position of the details pane's top left
+// corner (its tab bar)
+func (m model) detailsOrigin() (x, y int) {
+	if m.stacked() {
+		return 0, m.listPaneHeight()
+	}
+	return m.leftPaneWidth(), 0
+}
+
+// toggleLayout switches between the side-by-side and stacked layouts,
+// overriding the choice made from the terminal width
+func (m *model) toggleLayout() tea.Cmd {
+	if m.stacked() {
+		m.layout = layoutSideBySide
+		m.statusMsg = "Layout: side by side"
+	} else {
+		m.layout = layoutStacked
+		m.statusMsg = "Layout: stacked"
+	}
+	m.layoutPanes()
+	m.updateViewportContent()
+	return clearStatusLater()
+}
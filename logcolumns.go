@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/devpopsdotin/k9s-deck/internal/parser"
+)
+
+// --- JSON LOG COLUMNS ---
+
+// parseLogFields parses a comma-separated field list like "level,msg,ts"
+// (config: logFields, which sets parser.LogFields)
+func parseLogFields(s string) ([]string, error) {
+	var fields []string
+	for _, f := range strings.Split(s, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			return nil, fmt.Errorf("empty field in %q", s)
+		}
+		if containsString(fields, f) {
+			return nil, fmt.Errorf("field %q listed twice", f)
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+// nextJSONLogMode cycles pretty -> flat -> columns -> pretty ('J')
+func nextJSONLogMode(mode parser.JSONLogMode) parser.JSONLogMode {
+	switch mode {
+	case parser.JSONPretty:
+		return parser.JSONFlat
+	case parser.JSONFlat:
+		return parser.JSONColumns
+	}
+	return parser.JSONPretty
+}
+
+// toggleJSONColumnsExpanded shows or collapses the fields behind the
+// "+N fields" markers ('E'), false outside the columns mode
+func (m *model) toggleJSONColumnsExpanded() bool {
+	switch m.jsonMode {
+	case parser.JSONColumns:
+		m.jsonMode = parser.JSONColumnsExpanded
+	case parser.JSONColumnsExpanded:
+		m.jsonMode = parser.JSONColumns
+	default:
+		m.statusMsg = "E expands JSON log columns, press J until they show"
+		return false
+	}
+	return true
+}
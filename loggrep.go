@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- LOG GREP ---
+
+// logGrep drops log lines as they arrive (:grep), before they are formatted
+// or buffered, unlike the '/' filter which hides lines already loaded. Lines
+// kept still count towards MaxLogLines.
+type logGrep struct {
+	pattern string
+	re      *regexp.Regexp
+	invert  bool // grep -v: keep the lines that don't match
+	kept    int  // lines kept since the last reset
+	seen    int  // lines looked at since the last reset
+}
+
+// parseGrep reads the arguments of ":grep [-v] <pattern>". Like
+// :search-logs the pattern is a case-insensitive regexp, or literal text
+// when it doesn't compile.
+func parseGrep(args []string) (*logGrep, error) {
+	g := &logGrep{}
+	if len(args) > 0 && args[0] == "-v" {
+		g.invert = true
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("Usage: grep [-v] <pattern> (keeps only matching log lines, -v the others), grep off")
+	}
+	g.pattern = strings.Join(args, " ")
+	re, err := regexp.Compile("(?i)" + g.pattern)
+	if err != nil {
+		re = regexp.MustCompile("(?i)" + regexp.QuoteMeta(g.pattern))
+	}
+	g.re = re
+	return g, nil
+}
+
+// filter returns the lines of content that pass, counting them
+func (g *logGrep) filter(content string) string {
+	lines := strings.Split(content, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		g.seen++
+		if g.re.MatchString(line) != g.invert {
+			kept = append(kept, line)
+		}
+	}
+	g.kept += len(kept)
+	return strings.Join(kept, "\n")
+}
+
+// reset starts counting over, for a new fetch or stream
+func (g *logGrep) reset() {
+	g.kept, g.seen = 0, 0
+}
+
+// status describes the grep for the footer
+func (g *logGrep) status() string {
+	expr := "/" + g.pattern + "/"
+	if g.invert {
+		expr = "-v " + expr
+	}
+	return fmt.Sprintf(" GREP: %s (%d of %d lines) |", expr, g.kept, g.seen)
+}
+
+// setGrep replaces the log grep (nil clears it) and reloads the logs shown:
+// a running follow restarts so its tail passes through the new grep
+func (m *model) setGrep(g *logGrep) tea.Cmd {
+	m.logGrep = g
+	if m.follow != nil {
+		return m.startFollow()
+	}
+	if len(m.items) > 0 && m.detailView == "" {
+		it := m.items[m.cursor]
+		return fetchDetailsCmd(client, Namespace, it, m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(it))
+	}
+	return nil
+}
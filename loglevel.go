@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/devpopsdotin/k9s-deck/internal/parser"
+)
+
+// --- LOG LEVEL FILTER ---
+
+// logLevelThresholds are the minimum levels cycled with 'L' ("" shows all)
+var logLevelThresholds = []string{"", "INFO", "WARN", "ERROR"}
+
+// HideUnleveledLogs hides lines without a detectable level while a level
+// filter is active (config: hideUnleveledLogs)
+var HideUnleveledLogs bool
+
+// nextLogLevel returns the threshold after current in the 'L' cycle
+func nextLogLevel(current string) string {
+	for i, level := range logLevelThresholds {
+		if level == current {
+			return logLevelThresholds[(i+1)%len(logLevelThresholds)]
+		}
+	}
+	return ""
+}
+
+// filterLogLevel drops log lines below minLevel. Indented lines without a
+// level (stack traces, wrapped messages) follow the line they continue;
+// other lines without a level are kept only if showUnleveled.
+func filterLogLevel(content, minLevel string, showUnleveled bool) string {
+	minRank := parser.LogLevelRank(minLevel)
+	if minRank == 0 || content == "" {
+		return content
+	}
+
+	lines := strings.Split(content, "\n")
+	kept := make([]string, 0, len(lines))
+	keepPrev := showUnleveled
+	for _, line := range lines {
+		info := parser.ParseLogLine(line)
+		keep := showUnleveled
+		switch {
+		case info.LogLevel != "":
+			keep = parser.LogLevelRank(info.LogLevel) >= minRank
+		case strings.TrimSpace(info.LogContent) == "":
+			continue
+		case strings.HasPrefix(info.LogContent, " ") || strings.HasPrefix(info.LogContent, "\t"):
+			keep = keepPrev
+		}
+		if keep {
+			kept = append(kept, line)
+		}
+		keepPrev = keep
+	}
+	return strings.Join(kept, "\n")
+}
@@ -5,55 +5,82 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"log/slog"
+	"math"
+	"net"
 	"os"
-	"os/exec"
 	"regexp"
-	"runtime"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/alecthomas/chroma/v2/quick"
+	"github.com/alecthomas/chroma/v2/lexers"
 	"github.com/alecthomas/chroma/v2/styles"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/tidwall/gjson"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/yaml"
 
 	"github.com/devpopsdotin/k9s-deck/internal/k8s"
 	"github.com/devpopsdotin/k9s-deck/internal/logger"
+	"github.com/devpopsdotin/k9s-deck/internal/parser"
 )
 
 // --- CONFIG ---
 var (
-	Context    string
-	Namespace  string
-	Deployment string
-	client     k8s.Client // Kubernetes client (client-go)
+	Context     string
+	Namespace   string
+	Deployment  string
+	ReadOnly    bool        // disables commands that change the cluster or exec into pods
+	RawLogs     bool        // start with raw (unformatted) logs
+	LineNumbers bool        // start with line numbers in the details pane (config: lineNumbers)
+	client      k8s.Client  // Kubernetes client (client-go)
+	clientOpts  k8s.Options // rate limits, reused when :ctx rebuilds the client
+
+	LeftPaneWidthRatio = DefaultLeftPaneRatio // share of the width taken by the resource list (config: leftPaneRatio, '<'/'>')
+
+	RefreshInterval = DefaultRefreshInterval // pace of refreshes (--refresh, config: refreshInterval, :refresh)
+
+	MaxLogLines = DefaultMaxLogLines // log lines kept in the details pane (config: maxLogLines, :buffer)
+
+	RestartWarnThreshold = DefaultRestartWarnThreshold // pod restarts shown in red from this many on (config: restartWarnThreshold)
 )
 
 // --- CONSTANTS ---
 const (
 	// Timing
-	RefreshInterval    = 1 * time.Second
 	CommandTimeout     = 2 * time.Second
+	StaleErrorTimeout  = 30 * time.Second // keep showing a failing target's last good items this long
 	LongCommandTimeout = 5 * time.Second
-	TickerInterval     = 1 * time.Second
+	MaxRefreshInterval = 30 * time.Second // refresh backoff ceiling while the API is throttling
+
+	DefaultRefreshInterval = 1 * time.Second
+	MinRefreshInterval     = 250 * time.Millisecond // floor of --refresh, refreshInterval and :refresh
 
 	// UI Layout
-	LeftPaneWidthRatio = 0.35
-	MinLeftPaneWidth   = 20
-	MinWrapWidth       = 10
-	HeaderHeight       = 3
-	FooterHeight       = 1
-	UILayoutPadding    = 2
+	DefaultLeftPaneRatio = 0.35
+	MinLeftPaneRatio     = 0.1
+	MaxLeftPaneRatio     = 0.9
+	LeftPaneRatioStep    = 0.05 // per '<'/'>' press
+	MinLeftPaneWidth     = 20
+	MinWrapWidth         = 10
+	HorizontalStep       = 8 // columns per left/right scroll while wrapping is off
+	HeaderHeight         = 3
+	FooterHeight         = 1
+	UILayoutPadding      = 2
 
 	// Logging
 	DefaultLogTailLines = 200
 	DeploymentLogTail   = 100
+	SinceLogTailLines   = 10000 // cap per pod while :since is set
 
 	// Log Formatting
 	PodPrefixSuffixLen  = 7
@@ -61,81 +88,113 @@ const (
 	JSONIndent          = 2
 
 	// List Display
-	DefaultListHeight = 20
-	MaxSuggestions    = 5
+	DefaultListHeight           = 20
+	MaxSuggestions              = 5
+	DefaultRestartWarnThreshold = 5
 
 	// Validation
 	MaxK8sNameLength = 253
 
 	// Tabs
-	DeploymentTabCount = 3
-	PodTabCount        = 2
+	TabYAML     = "yaml"
+	TabEvents   = "events"
+	TabLogs     = "logs"
+	TabHistory  = "history"
+	TabNotes    = "notes"
+	TabHooks    = "hooks"
+	TabDescribe = "describe"
+)
+
+// --- TABS ---
+var (
+	// tabTitles are the labels rendered in the tab bar
+	tabTitles = map[string]string{
+		TabYAML:     "YAML",
+		TabEvents:   "Events",
+		TabLogs:     "Logs",
+		TabHistory:  "History",
+		TabNotes:    "Notes",
+		TabHooks:    "Hooks",
+		TabDescribe: "Describe",
+	}
+
+	// availableTabs lists the tabs each resource type knows how to render
+	availableTabs = map[string][]string{
+		"DEP":  {TabYAML, TabEvents, TabLogs, TabDescribe},
+		"STS":  {TabYAML, TabEvents, TabLogs},
+		"DS":   {TabYAML, TabEvents, TabLogs},
+		"RS":   {TabYAML, TabEvents, TabLogs},
+		"POD":  {TabYAML, TabEvents, TabLogs, TabDescribe},
+		"HELM": {TabHistory, TabNotes, TabHooks},
+	}
+
+	// tabSets are the tabs shown per resource type, in order (overridable via config).
+	// Types without an entry get a single "Details" tab.
+	tabSets = map[string][]string{
+		"DEP":  {TabYAML, TabEvents, TabLogs, TabDescribe},
+		"STS":  {TabYAML, TabEvents, TabLogs},
+		"DS":   {TabYAML, TabEvents, TabLogs},
+		"RS":   {TabYAML, TabEvents, TabLogs},
+		"POD":  {TabYAML, TabLogs, TabDescribe},
+		"HELM": {TabHistory, TabNotes, TabHooks},
+	}
 )
 
+// tabName returns the tab identifier at index tab for a resource type, or "" if none
+func tabName(resourceType string, tab int) string {
+	tabs := tabSets[resourceType]
+	if tab < 0 || tab >= len(tabs) {
+		return ""
+	}
+	return tabs[tab]
+}
+
+// tabIndex returns the index of tab name for a resource type, or -1 if not shown
+func tabIndex(resourceType, name string) int {
+	for i, tab := range tabSets[resourceType] {
+		if tab == name {
+			return i
+		}
+	}
+	return -1
+}
+
 // --- STYLES ---
+// Colors and styles are built from the active theme by buildStyles
 var (
-	cPrimary   = lipgloss.Color("62")  // Purple/Blue
-	cSecondary = lipgloss.Color("39")  // Cyan
-	cGreen     = lipgloss.Color("42")  // Green
-	cRed       = lipgloss.Color("196") // Red
-	cYellow    = lipgloss.Color("220") // Yellow
-	cGray      = lipgloss.Color("240") // Gray
-
-	// Pod color palette for log prefixes
-	podColorPalette = []lipgloss.Color{
-		lipgloss.Color("39"),  // Cyan
-		lipgloss.Color("42"),  // Green
-		lipgloss.Color("220"), // Yellow
-		lipgloss.Color("201"), // Magenta
-		lipgloss.Color("141"), // Purple
-		lipgloss.Color("208"), // Orange
-		lipgloss.Color("51"),  // Light Blue
-		lipgloss.Color("82"),  // Light Green
-		lipgloss.Color("213"), // Pink
-		lipgloss.Color("228"), // Light Yellow
-	}
-
-	styleBorder   = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1).BorderForeground(cGray)
-	stylePane     = lipgloss.NewStyle().Padding(0, 1)
-	styleTitle    = lipgloss.NewStyle().Foreground(cSecondary).Bold(true)
-	styleSelected = lipgloss.NewStyle().Foreground(lipgloss.Color("255")).Background(cPrimary).Bold(true).Padding(0, 1)
-	styleDim      = lipgloss.NewStyle().Foreground(cGray)
-	styleErr      = lipgloss.NewStyle().Foreground(cRed)
-	styleHeader   = lipgloss.NewStyle().Foreground(lipgloss.Color("255")).Bold(true).Background(lipgloss.Color("237")).Padding(0, 1).Width(100)
-
-	styleTabActive   = lipgloss.NewStyle().Border(lipgloss.NormalBorder(), false, false, true, false).BorderForeground(cPrimary).Foreground(cPrimary).Bold(true).Padding(0, 1)
-	styleTabInactive = lipgloss.NewStyle().Padding(0, 1).Foreground(cGray)
-
-	styleCmdBar = lipgloss.NewStyle().Foreground(lipgloss.Color("255")).Background(lipgloss.Color("236")).Padding(0, 1)
-
-	styleHighlight = lipgloss.NewStyle().Background(lipgloss.Color("201")).Foreground(lipgloss.Color("255")).Bold(true)
+	cPrimary, cSecondary, cGreen, cRed, cYellow, cGray lipgloss.Color
+
+	styleBorder, stylePane, styleTitle, styleSelected, styleDim, styleErr, styleHeader lipgloss.Style
+	styleTabActive, styleTabInactive                                                   lipgloss.Style
+	styleCmdBar, styleHighlight                                                        lipgloss.Style
 )
 
 // --- LOG PARSING ---
 var (
-	logLevelRegex  = regexp.MustCompile(`(?i)\b(FATAL|ERROR|ERR|WARN|WARNING|INFO|DEBUG|TRACE)\b`)
-	podPrefixRegex = regexp.MustCompile(`^\[([^/]+)/([^/]+)/([^\]]+)\]\s*(.*)$`)
+	// Java-style key=value lines, optionally with comments
+	propertiesLineRegex = regexp.MustCompile(`^(?:(?:[#!][^\n]*|[\w.\-]+\s*=[^\n]*)\n?)+$`)
 )
 
 func init() {
-	_ = styles.Get("dracula")
+	_ = styles.Get(syntaxStyle())
 }
 
 // --- DATA MODEL ---
 type item struct {
-	Type   string // DEP, POD, HELM, SEC, CM, HDR
-	Name   string
-	Status string
-}
-
-type logLineInfo struct {
-	OriginalLine  string
-	PodPrefix     string // e.g., "nginx-deployment-5c7588df-abc123/nginx"
-	PodName       string
-	ContainerName string
-	LogContent    string
-	LogLevel      string // ERROR, WARN, INFO, DEBUG, etc.
-	IsJSON        bool
+	Type     string // DEP, STS, DS, RS, POD, HELM, SEC, CM, HDR
+	Name     string
+	Status   string // DEP/STS/RS: "ready/desired" replicas, DS: "desired/ready/available" nodes
+	Image    string // DEP/STS/DS/RS: container images, comma-separated
+	Revision string // DEP: rollout revision (deployment.kubernetes.io/revision)
+	Digest   string // POD: running image digests (short), comma-separated
+	Drift    bool   // DEP/POD: the group's pods run different image digests
+
+	Created  time.Time // POD: creation time, for sorting by age
+	Restarts int       // POD: restarts summed over its containers
+
+	Anomaly string // DEP: why discovery may be incomplete (unexpected API shape)
+	System  bool   // SEC/CM: cluster plumbing, hidden unless toggled with 'S'
+	Note    string // HDR: shown in the header only, e.g. why its items are stale
 }
 
 type multiContainerCache struct {
@@ -146,127 +205,395 @@ type multiContainerCache struct {
 type model struct {
 	items []item
 
-	targets      []string          // List of deployments to monitor
-	selectors    map[string]string // Cache label selectors per deployment
-	helmReleases map[string]string // Cache helm release names
-
-	cursor     int
-	listOffset int
-	listHeight int
-
-	activeTab    int
-	textInput    textinput.Model
-	inputMode    bool
-	filterMode   bool
-	shortcutMode string // "scale", "rollback", "add", "remove", or ""
-	partialKey   string // for multi-character shortcuts like "rm"
-	activeFilter string
-	filterRegex  *regexp.Regexp
+	targets      []string            // List of deployments to monitor, in the order added
+	targetSet    map[string]struct{} // targets for membership checks, kept in sync by setTargets
+	selectors    map[string]string   // Cache label selectors per deployment
+	helmReleases map[string]string   // Cache helm release names
+
+	manualSelectors map[string]string // :selector overrides per deployment
+	scaleUndo       map[string]int    // replicas of each target before its last scale, restored by :undo
+
+	cursor        int
+	listOffset    int
+	listHeight    int
+	leftPaneRatio float64 // share of the width taken by the list, changed with '<'/'>'
+	layout        string  // layoutSideBySide or layoutStacked once chosen with '|'
+
+	activeTab     int
+	textInput     textinput.Model
+	inputMode     bool
+	filterMode    bool
+	shortcutMode  string         // "scale", "rollback", "add", "remove", "namespace", "context", "confirm", or ""
+	pendingAction *pendingAction // command the "confirm" prompt asks about
+	partialKey    string         // for multi-character shortcuts like "rm"
+	yanking       bool           // a clipboard copy is running
+	queuedYank    string         // text to copy once the running copy finishes
+	yankSeq       int            // bumped per 'y', tells its yankTimeoutMsg apart
+	activeFilter  string
+	filterRegex   *regexp.Regexp
+
+	// Search ('Ctrl+/'): matches stay in context, n/N jump between them
+	searchMode    bool // the input prompt is the search query
+	searchQuery   string
+	searchRegex   *regexp.Regexp
+	searchMatches []int // rendered line of each matching line, in order
+	searchIndex   int   // current match in searchMatches
+
+	// JSON query ('\'): the YAML tab shows only the result of a gjson path
+	queryMode   bool       // the input prompt is the query
+	jsonQuery   string     // active gjson path, "" for the full view
+	lastDetails detailsMsg // last fetched details, re-rendered when the query changes
 
 	// LSP-like autocomplete
-	suggestions     []string // Available deployment names for autocomplete
+	suggestions     []string // Available deployment (or namespace, context) names for autocomplete
 	suggestionIndex int      // Currently selected suggestion
 	showSuggestions bool     // Whether to show autocomplete suggestions
 
-	viewport   viewport.Model
-	rawContent string
-	ready      bool
-	width      int
-	height     int
-	lastUpd    time.Time
-	err        error
+	viewport    viewport.Model
+	rawContent  string
+	viewContent string // rawContent after the / filter, as displayed ('y' copies it)
+	detailsKey  string // item and tab the details pane shows, see detailsKeyOf
+	ready       bool
+	width       int
+	height      int
+	lastUpd     time.Time
+	err         error
 
 	// Log formatting
 	logFormatMode      bool                 // true=formatted, false=raw
+	wrapMode           bool                 // wrap the detail pane to its width ('w'); off scrolls horizontally
+	lineNumbers        bool                 // number the detail pane's lines ('#'), wrapped parts share one number
+	jsonMode           parser.JSONLogMode   // how formatted JSON logs render, cycled with 'J'
+	collapseRepeats    bool                 // identical consecutive log lines shown once with an (xN) count ('U')
+	timestamps         bool                 // prefix log lines with their RFC3339 timestamp ('t')
+	minLogLevel        string               // 'L' level filter: hide log lines below it, "" for all
+	logGrep            *logGrep             // :grep, drops log lines as they arrive; nil for none
+	configSaveSeq      int                  // bumped per change, only the latest schedules a config write-back
+	helmOldestFirst    bool                 // Helm History tab order, toggled with 'o' on a release
+	podSort            string               // order of the pods within each group ('o'), one of podSortKeys
 	multiContainerInfo *multiContainerCache // cache for multi-container detection
 
+	configErr error // config problem shown atop the details pane until Esc
+
+	// '?' help overlay
+	showHelp   bool
+	helpScroll int // first help line shown
+
+	// Pod usage from metrics.k8s.io
+	podMetrics         map[string]k8s.PodMetrics // pod name -> usage, nil without metrics
+	metricsUnavailable bool                      // the cluster has no metrics API
+	metricsGen         int                       // current metrics loop, see restartMetrics
+
 	// Status messages
 	statusMsg string // temporary status message (e.g., "Copied to clipboard")
+
+	// Mutating command (scale, restart, rollback, delete-pod) in flight, "" if none
+	mutating string
+
+	// Command-driven detail view (e.g., "debug-log"), "" for the selected item
+	detailView string
+
+	// Frozen copy of the detail pane for :diff-snapshot
+	snap *snapshot
+
+	// Detail view pinned below the main pane with 'p'
+	peek *peekPane
+
+	// Container picked with 'c' for containerPod's logs, "" for all of them
+	container    string
+	containerPod string
+	picker       *containerPicker
+
+	// Pod whose Logs tab shows the previous (crashed) container instance ('V')
+	previousPod string
+
+	// :since window for logs, 0 for the last DefaultLogTailLines lines
+	logSince time.Duration
+
+	// Active :pf port-forwards
+	forwards   []*portForward
+	forwardSeq int
+
+	// Rollout followed after a restart or scale, nil when none
+	rollout    *rolloutWatch
+	rolloutSeq int
+
+	// Live log stream for the Logs tab ('F'), nil when not following
+	follow    *logFollow
+	followSeq int
+
+	// ConfigMap key browsing: tab 0 is the full YAML, tab N shows cmKeys[N-1]
+	cmKeys []string
+
+	// Last successful refresh per target, shown marked stale while it errors
+	lastGoodItems map[string][]item
+	lastGoodAt    map[string]time.Time
+	targetErrs    map[string]error // why each target failed its last refresh
+
+	// Preferences persisted between launches
+	saved savedState
+
+	// Refresh pacing: backs off while the API server is throttling us
+	refreshInterval time.Duration
+	tickGen         int  // current refresh loop, see setRefreshInterval
+	fetching        bool // a tick-driven refresh is still in flight
+	dataGen         int  // current namespace/context, bumped by resetClusterState
+	paused          bool // 'z' stops automatic refreshes, Ctrl-F still refreshes
+
+	// Pod watches per target push refreshes; ticks only re-list as a fallback
+	watches             map[string]*podWatch
+	watchSeq            int
+	watchRefreshPending bool
+
+	// System secrets/configmaps are hidden unless toggled with 'S'
+	showSystem   bool
+	hiddenSystem int // how many the last refresh hid
+
+	// :pods lists every pod in the namespace instead of the monitored targets
+	podsMode   bool
+	podsFilter string
+
+	// Dashboard mode: grid of deployment cards instead of the split layout
+	dashboardMode bool
+	dashCursor    int
 }
 
 // --- MESSAGES ---
-type tickMsg time.Time
+// tickMsg carries the generation of the refresh loop; :refresh starts a new
+// loop at the new interval and the old one dies out
+type tickMsg struct {
+	gen int
+}
 type dataMsg struct {
-	items        []item
+	gen          int               // dataGen the refresh started in
+	targetItems  map[string][]item // items per successfully refreshed target
+	targetErrs   map[string]error  // refresh error per failed target
 	selectors    map[string]string
 	helmReleases map[string]string
-	err          error
+	renamed      map[string]string // bare targets that turned out to be another kind, e.g. db -> sts/db
+	throttled    bool              // some request was rate limited
+	allPods      bool              // result of a :pods refresh
+	pods         []item            // :pods sidebar items
+	err          error             // the cluster itself is unreachable, shown in the header
 }
 type detailsMsg struct {
 	content string
 	isYaml  bool
-	err     error
+	lang    string // chroma lexer to highlight with, takes precedence over isYaml
+	isLog   bool   // format as logs regardless of the active tab
+	styled  bool   // already colored, shown as is (e.g. :events)
+	cmKeys  []string
+	// Helm History tab: rendered as a table in the order chosen with 'o'
+	helmRevisions []k8s.HelmRevision
+	err           error
+}
+type commandFinishedMsg struct {
+	rollout string       // deployment whose rollout to watch, "" for none
+	scaled  *scaleChange // the replica change of a scale or undo, nil otherwise
+}
+type mutationDoneMsg struct {
+	result tea.Msg // what the mutating command returned
 }
-type commandFinishedMsg struct{}
 type addTargetMsg struct {
-	name string
+	name string // the target of the workload's actual kind
 }
 type removeTargetMsg struct {
 	name string
 }
 type suggestionsMsg struct {
-	deployments []string
+	mode  string // shortcutMode the names complete
+	names []string
 }
 type copyMsg struct {
 	success bool
+	osc52   bool // sent to the terminal, which may not support it
 	err     error
 }
 type clearStatusMsg struct{}
+type pasteMsg struct {
+	text string
+	err  error
+}
 
 // --- MAIN ---
 func main() {
-	if len(os.Args) < 4 {
-		if os.Getenv("KUBECONFIG") != "" {
-			Context = "kind-kind"
-			Namespace = "default"
-			Deployment = "hello-app"
-		} else {
-			fmt.Println("Usage: k9s-deck <context> <namespace> <deployment>")
-			os.Exit(1)
-		}
-	} else {
-		Context = os.Args[1]
-		Namespace = os.Args[2]
-		Deployment = os.Args[3]
+	if len(os.Args) > 1 && os.Args[1] == "wait" {
+		// Non-interactive: block until a deployment is rolled out, for scripts
+		os.Exit(runWait(os.Args[2:]))
+	}
+
+	logFile := flag.String("log-file", "", "path of the debug log (default $"+logger.EnvLogFile+" or the XDG state dir)")
+	flag.BoolVar(&ReadOnly, "read-only", false, "disable scale/restart/rollback/pod deletion and exec-based commands")
+	rawLogs := flag.Bool("raw-logs", false, "start with raw (unformatted) logs instead of formatted")
+	ascii := flag.Bool("ascii", false, "use ASCII markers instead of emoji icons (auto-detected for non-UTF-8 terminals)")
+	qps := flag.Float64("qps", 0, "client-side API request rate limit (default 5, or the config's qps)")
+	burst := flag.Int("burst", 0, "client-side API request burst (default 10, or the config's burst)")
+	refresh := flag.Duration("refresh", 0, "refresh interval, at least "+MinRefreshInterval.String()+" (default 1s, or the config's refreshInterval)")
+	osc52 := flag.Bool("osc52", false, "copy through the terminal (OSC52) instead of pbcopy/xclip/wl-copy, e.g. over SSH (or $"+EnvOSC52+"=1)")
+	configFile := flag.String("config", "", "path of the config file (default $"+EnvConfigFile+" or <config dir>/k9s-deck/config.yaml)")
+	kubeconfig := flag.String("kubeconfig", "", "path of the kubeconfig (default $KUBECONFIG, which may list several files, or ~/.kube/config)")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: k9s-deck [flags] [<context> <namespace> <deployment>]")
+		fmt.Fprintln(os.Stderr, "       "+strings.TrimPrefix(waitUsage, "Usage: "))
+		flag.PrintDefaults()
 	}
+	flag.Parse()
+	args := flag.Args()
 
-	// Initialize logger (writes to /tmp/k9s-deck.log)
-	if err := logger.Init(); err != nil {
+	// Initialize logger (writes to --log-file, rotated by size)
+	if err := logger.Init(*logFile); err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: failed to initialize logger: %v\n", err)
 		// Continue anyway - logging is not critical
 	}
 
+	cfg, err := loadConfig(*configFile)
+	if err == nil {
+		err = applyConfig(cfg)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if path, _, err := resolveConfigPath(*configFile); err == nil {
+		configFilePath = path
+	}
+	// Keybindings: a bad mapping keeps the defaults and is shown in the details pane
+	var keysErr error
+	Keys, keysErr = parseKeyBindings(cfg.Keybindings)
+	if keysErr != nil {
+		keysErr = fmt.Errorf("%w (using the default keybindings)", keysErr)
+	}
+	// Log levels: colors that can't be used fall back and are shown the same way
+	levelsWarning, err := parser.SetLogLevels(cfg.LogLevels)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Kubeconfig: --kubeconfig beats $KUBECONFIG. It is set in the environment
+	// so kubectl and helm subprocesses read the same file as client-go, and
+	// before the targets so it counts as a KUBECONFIG for the demo defaults.
+	if err := setKubeconfig(*kubeconfig); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Targets: the arguments beat the config, which beats the KUBECONFIG demo defaults
+	var ok bool
+	Context, Namespace, Deployment, ok = launchTarget(args, cfg)
+	if !ok {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	// Log format: --raw-logs beats the config, where 'f' writes its choice;
+	// without a config location the last 'f' toggle is in the saved state
+	saved := loadState()
+	RawLogs = cfg.RawLogs
+	HideUnleveledLogs = cfg.HideUnleveledLogs
+	LineNumbers = cfg.LineNumbers
+	if saved.RawLogs != nil && configFilePath == "" {
+		RawLogs = *saved.RawLogs
+	}
+	if *rawLogs {
+		RawLogs = true
+	}
+
+	// Icons: --ascii beats the config, which beats auto-detection
+	UseASCII = terminalLacksEmoji()
+	if cfg.ASCII != nil {
+		UseASCII = *cfg.ASCII
+	}
+	if *ascii {
+		UseASCII = true
+	}
+
+	// Syntax highlighting: $K9S_DECK_SYNTAX_STYLE beats the config, which beats the theme's style
+	SyntaxStyle, SyntaxFormatter = syntaxSettings(cfg)
+
+	// Scale, restart and rollback ask first when the config says so
+	ConfirmActions = cfg.ConfirmActions
+
+	// Refresh pace: --refresh beats the config
+	if *refresh != 0 {
+		if *refresh < MinRefreshInterval {
+			fmt.Fprintf(os.Stderr, "Error: --refresh %s is below the minimum of %s\n", *refresh, MinRefreshInterval)
+			os.Exit(1)
+		}
+		RefreshInterval = *refresh
+	}
+
+	// Clipboard: any of --osc52, the config and the environment force OSC52
+	ForceOSC52 = *osc52 || cfg.OSC52 || osc52FromEnv()
+
+	// Rate limits: flags beat the config, zero keeps client-go's defaults
+	clientOpts = k8s.Options{QPS: cfg.QPS, Burst: cfg.Burst}
+	if *qps > 0 {
+		clientOpts.QPS = float32(*qps)
+	}
+	if *burst > 0 {
+		clientOpts.Burst = *burst
+	}
+
 	// Initialize Kubernetes client (uses client-go for performance)
-	var err error
-	client, err = k8s.NewClient(Context)
+	client, err = k8s.NewClientWithOptions(Context, clientOpts)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: failed to create Kubernetes client: %v\n", err)
 		os.Exit(1)
 	}
 
-	p := tea.NewProgram(initialModel(), tea.WithAltScreen(), tea.WithMouseCellMotion())
-	if _, err := p.Run(); err != nil {
+	m := initialModel(saved, startTargets(cfg, Deployment))
+	m.configErr = errors.Join(keysErr, levelsWarning)
+	p := tea.NewProgram(m, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	final, err := p.Run()
+	if fm, ok := final.(model); ok {
+		fm.stopForwards()
+	}
+	if err != nil {
 		fmt.Println("Error:", err)
 		os.Exit(1)
 	}
 }
 
-func initialModel() model {
+// setKubeconfig exports a --kubeconfig path as $KUBECONFIG, "" keeps the environment's
+func setKubeconfig(path string) error {
+	if path == "" {
+		return nil
+	}
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("--kubeconfig: %w", err)
+	}
+	return os.Setenv("KUBECONFIG", path)
+}
+
+func initialModel(saved savedState, targets []string) model {
 	ti := textinput.New()
-	ti.Placeholder = "scale 3 | restart | rollback 1 | add <name> | remove <name>"
+	ti.Placeholder = "scale 3 | restart | rollback 1 | add <name> | remove <name> | ns <name> | ctx <name>"
 	ti.Prompt = ": "
 	ti.CharLimit = 156
 	ti.Width = 50
+	// ctrl+v is handled by pasteCmd so pastes update suggestions once
+	ti.KeyMap.Paste.SetEnabled(false)
 
-	// Initialize targets with the starting deployment
+	// Initialize targets with the starting deployments
 	return model{
-		textInput:     ti,
-		inputMode:     false,
-		listHeight:    DefaultListHeight,
-		targets:       []string{Deployment},
-		selectors:     make(map[string]string),
-		helmReleases:  make(map[string]string),
-		logFormatMode: true, // Default to formatted
+		textInput:       ti,
+		inputMode:       false,
+		listHeight:      DefaultListHeight,
+		targets:         targets,
+		targetSet:       newTargetSet(targets),
+		selectors:       make(map[string]string),
+		manualSelectors: make(map[string]string),
+		scaleUndo:       make(map[string]int),
+		helmReleases:    make(map[string]string),
+		lastGoodItems:   make(map[string][]item),
+		lastGoodAt:      make(map[string]time.Time),
+		logFormatMode:   !RawLogs,
+		wrapMode:        true,
+		lineNumbers:     LineNumbers,
+		refreshInterval: RefreshInterval,
+		leftPaneRatio:   LeftPaneWidthRatio,
+		saved:           saved,
 		multiContainerInfo: &multiContainerCache{
 			cache: make(map[string]bool),
 		},
@@ -274,7 +601,7 @@ func initialModel() model {
 }
 
 func (m model) Init() tea.Cmd {
-	return tea.Batch(fetchDataCmd(m.targets, m.selectors), tickCmd(), textinput.Blink)
+	return tea.Batch(fetchDataCmd(client, Context, Namespace, m.dataGen, m.targets, copySelectorMap(m.manualSelectors)), tickCmd(m.tickGen, m.refreshInterval), fetchMetricsCmd(client, Namespace, m.metricsGen), textinput.Blink)
 }
 
 // copySelectorMap creates a copy of selectors map to avoid concurrent access issues
@@ -316,6 +643,46 @@ func minInt(a, b int) int {
 	return b
 }
 
+// throttleIndicator warns that refreshes are slowed down by API throttling
+func (m model) throttleIndicator() string {
+	if m.refreshInterval <= RefreshInterval {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(cYellow).Render(fmt.Sprintf("⏳ API throttled, refreshing every %s", m.refreshInterval))
+}
+
+// refreshDetailsCmds re-fetches the details pane and a live peek for the
+// current selection
+func (m *model) refreshDetailsCmds() []tea.Cmd {
+	var cmds []tea.Cmd
+	if m.peek != nil && m.peek.live {
+		cmds = append(cmds, peekCmd(m.peek, copySelectorMap(m.selectors), m.multiContainerInfo))
+	}
+
+	if m.follow != nil && m.detailView != "" {
+		// A command took over the detail pane
+		m.stopFollow()
+	}
+
+	// Pass a copy of selectors to avoid race
+	switch {
+	case m.follow != nil:
+		// The stream appends to the logs, a refetch would replace them
+	case m.detailView == "debug-log":
+		cmds = append(cmds, fetchDebugLogCmd())
+	case m.detailView == "events":
+		cmds = append(cmds, fetchAllEventsCmd(client, Namespace))
+	case m.detailView == "diff-snapshot" && len(m.items) > 0:
+		// Keep diffing the snapshot against the live selection
+		cmds = append(cmds, fetchDetailsCmd(client, Namespace, m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(m.items[m.cursor])))
+	case m.detailView != "":
+		// One-shot command output (e.g. triage) stays until the selection changes
+	case len(m.items) > 0:
+		cmds = append(cmds, fetchDetailsCmd(client, Namespace, m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(m.items[m.cursor])))
+	}
+	return cmds
+}
+
 // --- UPDATE ---
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var (
@@ -326,24 +693,90 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// --- SYSTEM MESSAGES ---
 	switch msg := msg.(type) {
 	case tickMsg:
-		return m, tea.Batch(fetchDataCmd(m.targets, m.selectors), tickCmd())
+		if msg.gen != m.tickGen {
+			return m, nil
+		}
+		if m.fetching || m.paused {
+			// Don't queue more requests behind a slow or rate limited refresh
+			return m, tickCmd(m.tickGen, m.refreshInterval)
+		}
+		if m.watchingAll() && time.Since(m.lastUpd) < WatchResyncInterval {
+			// Pod changes arrive through the watches, only the details poll
+			cmds = append(m.refreshDetailsCmds(), tickCmd(m.tickGen, m.refreshInterval))
+			return m, tea.Batch(cmds...)
+		}
+		m.fetching = true
+		return m, tea.Batch(m.refreshCmd(), tickCmd(m.tickGen, m.refreshInterval))
 
-	case commandFinishedMsg:
-		return m, fetchDataCmd(m.targets, m.selectors)
+	case watchStartedMsg, podEventMsg, watchEndedMsg, watchRefreshMsg:
+		return m, m.handleWatchMsg(msg)
 
-	case addTargetMsg:
-		// Check duplicates
-		exists := false
-		for _, t := range m.targets {
-			if t == msg.name {
-				exists = true
-				break
-			}
+	case commandFinishedMsg:
+		var status tea.Cmd
+		if msg.scaled != nil {
+			status = m.recordScale(*msg.scaled)
 		}
-		if !exists {
-			m.targets = append(m.targets, msg.name)
+		if msg.rollout != "" {
+			return m, tea.Batch(m.refreshCmd(), m.startRolloutWatch(msg.rollout), status)
+		}
+		return m, tea.Batch(m.refreshCmd(), status)
+
+	case rolloutStatusMsg:
+		return m, m.handleRolloutStatus(msg)
+
+	case metricsTickMsg:
+		if msg.gen != m.metricsGen {
+			return m, nil
 		}
-		return m, fetchDataCmd(m.targets, m.selectors)
+		return m, fetchMetricsCmd(client, Namespace, msg.gen)
+
+	case metricsMsg:
+		return m, m.handleMetricsMsg(msg)
+
+	case restartAllMsg:
+		return m, m.handleRestartAll(msg)
+
+	case podDeletedMsg:
+		m.statusMsg = "Deleted pod " + msg.pod
+		return m, tea.Batch(m.refreshCmd(), clearStatusLater())
+
+	case actionPreviewMsg:
+		return m, m.handleActionPreview(msg)
+
+	case mutationDoneMsg:
+		// Unlock, then handle the result (commandFinishedMsg or an error) as usual
+		m.mutating = ""
+		return m.Update(msg.result)
+
+	case containersMsg:
+		return m, m.showContainerPicker(msg)
+
+	case shellFinishedMsg:
+		return m, m.handleShellFinished(msg)
+
+	case editReadyMsg:
+		return m, execEditorCmd(msg)
+
+	case editFinishedMsg:
+		return m, m.handleEditFinished(msg)
+
+	case editAppliedMsg:
+		return m, m.handleEditApplied(msg)
+
+	case forwardStartedMsg:
+		return m, m.handleForwardStarted(msg)
+
+	case forwardEndedMsg:
+		return m, m.handleForwardEnded(msg)
+
+	case namespaceSwitchMsg:
+		return m, m.switchNamespace(msg)
+
+	case contextSwitchMsg:
+		return m, m.switchContext(msg)
+
+	case addTargetMsg:
+		return m, m.monitorTarget(msg.name)
 
 	case removeTargetMsg:
 		// Remove target from list
@@ -353,30 +786,53 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				newTargets = append(newTargets, t)
 			}
 		}
-		m.targets = newTargets
+		m.setTargets(newTargets)
 		// Also clean up the selectors and helm releases for removed target
 		delete(m.selectors, msg.name)
+		delete(m.manualSelectors, msg.name)
 		delete(m.helmReleases, msg.name)
+		delete(m.lastGoodItems, msg.name)
+		delete(m.lastGoodAt, msg.name)
+		parsedWorkloads.delete(workloadKey{context: Context, namespace: Namespace, target: msg.name})
 		// Reset cursor if needed
 		if len(m.targets) == 0 {
 			m.cursor = 0
 		}
-		return m, fetchDataCmd(m.targets, m.selectors)
+		return m, tea.Batch(fetchDataCmd(client, Context, Namespace, m.dataGen, m.targets, copySelectorMap(m.manualSelectors)), m.scheduleConfigSave())
+
+	case yankTimeoutMsg:
+		if m.partialKey != Keys.Yank || msg.seq != m.yankSeq {
+			return m, nil
+		}
+		// No 'p' or 's' followed: 'y' alone copies the pane
+		m.partialKey = ""
+		return m, m.yankPane()
+
+	case configSaveMsg:
+		return m, m.autoSaveConfigCmd(msg)
+
+	case configSavedMsg:
+		if msg.err != nil {
+			m.statusMsg = "Cannot save the config: " + msg.err.Error()
+		} else {
+			m.statusMsg = "Saved the config to " + msg.path
+		}
+		return m, clearStatusLater()
 
 	case suggestionsMsg:
-		// Update available deployment suggestions (only for add mode)
+		// Ignore suggestions for a prompt that was already closed
+		if msg.mode != m.shortcutMode {
+			return m, nil
+		}
+		if m.shortcutMode == "namespace" {
+			m.suggestions = msg.names
+			m.updateSuggestions()
+		}
 		if m.shortcutMode == "add" {
 			// Filter out already monitored deployments immediately
 			var filtered []string
-			for _, deployment := range msg.deployments {
-				alreadyMonitored := false
-				for _, target := range m.targets {
-					if target == deployment {
-						alreadyMonitored = true
-						break
-					}
-				}
-				if !alreadyMonitored {
+			for _, deployment := range msg.names {
+				if !m.hasTarget(deployment) {
 					filtered = append(filtered, deployment)
 				}
 			}
@@ -387,8 +843,16 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case copyMsg:
-		// Handle clipboard copy result
-		if msg.success {
+		// Handle clipboard copy result, then run a yank that waited for it
+		m.yanking = false
+		if m.queuedYank != "" {
+			text := m.queuedYank
+			m.queuedYank = ""
+			return m, m.yank(text)
+		}
+		if msg.success && msg.osc52 {
+			m.statusMsg = "Yanked to clipboard (via terminal, OSC52)"
+		} else if msg.success {
 			m.statusMsg = "Yanked to clipboard"
 		} else {
 			m.statusMsg = fmt.Sprintf("Copy failed: %v", msg.err)
@@ -402,101 +866,172 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.statusMsg = ""
 		return m, nil
 
+	case pasteMsg:
+		if msg.err != nil {
+			m.statusMsg = fmt.Sprintf("Paste failed: %v", msg.err)
+			return m, tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
+				return clearStatusMsg{}
+			})
+		}
+		if m.inputMode {
+			m.insertInput(msg.text)
+		}
+		return m, nil
+
 	case tea.WindowSizeMsg:
 		m.width = maxInt(msg.Width, 0)
 		m.height = maxInt(msg.Height, 0)
 
-		m.listHeight = maxInt(msg.Height-HeaderHeight-FooterHeight-UILayoutPadding, 1)
-
-		paneWidth := maxInt(int(float64(msg.Width)*LeftPaneWidthRatio), 0)
-		vpWidth := maxInt(msg.Width-paneWidth-4, 0)
-		vpHeight := maxInt(msg.Height-HeaderHeight-FooterHeight-UILayoutPadding, 0)
+		// Keep the command input inside the command bar on narrow terminals
+		m.textInput.Width = minInt(50, maxInt(msg.Width-lipgloss.Width(m.textInput.Prompt)-4, 1))
 
 		if !m.ready {
-			m.viewport = viewport.New(vpWidth, vpHeight)
+			m.viewport = viewport.New(0, 0)
 			m.viewport.YPosition = HeaderHeight + 1
+			m.viewport.SetHorizontalStep(HorizontalStep)
+			m.layoutPanes()
 			m.ready = true
 		} else {
-			m.viewport.Width = vpWidth
-			m.viewport.Height = vpHeight
+			m.layoutPanes()
 			m.updateViewportContent()
 		}
 		return m, nil
 
 	case dataMsg:
+		if msg.gen != m.dataGen {
+			// Refresh started before a namespace or context switch
+			return m, nil
+		}
+		m.fetching = false
+		if msg.allPods != m.podsMode {
+			// Refresh started before :pods was toggled
+			return m, nil
+		}
 		m.lastUpd = time.Now()
-		if msg.err != nil {
-			m.err = msg.err
-		} else {
-			m.err = nil
+		m.err = msg.err
+		m.adjustRefreshInterval(msg.throttled)
 
-			// Remember current selection before updating items
-			var currentSelection *item
-			if len(m.items) > 0 && m.cursor < len(m.items) {
-				currentSelection = &m.items[m.cursor]
-			}
+		// Remember current selection before updating items
+		var currentSelection *item
+		if len(m.items) > 0 && m.cursor < len(m.items) {
+			currentSelection = &m.items[m.cursor]
+		}
 
-			m.items = msg.items
-			// Merge maps
-			for k, v := range msg.selectors {
-				m.selectors[k] = v
+		if m.podsMode {
+			if msg.err == nil {
+				m.items = msg.pods
 			}
-			for k, v := range msg.helmReleases {
-				m.helmReleases[k] = v
+		} else {
+			if len(msg.renamed) > 0 {
+				cmds = append(cmds, m.renameTargets(msg.renamed))
 			}
+			m.items = m.assembleItems(msg)
+		}
+		sortPods(m.items, m.podSort)
+		// Merge maps
+		for k, v := range msg.selectors {
+			m.selectors[k] = v
+		}
+		for k, v := range msg.helmReleases {
+			m.helmReleases[k] = v
+		}
 
-			// Try to restore cursor to the same item
-			if currentSelection != nil && len(m.items) > 0 {
-				newCursor := -1
-				for i, item := range m.items {
-					if item.Type == currentSelection.Type && item.Name == currentSelection.Name {
-						newCursor = i
-						break
-					}
-				}
-				if newCursor != -1 {
-					m.cursor = newCursor
-				} else {
-					// Item not found, validate bounds
-					m.cursor = ensureCursorInBounds(m.cursor, len(m.items))
+		// Try to restore cursor to the same item
+		if currentSelection != nil && len(m.items) > 0 {
+			newCursor := -1
+			for i, item := range m.items {
+				if item.Type == currentSelection.Type && item.Name == currentSelection.Name {
+					newCursor = i
+					break
 				}
+			}
+			if newCursor != -1 {
+				m.cursor = newCursor
 			} else {
-				// Validate cursor position for new or empty selections
+				// Item not found, validate bounds
 				m.cursor = ensureCursorInBounds(m.cursor, len(m.items))
 			}
+		} else {
+			// Validate cursor position for new or empty selections
+			m.cursor = ensureCursorInBounds(m.cursor, len(m.items))
+		}
 
-			// Always refresh details - pass a copy of selectors to avoid race
-			if len(m.items) > 0 {
-				cmds = append(cmds, fetchDetailsCmd(m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo))
-			}
+		// Always refresh details, and follow selector changes with the watches
+		if !m.podsMode {
+			cmds = append(cmds, m.restartFollowOnPodChange(msg))
 		}
+		cmds = append(cmds, m.refreshDetailsCmds()...)
+		cmds = append(cmds, m.syncWatches())
+		cmds = append(cmds, m.restartForwards()...)
 		return m, tea.Batch(cmds...)
 
 	case detailsMsg:
-		if msg.err != nil {
-			m.rawContent = fmt.Sprintf("Error: %v", msg.err)
+		m.cmKeys = msg.cmKeys
+		m.lastDetails = msg
+		currentItem := item{}
+		if len(m.items) > 0 && m.cursor < len(m.items) {
+			currentItem = m.items[m.cursor]
+		}
+		m.rawContent = m.renderDetails(msg, currentItem, m.activeTab)
+		if msg.err == nil && m.detailView == "diff-snapshot" && m.snap != nil {
+			m.rawContent = renderSnapshotDiff(*m.snap, stripANSI(m.rawContent), m.itemSource())
+		}
+		// Refreshes of the same item keep the scroll position, a new
+		// selection or tab starts at the top
+		offset := m.viewport.YOffset
+		m.updateViewportContent()
+		if key := detailsKeyOf(currentItem, m.activeTab); key != m.detailsKey {
+			m.detailsKey = key
+			m.viewport.GotoTop()
 		} else {
-			if msg.isYaml {
-				m.rawContent = highlight(msg.content, "yaml")
-			} else {
-				// Determine if this is log content
-				currentItem := item{}
-				if len(m.items) > 0 && m.cursor < len(m.items) {
-					currentItem = m.items[m.cursor]
-				}
+			m.viewport.SetYOffset(offset)
+		}
+		return m, nil
+
+	case followStartedMsg:
+		if m.follow == nil || msg.id != m.follow.id {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.stopFollow()
+			m.rawContent = fmt.Sprintf("Error: follow failed: %v", msg.err)
+			m.updateViewportContent()
+			return m, nil
+		}
+		m.follow.lines = msg.lines
+		m.follow.pods = msg.pods
+		if msg.skipped != nil {
+			m.statusMsg = fmt.Sprintf("Not following every pod: %v", msg.skipped)
+			return m, tea.Batch(waitLogLinesCmd(msg.id, msg.lines), clearStatusLater())
+		}
+		return m, waitLogLinesCmd(msg.id, msg.lines)
 
-				isLogContent := (currentItem.Type == "DEP" && m.activeTab == 2) ||
-					(currentItem.Type == "POD" && m.activeTab == 1)
+	case logLineMsg:
+		if m.follow == nil || msg.id != m.follow.id {
+			// Stopped stream: its context is cancelled, so the channel drains itself
+			return m, nil
+		}
+		m.appendLogLines(msg.lines)
+		return m, waitLogLinesCmd(msg.id, m.follow.lines)
+
+	case followEndedMsg:
+		if m.follow != nil && msg.id == m.follow.id {
+			m.follow = nil
+			m.statusMsg = "Log stream ended"
+			return m, clearStatusLater()
+		}
+		return m, nil
 
-				if isLogContent {
-					m.rawContent = processLogContent(msg.content, currentItem.Type,
-						currentItem.Name, m.logFormatMode)
-				} else {
-					m.rawContent = msg.content
-				}
+	case peekMsg:
+		if m.peek != nil && m.peek.live {
+			pm := m
+			if m.logGrep != nil {
+				// Grep the pinned logs too, without touching the footer's counts
+				g := *m.logGrep
+				pm.logGrep = &g
 			}
+			m.peek.content = pm.renderDetails(msg.details, m.peek.item, m.peek.tab)
 		}
-		m.updateViewportContent()
 		return m, nil
 	}
 
@@ -504,18 +1039,28 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if m.inputMode {
 		switch msg := msg.(type) {
 		case tea.KeyMsg:
+			// Bracketed paste arrives as a single event - insert it in one go
+			if msg.Paste {
+				m.insertInput(string(msg.Runes))
+				return m, nil
+			}
+			if m.shortcutMode == "confirm" {
+				return m, m.confirmAction(msg.String())
+			}
 			switch msg.String() {
+			case "ctrl+v":
+				return m, pasteCmd()
 			case "tab":
-				// Tab completes with selected suggestion for add/remove mode
-				if (m.shortcutMode == "add" || m.shortcutMode == "remove") && m.showSuggestions && len(m.suggestions) > 0 {
+				// Tab completes with selected suggestion for autocompleted prompts
+				if hasSuggestions(m.shortcutMode) && m.showSuggestions && len(m.suggestions) > 0 {
 					selectedSuggestion := m.suggestions[m.suggestionIndex]
 					m.textInput.SetValue(selectedSuggestion)
 					m.showSuggestions = false
 					return m, textinput.Blink
 				}
 			case "up":
-				// Navigate up in suggestions for add/remove mode
-				if (m.shortcutMode == "add" || m.shortcutMode == "remove") && m.showSuggestions && len(m.suggestions) > 0 {
+				// Navigate up in suggestions for autocompleted prompts
+				if hasSuggestions(m.shortcutMode) && m.showSuggestions && len(m.suggestions) > 0 {
 					if m.suggestionIndex > 0 {
 						m.suggestionIndex--
 					} else {
@@ -524,8 +1069,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, nil
 				}
 			case "down":
-				// Navigate down in suggestions for add/remove mode
-				if (m.shortcutMode == "add" || m.shortcutMode == "remove") && m.showSuggestions && len(m.suggestions) > 0 {
+				// Navigate down in suggestions for autocompleted prompts
+				if hasSuggestions(m.shortcutMode) && m.showSuggestions && len(m.suggestions) > 0 {
 					if m.suggestionIndex < len(m.suggestions)-1 {
 						m.suggestionIndex++
 					} else {
@@ -550,6 +1095,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 					m.filterMode = false
 					m.updateViewportContent()
+				} else if m.searchMode {
+					m.searchMode = false
+					m.applySearch(val)
+				} else if m.queryMode {
+					m.queryMode = false
+					m.applyQuery(val)
 				} else if m.shortcutMode != "" {
 					// Handle shortcut mode input
 					m.textInput.Reset()
@@ -570,7 +1121,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							m.updateViewportContent()
 							return m, nil
 						}
-						return m, func() tea.Msg { return executeCommand("scale "+val, "", getCurrentDeploymentName(m.items, m.cursor))() }
+						cmd = m.startCommand("scale "+val, "", getCurrentDeploymentName(m.items, m.cursor))
+						return m, cmd
 					case "rollback":
 						// Validate rollback revision is a positive integer
 						if val == "" {
@@ -589,20 +1141,26 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							m.updateViewportContent()
 							return m, nil
 						}
-						return m, func() tea.Msg { return executeCommand("rollback "+val, helmRelease, "")() }
+						cmd = m.startCommand("rollback "+val, helmRelease, "")
+						return m, cmd
 					case "add":
+						return m, m.addTarget(val)
+					case "namespace":
 						val = strings.TrimSpace(val)
-						if val == "" {
-							m.rawContent = "Deployment name cannot be empty"
+						if !isValidK8sName(val) {
+							m.rawContent = "Invalid namespace name. Must be lowercase alphanumeric with hyphens only."
 							m.updateViewportContent()
 							return m, nil
 						}
-						if !isValidK8sName(val) {
-							m.rawContent = "Invalid deployment name. Must be lowercase alphanumeric with hyphens only."
+						return m, switchNamespaceCmd(client, val)
+					case "context":
+						val = strings.TrimSpace(val)
+						if val == "" {
+							m.rawContent = "Context name cannot be empty"
 							m.updateViewportContent()
 							return m, nil
 						}
-						return m, func() tea.Msg { return addTargetMsg{name: val} }
+						return m, switchContextCmd(val, Namespace)
 					case "remove":
 						if len(m.targets) <= 1 {
 							m.rawContent = "Cannot remove the last deployment target"
@@ -622,8 +1180,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 					// Special handling for :add and :remove which need to return a Msg, not a Cmd
 					parts := strings.Fields(val)
-					if len(parts) >= 2 && parts[0] == "add" {
-						return m, func() tea.Msg { return addTargetMsg{name: parts[1]} }
+					if len(parts) == 0 {
+						return m, nil
+					}
+					if parts[0] == "add" {
+						return m, m.addTarget(strings.Join(parts[1:], " "))
 					}
 					if parts[0] == "remove" {
 						var targetToRemove string
@@ -638,15 +1199,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 								return m, nil
 							}
 						}
-						// Check if target exists before removing
-						exists := false
-						for _, t := range m.targets {
-							if t == targetToRemove {
-								exists = true
-								break
-							}
-						}
-						if !exists {
+						if !m.hasTarget(targetToRemove) {
 							m.rawContent = fmt.Sprintf("Target '%s' not found in current deployments", targetToRemove)
 							m.updateViewportContent()
 							return m, nil
@@ -658,55 +1211,423 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						}
 						return m, func() tea.Msg { return removeTargetMsg{name: targetToRemove} }
 					}
-
-					// Find the helm release for current deployment context
-					deploymentName := getCurrentDeploymentName(m.items, m.cursor)
-					helmRelease := getCurrentHelmRelease(m.items, m.cursor, m.helmReleases)
-					cmds = append(cmds, executeCommand(val, helmRelease, deploymentName))
-				}
-				return m, tea.Batch(cmds...)
-
-			case "esc":
-				m.inputMode = false
-				m.filterMode = false
-				m.shortcutMode = ""
-				m.textInput.Blur()
-				m.textInput.Reset()
-				// Reset autocomplete state
-				m.showSuggestions = false
-				m.suggestions = []string{}
-				m.suggestionIndex = 0
-				return m, nil
-			}
-		}
-		// Store old value to detect changes
-		oldValue := m.textInput.Value()
-		m.textInput, cmd = m.textInput.Update(msg)
-
-		// If text changed in add/remove mode, update suggestions
-		if (m.shortcutMode == "add" || m.shortcutMode == "remove") && m.textInput.Value() != oldValue {
-			m.updateSuggestions()
-		}
-
-		return m, cmd
-	}
-
-	// --- NORMAL MODE ---
-	switch msg := msg.(type) {
-	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c":
-			return m, tea.Quit
-
-		case ":":
-			m.inputMode = true
-			m.filterMode = false
+					if parts[0] == "ns" {
+						if len(parts) != 2 {
+							m.rawContent = fmt.Sprintf("Usage: ns <namespace> (current: %s)", Namespace)
+							m.updateViewportContent()
+							return m, nil
+						}
+						if !isValidK8sName(parts[1]) {
+							m.rawContent = "Invalid namespace name. Must be lowercase alphanumeric with hyphens only."
+							m.updateViewportContent()
+							return m, nil
+						}
+						return m, switchNamespaceCmd(client, parts[1])
+					}
+					if parts[0] == "ctx" {
+						if len(parts) != 2 {
+							m.rawContent = fmt.Sprintf("Usage: ctx <context> (current: %s)", Context)
+							m.updateViewportContent()
+							return m, nil
+						}
+						return m, switchContextCmd(parts[1], Namespace)
+					}
+					if parts[0] == "refresh" {
+						// ":refresh <duration>" changes the refresh pace, ":refresh" alone shows it
+						if len(parts) < 2 {
+							m.statusMsg = "Refreshing every " + RefreshInterval.String()
+							return m, clearStatusLater()
+						}
+						d, err := parseRefreshInterval(parts[1])
+						if err != nil {
+							m.rawContent = fmt.Sprintf("Invalid refresh interval: %v. Usage: refresh <duration> (e.g. 5s)", err)
+							m.updateViewportContent()
+							return m, nil
+						}
+						m.statusMsg = "Refreshing every " + d.String()
+						return m, tea.Batch(m.setRefreshInterval(d), clearStatusLater())
+					}
+					if parts[0] == "buffer" {
+						// ":buffer <n>" changes how many log lines are kept, ":buffer" alone shows it
+						if len(parts) < 2 {
+							m.statusMsg = fmt.Sprintf("Keeping %d log lines", MaxLogLines)
+							return m, clearStatusLater()
+						}
+						n, err := parseMaxLogLines(parts[1])
+						if err != nil {
+							m.rawContent = fmt.Sprintf("Invalid buffer size: %v. Usage: buffer <lines> (e.g. 20000)", err)
+							m.updateViewportContent()
+							return m, nil
+						}
+						m.statusMsg = fmt.Sprintf("Keeping %d log lines", n)
+						return m, tea.Batch(m.setMaxLogLines(n), clearStatusLater())
+					}
+					if parts[0] == "since" {
+						// ":since <duration>" limits logs to a time window, ":since" alone clears it
+						since := time.Duration(0)
+						if len(parts) >= 2 && parts[1] != "off" {
+							d, err := time.ParseDuration(parts[1])
+							if err != nil || d <= 0 {
+								m.rawContent = fmt.Sprintf("Invalid duration %q. Usage: since <duration> (e.g. 5m, 1h30m) | since off", parts[1])
+								m.updateViewportContent()
+								return m, nil
+							}
+							since = d
+						}
+						m.logSince = since
+						m.statusMsg = fmt.Sprintf("Logs: last %d lines", DefaultLogTailLines)
+						if since > 0 {
+							m.statusMsg = "Logs since " + since.String()
+						}
+						cmds = append(cmds, clearStatusLater())
+						if len(m.items) > 0 && m.detailView == "" && m.follow == nil && tabName(m.items[m.cursor].Type, m.activeTab) == TabLogs {
+							cmds = append(cmds, fetchDetailsCmd(client, Namespace, m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(m.items[m.cursor])))
+						}
+						return m, tea.Batch(cmds...)
+					}
+					if parts[0] == "pf" {
+						// ":pf <local>:<remote>" forwards to the selected pod, ":pf stop" ends every forward
+						if len(parts) == 2 && parts[1] == "stop" {
+							count := len(m.forwards)
+							m.stopForwards()
+							m.statusMsg = fmt.Sprintf("Stopped %d port-forward(s)", count)
+							return m, clearStatusLater()
+						}
+						if len(parts) != 2 {
+							m.rawContent = "Usage: pf <localPort>:<remotePort> | pf stop"
+							m.updateViewportContent()
+							return m, nil
+						}
+						return m, m.startPortForward(parts[1])
+					}
+					if parts[0] == "theme" {
+						// ":theme <name>" switches the color theme for this session
+						if len(parts) != 2 {
+							m.rawContent = fmt.Sprintf("Usage: theme <name> (current: %s, available: %s)", activeThemeName, strings.Join(themeNames(), ", "))
+							m.updateViewportContent()
+							return m, nil
+						}
+						if err := setTheme(parts[1]); err != nil {
+							m.rawContent = "Cannot switch theme: " + err.Error()
+							m.updateViewportContent()
+							return m, nil
+						}
+						m.statusMsg = "Theme: " + activeThemeName
+						cmds = append(cmds, clearStatusLater())
+						// Highlighted details carry the old colors until fetched again
+						m.updateViewportContent()
+						if len(m.items) > 0 && m.detailView == "" && m.follow == nil {
+							cmds = append(cmds, fetchDetailsCmd(client, Namespace, m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(m.items[m.cursor])))
+						}
+						return m, tea.Batch(cmds...)
+					}
+					if parts[0] == "selector" {
+						if len(parts) < 3 {
+							m.rawContent = "Usage: selector <deployment> <key=val,...> | selector <deployment> reset"
+							m.updateViewportContent()
+							return m, nil
+						}
+						name, selector := parts[1], strings.Join(parts[2:], " ")
+						if !m.hasTarget(name) {
+							m.rawContent = fmt.Sprintf("Target '%s' not found in current deployments", name)
+							m.updateViewportContent()
+							return m, nil
+						}
+						if selector == "reset" {
+							delete(m.manualSelectors, name)
+							delete(m.selectors, name)
+							m.statusMsg = "Selector reset for " + name
+						} else {
+							if _, err := labels.Parse(selector); err != nil {
+								m.rawContent = fmt.Sprintf("Invalid selector %q: %v", selector, err)
+								m.updateViewportContent()
+								return m, nil
+							}
+							m.manualSelectors[name] = selector
+							m.selectors[name] = selector
+							m.statusMsg = fmt.Sprintf("Selector for %s set to %s", name, selector)
+						}
+						return m, tea.Batch(fetchDataCmd(client, Context, Namespace, m.dataGen, m.targets, copySelectorMap(m.manualSelectors)), tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
+							return clearStatusMsg{}
+						}))
+					}
+					if parts[0] == "snapshot" {
+						m.snap = &snapshot{content: stripANSI(m.rawContent), source: m.detailSource(), at: time.Now()}
+						m.statusMsg = fmt.Sprintf("Snapshot of %s taken at %s", m.snap.source, m.snap.at.Format("15:04:05"))
+						return m, tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
+							return clearStatusMsg{}
+						})
+					}
+					if parts[0] == "diff-snapshot" {
+						if m.snap == nil {
+							m.rawContent = "No snapshot taken yet - use :snapshot first"
+							m.updateViewportContent()
+							return m, nil
+						}
+						if m.detailView == "" {
+							// The pane already shows the live selection
+							m.detailView = "diff-snapshot"
+							m.rawContent = renderSnapshotDiff(*m.snap, stripANSI(m.rawContent), m.itemSource())
+							m.updateViewportContent()
+							return m, nil
+						}
+						m.detailView = "diff-snapshot"
+						if len(m.items) == 0 {
+							return m, nil
+						}
+						return m, fetchDetailsCmd(client, Namespace, m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(m.items[m.cursor]))
+					}
+					if parts[0] == "pods" {
+						// ":pods" toggles, ":pods <text>" (re)enters filtered by name
+						m.podsFilter = strings.Join(parts[1:], " ")
+						m.podsMode = !m.podsMode || m.podsFilter != ""
+						m.items = nil
+						m.cursor, m.listOffset, m.activeTab = 0, 0, 0
+						m.detailView = ""
+						return m, m.refreshCmd()
+					}
+					if parts[0] == "dashboard" {
+						m.dashboardMode = true
+						return m, nil
+					}
+					if parts[0] == "triage" {
+						var unhealthy []item
+						for _, it := range m.items {
+							if it.Type == "POD" && podHealth(it.Status) != healthOK && !strings.HasPrefix(it.Status, "Terminating") {
+								unhealthy = append(unhealthy, it)
+							}
+						}
+						m.detailView = "triage"
+						m.rawContent = fmt.Sprintf("Collecting logs from %d unhealthy pod(s)...", len(unhealthy))
+						m.updateViewportContent()
+						return m, triageCmd(client, Namespace, unhealthy)
+					}
+					if parts[0] == "nettest" {
+						if ReadOnly {
+							m.rawContent = "nettest is disabled in read-only mode"
+							m.updateViewportContent()
+							return m, nil
+						}
+						var podName, target string
+						switch len(parts) {
+						case 2:
+							if len(m.items) > 0 && m.items[m.cursor].Type == "POD" {
+								podName = m.items[m.cursor].Name
+							}
+							target = parts[1]
+						case 3:
+							podName, target = parts[1], parts[2]
+						}
+						if podName == "" || target == "" {
+							m.rawContent = "Usage: nettest [pod] <host:port> (pod defaults to the selected pod)"
+							m.updateViewportContent()
+							return m, nil
+						}
+						m.detailView = "nettest"
+						m.rawContent = fmt.Sprintf("Testing %s -> %s...", podName, target)
+						m.updateViewportContent()
+						return m, netTestCmd(client, Namespace, podName, target)
+					}
+					if parts[0] == "search-logs" {
+						if len(parts) < 2 {
+							m.rawContent = "Usage: search-logs <pattern> (searches the selected pod, or all pods of the selected deployment)"
+							m.updateViewportContent()
+							return m, nil
+						}
+						pattern := strings.Join(parts[1:], " ")
+						re, err := regexp.Compile("(?i)" + pattern)
+						if err != nil {
+							// Not a valid regexp - search for the literal text
+							re = regexp.MustCompile("(?i)" + regexp.QuoteMeta(pattern))
+						}
+						var pods []string
+						selector := ""
+						if len(m.items) > 0 && m.items[m.cursor].Type == "POD" {
+							pods = []string{m.items[m.cursor].Name}
+						} else {
+							selector = m.selectors[getCurrentDeploymentName(m.items, m.cursor)]
+						}
+						if len(pods) == 0 && selector == "" {
+							m.rawContent = "search-logs: select a pod or a deployment first"
+							m.updateViewportContent()
+							return m, nil
+						}
+						m.detailView = "search-logs"
+						m.rawContent = fmt.Sprintf("Searching logs for /%s/...", pattern)
+						m.updateViewportContent()
+						return m, searchLogsCmd(client, Namespace, pods, selector, re)
+					}
+					if parts[0] == "events" {
+						// Every event of the namespace, kept up to date until the selection changes
+						m.detailView = "events"
+						return m, fetchAllEventsCmd(client, Namespace)
+					}
+					if parts[0] == "logs" && len(parts) > 1 && parts[1] == "grep" {
+						// ":logs grep ..." reads like the command it is short for
+						parts = parts[1:]
+					}
+					if parts[0] == "grep" {
+						if len(parts) == 2 && parts[1] == "off" {
+							return m, m.setGrep(nil)
+						}
+						g, err := parseGrep(parts[1:])
+						if err != nil {
+							m.rawContent = err.Error()
+							m.updateViewportContent()
+							return m, nil
+						}
+						return m, m.setGrep(g)
+					}
+					if parts[0] == "find" {
+						if len(parts) != 2 {
+							m.rawContent = "Usage: find <text> (lists pods in all namespaces whose name contains text), find <namespace>/<pod> (switches to it)"
+							m.updateViewportContent()
+							return m, nil
+						}
+						if ns, pod, ok := strings.Cut(parts[1], "/"); ok {
+							if !isValidK8sName(ns) || pod == "" {
+								m.rawContent = "Usage: find <namespace>/<pod>"
+								m.updateViewportContent()
+								return m, nil
+							}
+							return m, focusPodCmd(client, ns, pod)
+						}
+						m.detailView = "find"
+						m.rawContent = fmt.Sprintf("Searching all namespaces for pods named like %q...", parts[1])
+						m.updateViewportContent()
+						return m, findPodsCmd(client, parts[1])
+					}
+					if parts[0] == "helm" {
+						release := getCurrentHelmRelease(m.items, m.cursor, m.helmReleases)
+						if len(parts) < 2 || parts[1] != "diff" {
+							m.rawContent = "Usage: helm diff <rev1> [rev2] (rev2 defaults to the deployed revision)"
+							m.updateViewportContent()
+							return m, nil
+						}
+						from, to, err := parseHelmDiffArgs(parts[2:])
+						if err == nil && release == "" {
+							err = fmt.Errorf("helm diff: select a target managed by Helm first")
+						}
+						if err != nil {
+							m.rawContent = err.Error()
+							m.updateViewportContent()
+							return m, nil
+						}
+						m.detailView = "helm-diff"
+						m.rawContent = fmt.Sprintf("Diffing the manifests of %s...", release)
+						m.updateViewportContent()
+						return m, helmDiffCmd(client, Namespace, release, from, to)
+					}
+					if len(parts) == 2 && parts[0] == "restart" && parts[1] == "all" {
+						return m, m.startRestartAll()
+					}
+					if parts[0] == "undo" {
+						return m, m.undoScale()
+					}
+					if parts[0] == "save" {
+						// ":save" writes the targets back to the config file
+						return m, m.saveConfigCmd()
+					}
+					if parts[0] == "debug-log" {
+						// Keep showing the app's own log until the selection changes
+						m.detailView = "debug-log"
+						return m, fetchDebugLogCmd()
+					}
+
+					// Find the helm release for current deployment context
+					deploymentName := getCurrentDeploymentName(m.items, m.cursor)
+					helmRelease := getCurrentHelmRelease(m.items, m.cursor, m.helmReleases)
+					cmds = append(cmds, m.startCommand(val, helmRelease, deploymentName))
+				}
+				return m, tea.Batch(cmds...)
+
+			case "esc":
+				m.inputMode = false
+				m.filterMode = false
+				m.searchMode = false
+				m.queryMode = false
+				m.shortcutMode = ""
+				m.textInput.Blur()
+				m.textInput.Reset()
+				// Reset autocomplete state
+				m.showSuggestions = false
+				m.suggestions = []string{}
+				m.suggestionIndex = 0
+				return m, nil
+			}
+		}
+		// Store old value to detect changes
+		oldValue := m.textInput.Value()
+		m.textInput, cmd = m.textInput.Update(msg)
+
+		// If text changed in an autocompleted prompt, update suggestions
+		if hasSuggestions(m.shortcutMode) && m.textInput.Value() != oldValue {
+			m.updateSuggestions()
+		}
+
+		return m, cmd
+	}
+
+	// --- HELP OVERLAY ---
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.showHelp {
+		return m, m.updateHelp(keyMsg)
+	}
+
+	// --- DASHBOARD MODE ---
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.dashboardMode {
+		var handled bool
+		if m, cmd, handled = m.updateDashboard(keyMsg); handled {
+			return m, cmd
+		}
+	}
+
+	// --- CONTAINER PICKER ---
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.picker != nil {
+		return m, m.updatePicker(keyMsg)
+	}
+
+	// --- MOUSE ---
+	if mouseMsg, ok := msg.(tea.MouseMsg); ok {
+		return m, m.updateMouse(mouseMsg)
+	}
+
+	// --- NORMAL MODE ---
+	prevCursor, prevTab := m.cursor, m.activeTab
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.partialKey == Keys.Yank {
+			// "yp" and "ys" copy the item's name or selector, "yy" the pane
+			m.partialKey = ""
+			if cmd, ok := m.quickYank(msg.String()); ok {
+				return m, cmd
+			}
+			yank := m.yankPane()
+			if msg.String() == Keys.Yank {
+				return m, yank
+			}
+			// Any other key ends the sequence and still does what it does
+			next, cmd := m.Update(msg)
+			return next, tea.Batch(yank, cmd)
+		}
+		switch msg.String() {
+		case "q", "ctrl+c":
+			return m, tea.Quit
+
+		case "?":
+			m.partialKey = ""
+			m.showHelp = true
+			m.helpScroll = 0
+			return m, nil
+
+		case ":":
+			m.inputMode = true
+			m.filterMode = false
 			m.textInput.Prompt = ": "
 			m.textInput.Placeholder = "scale 3 | restart | add <name> | remove <name>"
 			m.textInput.Focus()
 			return m, textinput.Blink
 
-		case "/":
+		case Keys.Filter:
 			m.inputMode = true
 			m.filterMode = true
 			m.textInput.Prompt = "/ "
@@ -716,38 +1637,261 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.updateViewportContent()
 			return m, textinput.Blink
 
+		case "ctrl+_", "ctrl+/":
+			// Ctrl+/ arrives as Ctrl+_ in most terminals
+			return m, m.startSearch()
+
+		case "\\":
+			return m, m.startQuery()
+
+		case "N":
+			if m.searchQuery != "" {
+				return m, m.jumpToMatch(-1)
+			}
+
 		case "esc":
+			if m.follow != nil {
+				m.stopFollow()
+				if len(m.items) > 0 {
+					cmds = append(cmds, fetchDetailsCmd(client, Namespace, m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(m.items[m.cursor])))
+				}
+			}
 			if m.activeFilter != "" {
 				m.activeFilter = ""
 				m.filterRegex = nil
 				m.updateViewportContent()
 			}
+			if m.searchQuery != "" {
+				m.clearSearch()
+			}
+			if m.jsonQuery != "" {
+				m.applyQuery("")
+			}
+			if m.rollout != nil && m.rollout.err != nil {
+				m.rollout = nil
+			}
+			if m.configErr != nil {
+				m.configErr = nil
+				m.updateViewportContent()
+			}
+
+		case "c":
+			// Pick one container of a multi-container pod for its logs
+			m.partialKey = ""
+			cmds = append(cmds, m.openContainerPicker())
+
+		case "x":
+			// Shell into the selected pod, suspending the TUI until it exits
+			m.partialKey = ""
+			cmds = append(cmds, m.openShell())
+
+		case "e":
+			// Edit the selected object in $EDITOR and apply it back
+			cmds = append(cmds, m.startEdit())
+
+		case "F":
+			// Follow the Logs tab live, or stop following
+			m.partialKey = ""
+			if m.follow != nil {
+				m.stopFollow()
+				if len(m.items) > 0 {
+					cmds = append(cmds, fetchDetailsCmd(client, Namespace, m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(m.items[m.cursor])))
+				}
+				break
+			}
+			cmds = append(cmds, m.startFollow())
+
+		case "ctrl+k":
+			// Delete the selected pod after a y/N confirmation
+			m.partialKey = ""
+			return m, m.promptDeletePod()
 
 		case "ctrl+f":
-			cmds = append(cmds, fetchDataCmd(m.targets, m.selectors))
+			cmds = append(cmds, m.refreshCmd())
 
-		case "f":
+		case "z":
+			// Pause or resume automatic refreshes
+			m.partialKey = ""
+			m.paused = !m.paused
+			if m.paused {
+				m.statusMsg = "Auto-refresh paused"
+				return m, clearStatusLater()
+			}
+			m.statusMsg = "Auto-refresh resumed"
+			if m.fetching {
+				return m, clearStatusLater()
+			}
+			m.fetching = true
+			return m, tea.Batch(m.refreshCmd(), clearStatusLater())
+
+		case "d":
+			// Toggle the dashboard overview
+			m.partialKey = ""
+			m.dashboardMode = !m.dashboardMode
+			return m, nil
+
+		case Keys.ToggleFormat:
 			// Toggle log format mode
 			m.partialKey = ""
 			m.logFormatMode = !m.logFormatMode
 			m.updateViewportContent()
+			// Remember the choice for the next launch: in the config, or in
+			// the saved state when there is no config location
+			if configFilePath != "" {
+				return m, m.scheduleConfigSave()
+			}
+			rawLogs := !m.logFormatMode
+			m.saved.RawLogs = &rawLogs
+			return m, saveStateCmd(m.saved)
+
+		case "<", ">":
+			// Move the split between the resource list and the details
+			m.partialKey = ""
+			delta := LeftPaneRatioStep
+			if msg.String() == "<" {
+				delta = -delta
+			}
+			return m, m.resizePanes(delta)
+
+		case "|":
+			// Stack the list above the details, or put them side by side
+			m.partialKey = ""
+			return m, m.toggleLayout()
+
+		case "w":
+			// Toggle wrapping; unwrapped lines scroll with left/right (h/l)
+			m.partialKey = ""
+			m.wrapMode = !m.wrapMode
+			m.viewport.SetXOffset(0)
+			m.updateViewportContent()
+			return m, nil
+
+		case "#":
+			// Toggle line numbers in the details pane
+			m.partialKey = ""
+			m.lineNumbers = !m.lineNumbers
+			m.updateViewportContent()
 			return m, nil
 
-		case "r":
-			if m.partialKey == "r" {
+		case "S":
+			// Show/hide service-account tokens, the root CA and Helm release secrets
+			m.partialKey = ""
+			m.showSystem = !m.showSystem
+			return m, m.refreshCmd()
+
+		case "o":
+			m.partialKey = ""
+			if len(m.items) > 0 && m.items[m.cursor].Type == "HELM" {
+				// Reverse the order of the Helm History tab
+				m.helmOldestFirst = !m.helmOldestFirst
+				if m.detailView == "" {
+					cmds = append(cmds, fetchDetailsCmd(client, Namespace, m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(m.items[m.cursor])))
+				}
+			} else {
+				cmds = append(cmds, m.cyclePodSort())
+			}
+
+		case "L":
+			// Cycle the minimum log level shown: ALL -> INFO -> WARN -> ERROR
+			m.partialKey = ""
+			m.minLogLevel = nextLogLevel(m.minLogLevel)
+			if len(m.items) > 0 && m.detailView == "" && m.follow == nil {
+				cmds = append(cmds, fetchDetailsCmd(client, Namespace, m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(m.items[m.cursor])))
+			}
+			if m.peek != nil && m.peek.live {
+				cmds = append(cmds, peekCmd(m.peek, copySelectorMap(m.selectors), m.multiContainerInfo))
+			}
+			m.statusMsg = "Log level: all"
+			if m.minLogLevel != "" {
+				m.statusMsg = "Log level: " + m.minLogLevel + " and above"
+			}
+			cmds = append(cmds, clearStatusLater())
+
+		case "U":
+			// Collapse runs of identical log lines into one with an (xN) count
+			m.partialKey = ""
+			m.collapseRepeats = !m.collapseRepeats
+			if len(m.items) > 0 && m.detailView == "" && m.follow == nil {
+				cmds = append(cmds, fetchDetailsCmd(client, Namespace, m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(m.items[m.cursor])))
+			}
+			if m.peek != nil && m.peek.live {
+				cmds = append(cmds, peekCmd(m.peek, copySelectorMap(m.selectors), m.multiContainerInfo))
+			}
+			m.statusMsg = "Repeated log lines: shown"
+			if m.collapseRepeats {
+				m.statusMsg = "Repeated log lines: collapsed"
+			}
+			cmds = append(cmds, clearStatusLater())
+
+		case "J", "E":
+			// 'J' cycles JSON logs through pretty-printed, flattened and
+			// columns; 'E' expands the fields the columns leave out
+			m.partialKey = ""
+			if msg.String() == "J" {
+				m.jsonMode = nextJSONLogMode(m.jsonMode)
+			} else if !m.toggleJSONColumnsExpanded() {
+				return m, clearStatusLater()
+			}
+			if len(m.items) > 0 && m.detailView == "" && m.follow == nil {
+				cmds = append(cmds, fetchDetailsCmd(client, Namespace, m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(m.items[m.cursor])))
+			}
+			if m.peek != nil && m.peek.live {
+				cmds = append(cmds, peekCmd(m.peek, copySelectorMap(m.selectors), m.multiContainerInfo))
+			}
+
+		case "V":
+			// Toggle the previous container instance's logs on a pod's Logs tab
+			m.partialKey = ""
+			if len(m.items) == 0 || m.items[m.cursor].Type != "POD" || tabName("POD", m.activeTab) != TabLogs {
+				m.statusMsg = "Previous logs work on the Logs tab of a pod"
+				cmds = append(cmds, clearStatusLater())
+			} else {
+				m.stopFollow()
+				if m.previousPod == "" {
+					m.previousPod = m.items[m.cursor].Name
+				} else {
+					m.previousPod = ""
+				}
+				if m.detailView == "" {
+					cmds = append(cmds, fetchDetailsCmd(client, Namespace, m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(m.items[m.cursor])))
+				}
+			}
+
+		case "t":
+			// Toggle RFC3339 timestamps on log lines; a running follow restarts with them
+			m.partialKey = ""
+			m.timestamps = !m.timestamps
+			if m.peek != nil {
+				m.peek.scope.timestamps = m.timestamps
+			}
+			if m.follow != nil {
+				cmds = append(cmds, m.startFollow())
+			} else if len(m.items) > 0 && m.detailView == "" {
+				cmds = append(cmds, fetchDetailsCmd(client, Namespace, m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(m.items[m.cursor])))
+			}
+			if m.peek != nil && m.peek.live {
+				cmds = append(cmds, peekCmd(m.peek, copySelectorMap(m.selectors), m.multiContainerInfo))
+			}
+
+		case Keys.Restart:
+			if m.partialKey == Keys.Restart {
 				// Double 'r' - execute restart immediately
 				m.partialKey = ""
+				if len(m.items) > 0 && m.items[m.cursor].Type == "POD" {
+					// Restart just this pod: delete it and let its owner recreate it
+					return m, m.promptDeletePod()
+				}
 				deploymentName := getCurrentDeploymentName(m.items, m.cursor)
 				if deploymentName != "" {
 					helmRelease := getCurrentHelmRelease(m.items, m.cursor, m.helmReleases)
-					cmds = append(cmds, executeCommand("restart", helmRelease, deploymentName))
+					cmds = append(cmds, m.startCommand("restart", helmRelease, deploymentName))
 				}
 			} else {
 				// Start of 'r' sequence for 'rr' (restart)
-				m.partialKey = "r"
+				m.partialKey = Keys.Restart
 			}
 
-		case "-":
+		case Keys.RemoveTarget:
 			// Remove shortcut with autocomplete - show currently monitored deployments
 			m.partialKey = "" // Clear any partial key
 			m.inputMode = true
@@ -764,7 +1908,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.showSuggestions = len(m.suggestions) > 0
 			return m, textinput.Blink
 
-		case "R":
+		case Keys.Rollback:
 			// Rollback shortcut (capital R) - prompt for revision
 			m.partialKey = "" // Clear any partial key
 			m.inputMode = true
@@ -776,9 +1920,14 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.textInput.Focus()
 			return m, textinput.Blink
 
-		case "s":
+		case Keys.Scale:
 			// Scale shortcut - prompt for replicas
 			m.partialKey = "" // Clear any partial key
+			kind, _ := parseTarget(getCurrentDeploymentName(m.items, m.cursor))
+			if err := workloadActionError(kind, "scale"); err != nil {
+				m.statusMsg = err.Error()
+				return m, clearStatusLater()
+			}
 			m.inputMode = true
 			m.filterMode = false
 			m.shortcutMode = "scale"
@@ -788,7 +1937,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.textInput.Focus()
 			return m, textinput.Blink
 
-		case "+":
+		case Keys.AddTarget:
 			// Add shortcut - prompt for deployment name with autocomplete
 			m.partialKey = "" // Clear any partial key
 			m.inputMode = true
@@ -803,9 +1952,46 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.suggestionIndex = 0
 			m.showSuggestions = false
 			// Fetch available deployments for autocomplete
-			return m, tea.Batch(textinput.Blink, fetchAvailableDeployments())
+			return m, tea.Batch(textinput.Blink, fetchAvailableDeployments(client, Namespace))
 
-		case "1", "2", "3", "4", "5":
+		case "n":
+			if m.searchQuery != "" {
+				// Next search match; Esc ends the search and frees n again
+				m.partialKey = ""
+				return m, m.jumpToMatch(1)
+			}
+			// Namespace shortcut - prompt with the cluster's namespaces
+			m.partialKey = "" // Clear any partial key
+			m.inputMode = true
+			m.filterMode = false
+			m.shortcutMode = "namespace"
+			m.textInput.Prompt = "Namespace: "
+			m.textInput.Placeholder = "Type to search namespaces..."
+			m.textInput.Reset()
+			m.textInput.Focus()
+			// Reset suggestions state
+			m.suggestions = []string{}
+			m.suggestionIndex = 0
+			m.showSuggestions = false
+			return m, tea.Batch(textinput.Blink, fetchAvailableNamespaces(client))
+
+		case "C":
+			// Context shortcut - prompt with the kubeconfig's contexts
+			m.partialKey = "" // Clear any partial key
+			m.inputMode = true
+			m.filterMode = false
+			m.shortcutMode = "context"
+			m.textInput.Prompt = "Context: "
+			m.textInput.Placeholder = "Type to search contexts..."
+			m.textInput.Reset()
+			m.textInput.Focus()
+			// The kubeconfig is local, so suggestions are ready right away
+			m.suggestions = contextSuggestions()
+			m.suggestionIndex = 0
+			m.showSuggestions = len(m.suggestions) > 0
+			return m, textinput.Blink
+
+		case "1", "2", "3", "4", "5", "6":
 			m.partialKey = "" // Clear any partial key
 			target := ""
 			switch msg.String() {
@@ -819,19 +2005,21 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				target = "SEC"
 			case "5":
 				target = "POD"
+			case "6":
+				target = "SVC"
 			}
 
 			// Find next index
 			start := 0
 			// If we are currently on this type, start searching from next item
-			if len(m.items) > 0 && m.items[m.cursor].Type == target {
+			if len(m.items) > 0 && jumpType(m.items[m.cursor].Type) == target {
 				start = m.cursor + 1
 			}
 
 			found := -1
 			// Search forward
 			for i := start; i < len(m.items); i++ {
-				if m.items[i].Type == target {
+				if jumpType(m.items[i].Type) == target {
 					found = i
 					break
 				}
@@ -839,7 +2027,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Wrap around if not found
 			if found == -1 {
 				for i := 0; i < start; i++ {
-					if m.items[i].Type == target {
+					if jumpType(m.items[i].Type) == target {
 						found = i
 						break
 					}
@@ -848,56 +2036,78 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			if found != -1 {
 				m.cursor = found
-				// Adjust scroll
-				if m.cursor < m.listOffset {
-					m.listOffset = m.cursor
-				} else if m.cursor >= m.listOffset+m.listHeight {
-					m.listOffset = m.cursor - m.listHeight + 1
-				}
+				m.scrollToCursor()
 				// Refresh details
 				m.activeTab = 0
-				cmds = append(cmds, fetchDetailsCmd(m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo))
+				m.detailView = ""
+				cmds = append(cmds, fetchDetailsCmd(client, Namespace, m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(m.items[m.cursor])))
+			}
+
+		case "D", "P":
+			// Jump to the deployment owning the selection (D) or its first pod (P)
+			m.partialKey = ""
+			depIdx := getCurrentDeploymentIndex(m.items, m.cursor)
+			if depIdx == -1 {
+				break
+			}
+			found := depIdx
+			if msg.String() == "P" {
+				found = -1
+				for i := depIdx + 1; i < len(m.items) && m.items[i].Type != "HDR"; i++ {
+					if m.items[i].Type == "POD" {
+						found = i
+						break
+					}
+				}
+			}
+			if found != -1 && found != m.cursor {
+				m.cursor = found
+				m.scrollToCursor()
+				m.activeTab = 0
+				m.detailView = ""
+				cmds = append(cmds, fetchDetailsCmd(client, Namespace, m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(m.items[m.cursor])))
 			}
 
 		case "up", "k":
 			if m.cursor > 0 {
 				m.cursor--
-				if m.cursor < m.listOffset {
-					m.listOffset = m.cursor
-				}
+				m.scrollToCursor()
 				m.activeTab = 0
-				cmds = append(cmds, fetchDetailsCmd(m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo))
+				m.detailView = ""
+				cmds = append(cmds, fetchDetailsCmd(client, Namespace, m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(m.items[m.cursor])))
 			}
 		case "down", "j":
 			if m.cursor < len(m.items)-1 {
 				m.cursor++
-				if m.cursor >= m.listOffset+m.listHeight {
-					m.listOffset++
-				}
+				m.scrollToCursor()
 				m.activeTab = 0
-				cmds = append(cmds, fetchDetailsCmd(m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo))
+				m.detailView = ""
+				cmds = append(cmds, fetchDetailsCmd(client, Namespace, m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(m.items[m.cursor])))
 			}
 
 		case "tab":
 			if len(m.items) > 0 {
+				m.detailView = ""
 				curr := m.items[m.cursor]
-				if curr.Type == "DEP" {
-					// Cycle 0 (YAML) -> 1 (Events) -> 2 (Logs) -> 0
-					m.activeTab = (m.activeTab + 1) % DeploymentTabCount
-					cmds = append(cmds, fetchDetailsCmd(curr, m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo))
-				} else if curr.Type == "POD" {
-					m.activeTab = (m.activeTab + 1) % PodTabCount
-					cmds = append(cmds, fetchDetailsCmd(curr, m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo))
+				if tabCount := len(tabSets[curr.Type]); tabCount > 0 {
+					// Cycle through the configured tabs for this type
+					m.activeTab = (m.activeTab + 1) % tabCount
+					cmds = append(cmds, fetchDetailsCmd(client, Namespace, curr, m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(curr)))
+				} else if curr.Type == "CM" {
+					// Cycle 0 (YAML) -> 1..N (one key each) -> 0
+					m.activeTab = (m.activeTab + 1) % (len(m.cmKeys) + 1)
+					cmds = append(cmds, fetchDetailsCmd(client, Namespace, curr, m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(curr)))
 				} else {
 					// Reset tab for other resource types
 					m.activeTab = 0
-					cmds = append(cmds, fetchDetailsCmd(curr, m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo))
+					cmds = append(cmds, fetchDetailsCmd(client, Namespace, curr, m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(curr)))
 				}
 			}
 
 		case "enter":
 			if len(m.items) > 0 {
-				cmds = append(cmds, fetchDetailsCmd(m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo))
+				m.detailView = ""
+				cmds = append(cmds, fetchDetailsCmd(client, Namespace, m.items[m.cursor], m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(m.items[m.cursor])))
 			}
 
 		// Viewport scrolling keybindings
@@ -920,15 +2130,40 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Scroll viewport up one page
 			m.viewport.ViewUp()
 
-		case "y":
-			// Yank (copy) right pane content to clipboard (vim-style)
+		case "p":
+			// Pin the current detail view below the main pane, or unpin it
+			m.partialKey = ""
+			if m.peek != nil {
+				m.peek = nil
+			} else {
+				m.pinPeek()
+			}
+			m.viewport.Height = m.viewportHeight()
+			m.updateViewportContent()
+			return m, nil
+
+		case Keys.Yank:
+			// Yank (copy) the right pane, unless 'p' or 's' follow within
+			// YankKeyTimeout to copy the name or selector instead
+			m.partialKey = Keys.Yank
+			m.yankSeq++
+			return m, yankTimeoutCmd(m.yankSeq)
+
+		case "Y":
+			// Yank the whole unfiltered buffer
 			m.partialKey = ""
-			return m, yankCmd(m.rawContent)
+			return m, m.yank(m.rawContent)
 
 		default:
 			// Clear partial key for any unhandled input
 			m.partialKey = ""
 		}
+
+		// Leaving the followed view (or refreshing it with enter) ends the stream
+		if m.follow != nil && (m.cursor != prevCursor || m.activeTab != prevTab || msg.String() == "enter") {
+			m.stopFollow()
+		}
+		m.dropPodLogChoices()
 	}
 
 	m.viewport, cmd = m.viewport.Update(msg)
@@ -936,8 +2171,87 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// scrollToCursor scrolls the sidebar as little as possible to show the
+// cursor, which the mouse wheel may have scrolled out of view
+func (m *model) scrollToCursor() {
+	if m.cursor < m.listOffset {
+		m.listOffset = m.cursor
+	} else if m.cursor >= m.listOffset+m.listHeight {
+		m.listOffset = m.cursor - m.listHeight + 1
+	}
+}
+
+// dropPodLogChoices forgets the picked container and the previous-logs
+// choice once the selection has moved off the pod they were made for
+func (m *model) dropPodLogChoices() {
+	if m.containerPod != "" && (len(m.items) == 0 || m.items[m.cursor].Name != m.containerPod) {
+		m.container, m.containerPod = "", ""
+	}
+	if m.previousPod != "" && (len(m.items) == 0 || m.items[m.cursor].Name != m.previousPod) {
+		m.previousPod = ""
+	}
+}
+
+// viewportHeight is the main detail pane's height, leaving room for the peek pane
+func (m model) viewportHeight() int {
+	height := maxInt(m.height-HeaderHeight-FooterHeight-UILayoutPadding, 0)
+	if m.stacked() {
+		// Below the list pane, less the tab bar and the border
+		height = maxInt(m.height-FooterHeight-m.listPaneHeight()-4, 0)
+	}
+	if m.peek != nil {
+		height = maxInt(height-PeekHeight-2, 0)
+	}
+	return height
+}
+
+// detailsKeyOf identifies what a detailsMsg shows: the item's type and name
+// and the tab
+func detailsKeyOf(it item, tab int) string {
+	return fmt.Sprintf("%s/%s/%d", it.Type, it.Name, tab)
+}
+
+// renderDetails formats fetched details for display: highlighting code,
+// formatting logs, or the error. it and tab identify what was fetched.
+func (m model) renderDetails(msg detailsMsg, it item, tab int) string {
+	if msg.err != nil {
+		return fmt.Sprintf("Error: %v", msg.err)
+	}
+	if msg.helmRevisions != nil {
+		return renderHelmHistory(msg.helmRevisions, m.helmOldestFirst)
+	}
+	if msg.styled {
+		return msg.content
+	}
+	if msg.lang != "" {
+		return highlight(msg.content, msg.lang)
+	}
+	if msg.isYaml {
+		if m.jsonQuery != "" && tabName(it.Type, tab) == TabYAML {
+			return queryJSON(msg.content, m.jsonQuery)
+		}
+		return highlight(msg.content, "yaml")
+	}
+	if msg.isLog || tabName(it.Type, tab) == TabLogs {
+		content := msg.content
+		if m.logGrep != nil {
+			m.logGrep.reset()
+			if content = m.logGrep.filter(content); content == "" {
+				return fmt.Sprintf("No log lines match :grep %s", m.logGrep.pattern)
+			}
+		}
+		content = filterLogLevel(content, m.minLogLevel, !HideUnleveledLogs)
+		if content == "" && strings.TrimSpace(msg.content) != "" {
+			return fmt.Sprintf("No log lines at %s or above (press L to change the level filter)", m.minLogLevel)
+		}
+		return truncateLogLines(processLogContent(content, it.Type, it.Name, m.logFormatMode, m.jsonMode, m.collapseRepeats))
+	}
+	return msg.content
+}
+
 func (m *model) updateViewportContent() {
 	content := strings.ReplaceAll(m.rawContent, "\r\n", "\n")
+	var lineNos []int // original number of each displayed line, nil when not numbered
 
 	if m.activeFilter != "" {
 		lines := strings.Split(content, "\n")
@@ -953,28 +2267,98 @@ func (m *model) updateViewportContent() {
 			}
 		}
 
-		for _, line := range lines {
+		for i, line := range lines {
 			if re != nil && re.MatchString(line) {
 				highlighted := re.ReplaceAllStringFunc(line, func(s string) string {
 					return styleHighlight.Render(s)
 				})
 				filtered = append(filtered, highlighted)
+				if m.lineNumbers {
+					lineNos = append(lineNos, i+1)
+				}
 			}
 		}
 
 		if len(filtered) == 0 {
+			m.viewContent = ""
 			content = "No results found for filter: " + m.activeFilter
 		} else {
 			content = strings.Join(filtered, "\n")
+			m.viewContent = content
+		}
+	} else {
+		m.viewContent = content
+		if m.lineNumbers {
+			lineNos = make([]int, strings.Count(content, "\n")+1)
+			for i := range lineNos {
+				lineNos[i] = i + 1
+			}
 		}
 	}
+	content = m.highlightSearch(content)
+	banner := ""
+	if m.configErr != nil {
+		banner = styleErr.Render("Config error: "+m.configErr.Error()+" (Esc to dismiss)") + "\n\n"
+	}
+
+	wrapWidth := 0
+	if m.wrapMode {
+		wrapWidth = maxInt(m.viewport.Width-2, MinWrapWidth)
+	} else {
+		// Lines go to the viewport as is, which cuts them at the scroll
+		// offset without breaking the filter highlight's escape codes.
+		// Tabs are expanded the way lipgloss does when wrapping.
+		content = strings.ReplaceAll(content, "\t", "    ")
+	}
+
+	if lineNos != nil {
+		// Numbered before wrapping so a wrapped line keeps a single number;
+		// search matches are indexed without the gutter
+		plain, numbered := numberLines(strings.Split(content, "\n"), lineNos, wrapWidth)
+		if banner != "" {
+			banner = m.wrapDetails(banner, wrapWidth)
+		}
+		m.indexSearchMatches(banner + plain)
+		m.viewport.SetContent(banner + numbered)
+		return
+	}
+
+	rendered := m.wrapDetails(banner+content, wrapWidth)
+	m.indexSearchMatches(rendered)
+	m.viewport.SetContent(rendered)
+}
+
+// wrapDetails wraps content to width, 0 leaves it as is
+func (m *model) wrapDetails(content string, width int) string {
+	if width == 0 {
+		return content
+	}
+	return lipgloss.NewStyle().Width(width).Render(content)
+}
 
-	wrapWidth := m.viewport.Width - 2
-	if wrapWidth < MinWrapWidth {
-		wrapWidth = MinWrapWidth
+// numberLines prefixes each line with its number from numbers, right-aligned
+// and dimmed. With wrapWidth > 0 every line is wrapped to fit next to the
+// gutter and its continuation lines are indented instead of numbered.
+// plain is the same layout without the gutter.
+func numberLines(lines []string, numbers []int, wrapWidth int) (plain, numbered string) {
+	digits := len(strconv.Itoa(numbers[len(numbers)-1]))
+	blank := strings.Repeat(" ", digits+1)
+	var plainLines, numberedLines []string
+	for i, line := range lines {
+		parts := []string{line}
+		if wrapWidth > 0 {
+			parts = strings.Split(lipgloss.NewStyle().Width(maxInt(wrapWidth-digits-1, MinWrapWidth)).Render(line), "\n")
+		}
+		for j, part := range parts {
+			gutter := blank
+			if j == 0 {
+				gutter = styleDim.Render(fmt.Sprintf("%*d", digits, numbers[i])) + " "
+			}
+			plainLines = append(plainLines, part)
+			numberedLines = append(numberedLines, gutter+part)
+		}
 	}
-	wrapper := lipgloss.NewStyle().Width(wrapWidth)
-	m.viewport.SetContent(wrapper.Render(content))
+	return strings.Join(plainLines, "\n"), strings.Join(numberedLines, "\n")
 }
 
 func (m model) View() string {
@@ -982,28 +2366,28 @@ func (m model) View() string {
 		return "Initializing..."
 	}
 
-	leftWidth := int(float64(m.width) * LeftPaneWidthRatio)
-	if leftWidth < MinLeftPaneWidth {
-		leftWidth = MinLeftPaneWidth
+	if m.dashboardMode && !m.inputMode {
+		hint := " [←↑↓→/hjkl] Select  [Enter] Open  [d/Esc] Back  [:] Cmds  [Ctrl-F] Refresh  [?] Help  [q] Quit"
+		if m.statusMsg != "" {
+			hint = " ✓ " + m.statusMsg + " |" + hint
+		}
+		if line := m.rolloutLine(); line != "" {
+			hint = " " + line + " |" + hint
+		}
+		dashboard := m.dashboardView()
+		if m.showHelp {
+			dashboard = overlayCenter(dashboard, m.helpView(), m.width)
+		}
+		return lipgloss.JoinVertical(lipgloss.Left, dashboard, styleDim.Render(hint))
 	}
 
-	var listItems []string
-	// Header Title
-	listItems = append(listItems, styleTitle.Render("K9s Deck"))
-
-	infoLine := fmt.Sprintf("%s | %s", m.lastUpd.Format("15:04:05"), Context)
-	if m.err != nil {
-		listItems = append(listItems, styleErr.Render("Err: "+m.err.Error()))
-	} else {
-		listItems = append(listItems, styleDim.Render(infoLine))
-	}
+	leftWidth := m.leftPaneWidth()
 
-	// Show status message if present (e.g., "Yanked to clipboard")
-	if m.statusMsg != "" {
-		listItems = append(listItems, styleTitle.Render("✓ "+m.statusMsg))
-	}
+	// Group headers fill the pane's content width (inside stylePane's padding)
+	// and are cut to one line instead of wrapping on narrow terminals
+	headerStyle := styleHeader.Width(maxInt(leftWidth-2, 1)).MaxHeight(1)
 
-	listItems = append(listItems, "")
+	listItems := m.sidebarHeader()
 
 	if len(m.items) == 0 {
 		listItems = append(listItems, "Loading resources...")
@@ -1013,6 +2397,7 @@ func (m model) View() string {
 			end = len(m.items)
 		}
 
+		now := time.Now()
 		for i := m.listOffset; i < end; i++ {
 			if i >= len(m.items) {
 				break
@@ -1020,39 +2405,101 @@ func (m model) View() string {
 			item := m.items[i]
 
 			if item.Type == "HDR" {
-				listItems = append(listItems, styleHeader.Render(item.Name))
+				label := item.Name
+				if item.Note != "" {
+					label = strings.TrimSuffix(label, " ===") + " (" + item.Note + ") ==="
+				}
+				listItems = append(listItems, headerStyle.Render(label))
 				continue
 			}
 
-			icon := " "
+			itemIcon := " "
 			st := styleDim
 			statusStr := ""
+			restarts, age := "", "" // POD only, after the status
 			switch item.Type {
-			case "DEP":
-				icon = "🚀"
-				st = styleTitle.Copy()
+			case "DEP", "STS", "DS", "RS":
+				itemIcon = icon(item.Type)
+				st = styleTitle
+				if item.Type == "STS" {
+					st = st.Foreground(cPrimary)
+				}
+				var notes []string
+				if desired, ready, available, ok := daemonSetCounts(item.Status); item.Type == "DS" && ok {
+					notes = append(notes, fmt.Sprintf("%d desired, %d ready, %d available", desired, ready, available))
+				} else if ready, desired, ok := replicaCounts(item.Status); ok {
+					notes = append(notes, fmt.Sprintf("%d/%d ready", ready, desired))
+				}
+				if scaledToZero(item) {
+					// No pods below it is expected, not a failed fetch
+					notes = []string{"scaled to 0"}
+					st = styleDim
+				}
+				if image := imageSummary(item.Image); image != "" {
+					notes = append(notes, image)
+				}
+				if item.Revision != "" {
+					notes = append(notes, "rev "+item.Revision)
+				}
+				if item.Drift {
+					notes = append(notes, "digest drift")
+					st = st.Foreground(cYellow)
+				}
+				if len(notes) > 0 {
+					statusStr = "(" + strings.Join(notes, ", ") + ")"
+				}
+				if item.Anomaly != "" {
+					// Details are on the YAML tab, the dashboard and in the debug log
+					statusStr += icon("warn")
+				}
 			case "POD":
-				icon = "📦"
-				statusStr = fmt.Sprintf("(%s)", item.Status)
-				if strings.Contains(item.Status, "Running") && !strings.Contains(item.Status, "0/") {
-					st = st.Copy().Foreground(cGreen)
-				} else if strings.Contains(item.Status, "Terminating") || strings.Contains(item.Status, "ContainerCreating") || strings.Contains(item.Status, "Pending") || strings.Contains(item.Status, "0/") {
-					st = st.Copy().Foreground(cYellow)
-				} else {
-					st = st.Copy().Foreground(cRed)
+				itemIcon = icon("POD")
+				status := item.Status
+				if item.Drift {
+					// Tell the pods apart by what they actually run
+					status += " @" + item.Digest[:minInt(len(item.Digest), 7)]
+				}
+				if usage := m.podUsage(item.Name); usage != "" {
+					status += " " + usage
+				}
+				statusStr = "(" + status + ")"
+				restarts, age = podRowSuffix(item, now)
+				switch podHealth(item.Status) {
+				case healthOK:
+					st = st.Foreground(cGreen)
+				case healthPending:
+					st = st.Foreground(cYellow)
+				default:
+					st = st.Foreground(cRed)
 				}
 			case "HELM":
-				icon = "⚓"
-				st = st.Copy().Foreground(lipgloss.Color("201"))
+				itemIcon = icon("HELM")
+				st = st.Foreground(activeTheme.Helm)
+			case "SVC":
+				itemIcon = icon("SVC")
+				st = st.Foreground(activeTheme.Service)
+				statusStr = "(" + item.Status + ")"
 			case "SEC":
-				icon = "🔒"
-				st = st.Copy().Foreground(cYellow)
+				itemIcon = icon("SEC")
+				st = st.Foreground(cYellow)
+				if strings.HasPrefix(item.Status, "Pull") {
+					statusStr = "(" + strings.ToLower(item.Status) + ")"
+					if strings.HasPrefix(item.Status, "Pull:") {
+						st = st.Foreground(cRed)
+					}
+				}
 			case "CM":
-				icon = "📜"
-				st = st.Copy().Foreground(cSecondary)
+				itemIcon = icon("CM")
+				st = st.Foreground(cSecondary)
 			}
 
-			availNameWidth := leftWidth - 9 - len(statusStr) - 2
+			// Icon, type and separators, measured in cells since emoji are double width
+			fixedWidth := lipgloss.Width(itemIcon) + 7
+			suffix := strings.TrimSpace(restarts + " " + age)
+			if suffix != "" {
+				fixedWidth += lipgloss.Width(suffix) + 1
+			}
+			availNameWidth := leftWidth - fixedWidth - lipgloss.Width(statusStr) - 2
 			if availNameWidth < 5 {
 				availNameWidth = 5
 			}
@@ -1062,59 +2509,64 @@ func (m model) View() string {
 				if cutLen < 0 {
 					cutLen = 0
 				}
-				nameDisplay = nameDisplay[:cutLen] + "…"
+				nameDisplay = nameDisplay[:cutLen] + icon("more")
 			}
-			label := fmt.Sprintf("%s %-4s %s %s", icon, item.Type, nameDisplay, statusStr)
-			if m.cursor == i {
+			label := fmt.Sprintf("%s %-4s %s %s", itemIcon, item.Type, nameDisplay, statusStr)
+			switch {
+			case m.cursor == i:
+				if suffix != "" {
+					label += " " + suffix
+				}
 				listItems = append(listItems, styleSelected.Render(label))
-			} else {
+			case restarts != "" && item.Restarts >= RestartWarnThreshold:
+				// Only the restart count turns red, the row keeps its health color
+				row := st.Render(label+" ") + lipgloss.NewStyle().Foreground(cRed).Bold(true).Render(restarts)
+				if age != "" {
+					row += st.Render(" " + age)
+				}
+				listItems = append(listItems, row)
+			default:
+				if suffix != "" {
+					label += " " + suffix
+				}
 				listItems = append(listItems, st.Render(label))
 			}
 		}
 	}
 	leftStack := lipgloss.JoinVertical(lipgloss.Left, listItems...)
 	leftPane := stylePane.Width(leftWidth).Render(leftStack)
-
-	var tabs string
-	if len(m.items) > 0 {
-		curr := m.items[m.cursor]
-		if curr.Type == "DEP" {
-			t1, t2, t3 := styleTabInactive, styleTabInactive, styleTabInactive
-			if m.activeTab == 0 {
-				t1 = styleTabActive
-			}
-			if m.activeTab == 1 {
-				t2 = styleTabActive
-			}
-			if m.activeTab == 2 {
-				t3 = styleTabActive
-			}
-			tabs = lipgloss.JoinHorizontal(lipgloss.Top, t1.Render("YAML"), t2.Render("Events"), t3.Render("Logs"))
-		} else if curr.Type == "POD" {
-			t1, t2 := styleTabInactive, styleTabInactive
-			if m.activeTab == 0 {
-				t1 = styleTabActive
-			} else {
-				t2 = styleTabActive
-			}
-			tabs = lipgloss.JoinHorizontal(lipgloss.Top, t1.Render("YAML"), t2.Render("Logs"))
-		} else {
-			tabs = styleTabActive.Render("Details")
-		}
-	} else {
-		tabs = styleTabActive.Render("Details")
+	if m.stacked() {
+		// A fixed height keeps the details where layoutPanes sized them
+		height := m.listPaneHeight()
+		leftPane = stylePane.Width(leftWidth).Height(height).MaxHeight(height).Render(leftStack)
 	}
 
-	rightView := styleBorder.Width(m.viewport.Width).Height(m.viewport.Height).Render(m.viewport.View())
+	labels, _ := m.tabBar()
+	tabs := lipgloss.JoinHorizontal(lipgloss.Top, labels...)
+
+	detailView := m.viewport.View()
+	if m.picker != nil {
+		detailView = lipgloss.Place(m.viewport.Width, m.viewport.Height, lipgloss.Center, lipgloss.Center, m.pickerView())
+	}
+	rightView := styleBorder.Width(m.viewport.Width).Height(m.viewport.Height).Render(detailView)
 	rightStack := lipgloss.JoinVertical(lipgloss.Left, tabs, rightView)
+	if m.peek != nil {
+		rightStack = lipgloss.JoinVertical(lipgloss.Left, rightStack, m.peekView())
+	}
 	mainContent := lipgloss.JoinHorizontal(lipgloss.Top, leftPane, rightStack)
+	if m.stacked() {
+		mainContent = lipgloss.JoinVertical(lipgloss.Left, leftPane, rightStack)
+	}
+	if m.showHelp {
+		mainContent = overlayCenter(mainContent, m.helpView(), m.width)
+	}
 
 	var footer string
 	if m.inputMode {
 		inputView := m.textInput.View()
 
-		// Show suggestions for add/remove mode
-		if (m.shortcutMode == "add" || m.shortcutMode == "remove") && m.showSuggestions {
+		// Show suggestions for autocompleted prompts
+		if hasSuggestions(m.shortcutMode) && m.showSuggestions {
 			suggestions := m.getFilteredSuggestions()
 			if len(suggestions) > 0 {
 				var suggestionLines []string
@@ -1122,72 +2574,280 @@ func (m model) View() string {
 					prefix := "  "
 					if i == m.suggestionIndex {
 						prefix = "▶ " // highlight selected suggestion
-						suggestion = lipgloss.NewStyle().Foreground(lipgloss.Color("12")).Bold(true).Render(suggestion)
+						suggestion = lipgloss.NewStyle().Foreground(activeTheme.Active).Bold(true).Render(suggestion)
 					} else {
-						suggestion = lipgloss.NewStyle().Foreground(lipgloss.Color("8")).Render(suggestion)
+						suggestion = lipgloss.NewStyle().Foreground(activeTheme.Inactive).Render(suggestion)
 					}
 					suggestionLines = append(suggestionLines, prefix+suggestion)
 				}
 
 				suggestionsView := lipgloss.JoinVertical(lipgloss.Left, suggestionLines...)
-				action := "Add"
-				if m.shortcutMode == "remove" {
-					action = "Remove"
-				}
-				helpLine := styleDim.Render(fmt.Sprintf(" [Tab] Complete  [↑↓] Navigate  [Enter] %s  [Esc] Cancel", action))
+				helpLine := styleDim.Render(fmt.Sprintf(" [Tab] Complete  [↑↓] Navigate  [Enter] %s  [Esc] Cancel", suggestionActions[m.shortcutMode]))
 				footer = lipgloss.JoinVertical(lipgloss.Left,
-					styleCmdBar.Width(m.width).Render(inputView),
+					styleCmdBar.Width(m.width).MaxHeight(1).Render(inputView),
 					suggestionsView,
 					helpLine)
 			} else {
-				footer = styleCmdBar.Width(m.width).Render(inputView)
+				footer = styleCmdBar.Width(m.width).MaxHeight(1).Render(inputView)
 			}
 		} else {
-			footer = styleCmdBar.Width(m.width).Render(inputView)
+			footer = styleCmdBar.Width(m.width).MaxHeight(1).Render(inputView)
 		}
 	} else {
-		hint := " [:] Cmds  [/] Filter  [Tab] View  [f] Format  [y] Yank  [Ctrl+d/u] Scroll  [Ctrl-F] Refresh  [rr] Restart  [s] Scale  [R] Rollback  [+] Add  [-] Remove  [q] Quit"
+		selectedType := ""
+		if len(m.items) > 0 {
+			selectedType = m.items[m.cursor].Type
+		}
+		hint := footerHint(selectedType)
 
 		// Add format mode indicator
-		if m.logFormatMode {
+		if m.logFormatMode && m.jsonMode == parser.JSONFlat {
+			hint += " (Formatted, flat JSON)"
+		} else if m.logFormatMode && m.jsonMode != parser.JSONPretty {
+			hint += " (Formatted, JSON columns)"
+		} else if m.logFormatMode {
 			hint += " (Formatted)"
 		} else {
 			hint += " (Raw)"
 		}
+		if m.timestamps {
+			hint += " (Timestamps)"
+		}
+		if m.collapseRepeats {
+			hint += " (Repeats collapsed)"
+		}
+		if !m.wrapMode {
+			hint += " (No wrap, ←/→ scroll)"
+		}
+		if m.lineNumbers {
+			hint += " (Line numbers)"
+		}
+		if m.podSort != "" {
+			hint += " (Pods: " + podSortLabel(m.podSort) + ")"
+		}
 
 		if m.activeFilter != "" {
 			hint = fmt.Sprintf(" FILTER: \"%s\" (Esc to clear) | %s", m.activeFilter, hint)
 		}
+		if m.jsonQuery != "" {
+			hint = fmt.Sprintf(" QUERY: %s (Esc to clear) |%s", m.jsonQuery, hint)
+		}
+		hint = m.searchStatus() + hint
+		hint = m.forwardsStatus() + hint
+		if m.logGrep != nil {
+			hint = m.logGrep.status() + hint
+		}
+		if m.minLogLevel != "" {
+			hint = fmt.Sprintf(" LEVEL: %s+ (L to cycle) |%s", m.minLogLevel, hint)
+		}
+		if m.follow != nil {
+			hint = " FOLLOWING (F/Esc to stop) |" + hint
+		}
 		footer = styleDim.Render(hint)
 	}
 
 	return lipgloss.JoinVertical(lipgloss.Left, mainContent, footer)
 }
 
-func runCmd(name string, args ...string) ([]byte, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), CommandTimeout)
-	defer cancel()
-	cmd := exec.CommandContext(ctx, name, args...)
-	return cmd.CombinedOutput()
+// leftPaneWidth is the width of the sidebar, including its padding; the
+// whole width when it is stacked above the details
+func (m model) leftPaneWidth() int {
+	if m.stacked() {
+		return m.width
+	}
+	return maxInt(int(float64(m.width)*m.leftPaneRatio), MinLeftPaneWidth)
+}
+
+// resizePanes moves the split by delta of the width, within
+// MinLeftPaneRatio..MaxLeftPaneRatio, and saves it to the config
+func (m *model) resizePanes(delta float64) tea.Cmd {
+	if m.stacked() {
+		m.statusMsg = "The panes are stacked, | puts them side by side to resize"
+		return clearStatusLater()
+	}
+	// Round to the step so repeated presses don't accumulate float error
+	ratio := math.Round((m.leftPaneRatio+delta)/LeftPaneRatioStep) * LeftPaneRatioStep
+	ratio = math.Max(MinLeftPaneRatio, math.Min(MaxLeftPaneRatio, ratio))
+	if ratio == m.leftPaneRatio {
+		return nil
+	}
+	m.leftPaneRatio = ratio
+	m.layoutPanes()
+	m.updateViewportContent()
+	m.statusMsg = fmt.Sprintf("Resource list: %.0f%% of the width", ratio*100)
+	return tea.Batch(clearStatusLater(), m.scheduleConfigSave())
+}
+
+// sidebarHeader renders the lines above the resource list: the title, the
+// info or error line, the indicators and status message, then a blank line
+func (m model) sidebarHeader() []string {
+	listItems := []string{styleTitle.Render("K9s Deck")}
+
+	infoLine := fmt.Sprintf("%s | %s/%s", m.lastUpd.Format("15:04:05"), Context, Namespace)
+	if m.watchingAll() {
+		infoLine += " | live"
+	}
+	if m.hiddenSystem > 0 {
+		infoLine += fmt.Sprintf(" | %d system hidden [S]", m.hiddenSystem)
+	}
+	if m.err != nil {
+		listItems = append(listItems, styleErr.Render("Err: "+m.err.Error()))
+	} else {
+		listItems = append(listItems, styleDim.Render(infoLine))
+	}
+	if m.paused {
+		listItems = append(listItems, lipgloss.NewStyle().Foreground(cYellow).Bold(true).Render("⏸ PAUSED (z resumes, Ctrl-F refreshes)"))
+	}
+	if throttle := m.throttleIndicator(); throttle != "" {
+		listItems = append(listItems, throttle)
+	}
+	if m.mutating != "" {
+		listItems = append(listItems, lipgloss.NewStyle().Foreground(cYellow).Render("⟳ "+m.mutating+" in progress..."))
+	}
+	if line := m.rolloutLine(); line != "" {
+		listItems = append(listItems, line)
+	}
+
+	// Show status message if present (e.g., "Yanked to clipboard")
+	if m.statusMsg != "" {
+		listItems = append(listItems, styleTitle.Render("✓ "+m.statusMsg))
+	}
+
+	listItems = append(listItems, "")
+	return listItems
+}
+
+// tabBar renders the tab labels above the details pane, each with the
+// activeTab a click on it selects
+func (m model) tabBar() (labels []string, tabs []int) {
+	if len(m.items) == 0 {
+		return []string{styleTabActive.Render("Details")}, []int{0}
+	}
+	curr := m.items[m.cursor]
+	if tabList := tabSets[curr.Type]; len(tabList) > 0 {
+		for idx, name := range tabList {
+			st := styleTabInactive
+			if idx == m.activeTab {
+				st = styleTabActive
+			}
+			title := tabTitles[name]
+			if name == TabLogs {
+				title += m.logScopeFor(curr).label()
+			}
+			labels = append(labels, st.Render(title))
+			tabs = append(tabs, idx)
+		}
+		return labels, tabs
+	}
+	if curr.Type == "CM" && len(m.cmKeys) > 0 {
+		t1, t2 := styleTabInactive, styleTabInactive
+		keyLabel := fmt.Sprintf("Keys (%d)", len(m.cmKeys))
+		keyTab := 1
+		if m.activeTab > 0 && m.activeTab <= len(m.cmKeys) {
+			t2 = styleTabActive
+			keyLabel = fmt.Sprintf("Key %d/%d: %s", m.activeTab, len(m.cmKeys), m.cmKeys[m.activeTab-1])
+			keyTab = m.activeTab
+		} else {
+			t1 = styleTabActive
+		}
+		return []string{t1.Render("YAML"), t2.Render(keyLabel)}, []int{0, keyTab}
+	}
+	return []string{styleTabActive.Render("Details")}, []int{0}
+}
+
+// Pod health buckets used for status coloring
+const (
+	healthOK = iota
+	healthPending
+	healthFailing
+)
+
+// podHealth buckets a POD item status ("Running 1/1", "CrashLoopBackOff 0/1", ...)
+func podHealth(status string) int {
+	if strings.Contains(status, "Running") && !strings.Contains(status, "0/") {
+		return healthOK
+	}
+	if strings.Contains(status, "Terminating") || strings.Contains(status, "ContainerCreating") || strings.Contains(status, "Pending") || strings.Contains(status, "0/") {
+		return healthPending
+	}
+	return healthFailing
 }
 
-// fetchAvailableDeployments gets all deployments in the current namespace
-func fetchAvailableDeployments() tea.Cmd {
+// fetchAvailableDeployments gets all deployments in the current namespace,
+// followed by its statefulsets as "sts/<name>" and daemonsets as "ds/<name>"
+func fetchAvailableDeployments(kc k8s.Client, ns string) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), CommandTimeout)
 		defer cancel()
 
-		deployments, err := client.ListDeployments(ctx, Namespace)
+		deployments, err := kc.ListDeployments(ctx, ns)
+		if err != nil {
+			return suggestionsMsg{mode: "add", names: []string{}}
+		}
+		statefulSets, err := kc.ListStatefulSets(ctx, ns)
+		if err != nil {
+			slog.Debug("failed to list statefulsets", "namespace", ns, "error", err)
+		}
+		for _, name := range statefulSets {
+			deployments = append(deployments, StatefulSetPrefix+name)
+		}
+		daemonSets, err := kc.ListDaemonSets(ctx, ns)
 		if err != nil {
-			return suggestionsMsg{deployments: []string{}}
+			slog.Debug("failed to list daemonsets", "namespace", ns, "error", err)
+		}
+		for _, name := range daemonSets {
+			deployments = append(deployments, DaemonSetPrefix+name)
 		}
 
-		return suggestionsMsg{deployments: deployments}
+		return suggestionsMsg{mode: "add", names: deployments}
+	}
+}
+
+func tickCmd(gen int, interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg { return tickMsg{gen: gen} })
+}
+
+// parseRefreshInterval parses a refresh interval such as "5s", refusing
+// anything below MinRefreshInterval
+func parseRefreshInterval(s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a duration (e.g. 500ms, 5s)", s)
 	}
+	if d < MinRefreshInterval {
+		return 0, fmt.Errorf("%s is below the minimum of %s", d, MinRefreshInterval)
+	}
+	return d, nil
+}
+
+// setRefreshInterval makes d the refresh pace and restarts the refresh loop
+// so it applies from the next tick
+func (m *model) setRefreshInterval(d time.Duration) tea.Cmd {
+	RefreshInterval = d
+	m.refreshInterval = d
+	m.tickGen++
+	return tickCmd(m.tickGen, m.refreshInterval)
 }
 
-func tickCmd() tea.Cmd {
-	return tea.Tick(TickerInterval, func(t time.Time) tea.Msg { return tickMsg(t) })
+// adjustRefreshInterval doubles the refresh interval while requests are being
+// throttled and halves it back towards RefreshInterval once they succeed
+func (m *model) adjustRefreshInterval(throttled bool) {
+	prev := m.refreshInterval
+	ceiling := max(MaxRefreshInterval, RefreshInterval)
+	if throttled {
+		m.refreshInterval *= 2
+		if m.refreshInterval > ceiling {
+			m.refreshInterval = ceiling
+		}
+	} else if m.refreshInterval > RefreshInterval {
+		m.refreshInterval /= 2
+		if m.refreshInterval < RefreshInterval {
+			m.refreshInterval = RefreshInterval
+		}
+	}
+	if m.refreshInterval != prev {
+		slog.Warn("adjusted refresh interval", "interval", m.refreshInterval.String(), "throttled", throttled)
+	}
 }
 
 // stripANSI removes ANSI escape codes from a string
@@ -1197,37 +2857,67 @@ func stripANSI(s string) string {
 	return ansiRegex.ReplaceAllString(s, "")
 }
 
-// copyToClipboard copies content to system clipboard (cross-platform)
-func copyToClipboard(content string) error {
-	// Strip ANSI color codes before copying
-	cleanContent := stripANSI(content)
+// pasteCmd reads the system clipboard for the command input
+func pasteCmd() tea.Cmd {
+	return func() tea.Msg {
+		text, err := readClipboard()
+		return pasteMsg{text: text, err: err}
+	}
+}
 
-	var cmd *exec.Cmd
+// yankCmd copies the current content to clipboard
+func yankCmd(content string) tea.Cmd {
+	return func() tea.Msg {
+		osc52, err := copyToClipboard(content)
+		return copyMsg{success: err == nil, osc52: osc52, err: err}
+	}
+}
 
-	switch runtime.GOOS {
-	case "darwin":
-		cmd = exec.Command("pbcopy")
-	case "linux":
-		cmd = exec.Command("xclip", "-selection", "clipboard")
-	case "windows":
-		cmd = exec.Command("clip")
-	default:
-		return fmt.Errorf("unsupported platform")
+// isMutatingCommand reports whether a command verb changes the cluster
+func isMutatingCommand(verb string) bool {
+	switch verb {
+	case "scale", "undo", "restart", "rollback", "delete-pod":
+		return true
 	}
+	return false
+}
 
-	cmd.Stdin = strings.NewReader(cleanContent)
-	return cmd.Run()
+// startCommand runs executeCommand, allowing a single mutating command in
+// flight at a time so e.g. a restart can't race a pending scale
+func (m *model) startCommand(input, helmRelease, deploymentName string) tea.Cmd {
+	parts := strings.Fields(input)
+	if len(parts) == 0 || !isMutatingCommand(parts[0]) {
+		return executeCommand(client, Namespace, input, helmRelease, deploymentName)
+	}
+	if m.mutating != "" {
+		m.statusMsg = fmt.Sprintf("Operation in progress (%s), try again when it finishes", m.mutating)
+		return clearStatusLater()
+	}
+	if !ReadOnly && needsConfirmation(parts[0]) {
+		return previewActionCmd(client, Namespace, pendingAction{input: input, helmRelease: helmRelease, deployment: deploymentName})
+	}
+	return m.runMutation(input, helmRelease, deploymentName)
 }
 
-// yankCmd copies the current content to clipboard
-func yankCmd(content string) tea.Cmd {
+// runMutation runs a mutating command, marking it in flight until it finishes
+func (m *model) runMutation(input, helmRelease, deploymentName string) tea.Cmd {
+	if m.mutating != "" {
+		m.statusMsg = fmt.Sprintf("Operation in progress (%s), try again when it finishes", m.mutating)
+		return clearStatusLater()
+	}
+	m.mutating = strings.Fields(input)[0]
+	run := executeCommand(client, Namespace, input, helmRelease, deploymentName)
+	if input == restartAllCommand {
+		m.mutating = input
+		m.statusMsg = fmt.Sprintf("Restarting %d targets...", len(m.targets))
+		run = restartAllCmd(client, Namespace, m.targets)
+	}
 	return func() tea.Msg {
-		err := copyToClipboard(content)
-		return copyMsg{success: err == nil, err: err}
+		return mutationDoneMsg{result: run()}
 	}
 }
 
-func executeCommand(input, helmRelease, deploymentName string) tea.Cmd {
+func executeCommand(kc k8s.Client, ns string, input, helmRelease, deploymentName string) tea.Cmd {
 	return func() tea.Msg {
 		parts := strings.Fields(input)
 		if len(parts) == 0 {
@@ -1235,13 +2925,20 @@ func executeCommand(input, helmRelease, deploymentName string) tea.Cmd {
 		}
 		verb := parts[0]
 
-		// :add is handled in Update now via addTargetMsg
+		// :add is handled in Update by addTarget
 
 		ctx, cancel := context.WithTimeout(context.Background(), LongCommandTimeout)
 		defer cancel()
 
 		switch verb {
-		case "scale":
+		case "scale", "undo", "restart", "rollback", "delete-pod":
+			if ReadOnly {
+				return detailsMsg{err: fmt.Errorf("%s is disabled in read-only mode", verb)}
+			}
+		}
+
+		switch verb {
+		case "scale", "undo":
 			if len(parts) < 2 {
 				return detailsMsg{err: fmt.Errorf("Usage: scale <replicas>")}
 			}
@@ -1252,20 +2949,41 @@ func executeCommand(input, helmRelease, deploymentName string) tea.Cmd {
 			if _, err := fmt.Sscanf(parts[1], "%d", &replicas); err != nil {
 				return detailsMsg{err: fmt.Errorf("Invalid replica count: %s", parts[1])}
 			}
-			err := client.ScaleDeployment(ctx, Namespace, deploymentName, replicas)
+			kind, name := parseTarget(deploymentName)
+			if err := workloadActionError(kind, "scale"); err != nil {
+				return detailsMsg{err: err}
+			}
+			// Remember the replicas before the change for :undo
+			scaled := &scaleChange{target: deploymentName, from: -1, to: replicas, undo: verb == "undo"}
+			if from, err := currentReplicas(ctx, kc, ns, deploymentName); err == nil {
+				scaled.from = from
+			}
+			if kind == "STS" {
+				if err := kc.ScaleStatefulSet(ctx, ns, name, replicas); err != nil {
+					return detailsMsg{err: fmt.Errorf("Scale failed: %v", err)}
+				}
+				return commandFinishedMsg{scaled: scaled}
+			}
+			err := kc.ScaleDeployment(ctx, ns, deploymentName, replicas)
 			if err != nil {
 				return detailsMsg{err: fmt.Errorf("Scale failed: %v", err)}
 			}
-			return commandFinishedMsg{}
+			return commandFinishedMsg{rollout: deploymentName, scaled: scaled}
 		case "restart":
 			if deploymentName == "" {
 				return detailsMsg{err: fmt.Errorf("No deployment selected")}
 			}
-			err := client.RestartDeployment(ctx, Namespace, deploymentName)
-			if err != nil {
+			kind, _ := parseTarget(deploymentName)
+			if err := workloadActionError(kind, verb); err != nil {
+				return detailsMsg{err: err}
+			}
+			if err := restartWorkload(ctx, kc, ns, deploymentName); err != nil {
 				return detailsMsg{err: fmt.Errorf("Restart failed: %v", err)}
 			}
-			return commandFinishedMsg{}
+			if kind == "STS" || kind == "DS" {
+				return commandFinishedMsg{}
+			}
+			return commandFinishedMsg{rollout: deploymentName}
 		case "rollback":
 			if helmRelease == "" {
 				return detailsMsg{err: fmt.Errorf("No Helm release associated.")}
@@ -1277,16 +2995,23 @@ func executeCommand(input, helmRelease, deploymentName string) tea.Cmd {
 			if _, err := fmt.Sscanf(parts[1], "%d", &revision); err != nil {
 				return detailsMsg{err: fmt.Errorf("Invalid revision: %s", parts[1])}
 			}
-			err := client.RollbackHelm(ctx, Namespace, helmRelease, revision)
+			err := kc.RollbackHelm(ctx, ns, helmRelease, revision)
 			if err != nil {
 				return detailsMsg{err: fmt.Errorf("Rollback failed: %v", err)}
 			}
 			return commandFinishedMsg{}
+		case "delete-pod":
+			if len(parts) < 2 {
+				return detailsMsg{err: fmt.Errorf("No pod selected")}
+			}
+			if err := kc.DeletePod(ctx, ns, parts[1]); err != nil {
+				return detailsMsg{err: fmt.Errorf("Delete failed: %v", err)}
+			}
+			return podDeletedMsg{pod: parts[1]}
 		case "fetch":
 			return tea.Batch(
 				func() tea.Msg { return detailsMsg{content: "Manual Refresh...", isYaml: false} },
 				func() tea.Msg { return commandFinishedMsg{} },
-				tickCmd(),
 			)()
 		default:
 			return detailsMsg{err: fmt.Errorf("Unknown command: %s", verb)}
@@ -1294,7 +3019,9 @@ func executeCommand(input, helmRelease, deploymentName string) tea.Cmd {
 	}
 }
 
-func fetchDataCmd(targets []string, selectors map[string]string) tea.Cmd {
+// fetchDataCmd refreshes every target. overrides holds :selector overrides
+// that replace the deployment's spec.selector for pod discovery.
+func fetchDataCmd(kc k8s.Client, kubeContext, ns string, gen int, targets []string, overrides map[string]string) tea.Cmd {
 	return func() tea.Msg {
 		var wg sync.WaitGroup
 		var mu sync.Mutex
@@ -1303,7 +3030,16 @@ func fetchDataCmd(targets []string, selectors map[string]string) tea.Cmd {
 		targetItems := make(map[string][]item)
 		updatedSelectors := make(map[string]string)
 		updatedHelm := make(map[string]string)
-		var combinedErr error
+		targetErrs := make(map[string]error)
+		renamed := make(map[string]string)
+		throttled := false
+
+		// Services are matched against every deployment, so list them once
+		listServices := sync.OnceValues(func() ([]byte, error) {
+			ctx, cancel := context.WithTimeout(context.Background(), CommandTimeout)
+			defer cancel()
+			return kc.ListServices(ctx, ns)
+		})
 
 		for _, targetName := range targets {
 			wg.Add(1)
@@ -1313,161 +3049,409 @@ func fetchDataCmd(targets []string, selectors map[string]string) tea.Cmd {
 				ctx, cancel := context.WithTimeout(context.Background(), CommandTimeout)
 				defer cancel()
 
-				depOut, depErr := client.GetDeployment(ctx, Namespace, tName)
+				kind, name := parseTarget(tName)
+				depOut, depErr := getWorkload(ctx, kc, ns, tName)
+				if kind == "DEP" && k8s.IsNotFound(depErr) {
+					// A bare target (e.g. from the command line) may be another kind of workload
+					if target, err := resolveTarget(ctx, kc, ns, name); err == nil && target != tName {
+						mu.Lock()
+						renamed[tName] = target
+						mu.Unlock()
+						tName = target
+						kind, name = parseTarget(tName)
+						depOut, depErr = getWorkload(ctx, kc, ns, tName)
+					}
+				}
 
 				if depErr != nil {
 					mu.Lock()
-					targetItems[tName] = []item{{Type: "HDR", Name: fmt.Sprintf("=== %s (Err) ===", tName)}}
-					if combinedErr == nil {
-						combinedErr = depErr
-					}
+					throttled = throttled || k8s.IsThrottled(depErr)
+					targetErrs[tName] = depErr
 					mu.Unlock()
 					return
 				}
 
 				jsonRaw := string(depOut)
+				parsed := parseWorkloadCached(kubeContext, ns, tName, kind, name, jsonRaw)
+				anomalies := append([]string(nil), parsed.anomalies...)
 
 				// Collect local items for this deployment
 				var localItems []item
-				localItems = append(localItems, item{Type: "HDR", Name: fmt.Sprintf("=== %s ===", tName)})
-				localItems = append(localItems, item{Type: "DEP", Name: tName, Status: "Active"})
-
-				// Helm
-				annotations := gjson.Get(jsonRaw, "metadata.annotations").Map()
-				helmName := ""
-				if val, ok := annotations["meta.helm.sh/release-name"]; ok {
-					helmName = val.String()
+				override, manual := overrides[tName]
+				header := fmt.Sprintf("=== %s ===", tName)
+				if manual {
+					header = fmt.Sprintf("=== %s (manual selector) ===", tName)
 				}
-				if helmName != "" {
-					localItems = append(localItems, item{Type: "HELM", Name: helmName, Status: "Release"})
+				localItems = append(localItems, item{Type: "HDR", Name: header})
+				localItems = append(localItems, parsed.items...)
+				if parsed.helmRelease != "" {
 					mu.Lock()
-					updatedHelm[tName] = helmName
+					updatedHelm[tName] = parsed.helmRelease
 					mu.Unlock()
 				}
 
-				// Secrets/CM
-				seenSecrets := make(map[string]bool)
-				seenConfigMaps := make(map[string]bool)
-
-				containers := gjson.Get(jsonRaw, "spec.template.spec.containers").Array()
-				for _, c := range containers {
-					// Check envFrom
-					c.Get("envFrom").ForEach(func(_, v gjson.Result) bool {
-						if name := v.Get("secretRef.name").String(); name != "" && !seenSecrets[name] {
-							seenSecrets[name] = true
-							localItems = append(localItems, item{Type: "SEC", Name: name, Status: "Ref"})
-						}
-						if name := v.Get("configMapRef.name").String(); name != "" && !seenConfigMaps[name] {
-							seenConfigMaps[name] = true
-							localItems = append(localItems, item{Type: "CM", Name: name, Status: "Ref"})
-						}
-						return true
-					})
-					// Check env
-					c.Get("env").ForEach(func(_, v gjson.Result) bool {
-						if name := v.Get("valueFrom.secretKeyRef.name").String(); name != "" && !seenSecrets[name] {
-							seenSecrets[name] = true
-							localItems = append(localItems, item{Type: "SEC", Name: name, Status: "Ref"})
-						}
-						if name := v.Get("valueFrom.configMapKeyRef.name").String(); name != "" && !seenConfigMaps[name] {
-							seenConfigMaps[name] = true
-							localItems = append(localItems, item{Type: "CM", Name: name, Status: "Ref"})
-						}
-						return true
-					})
+				// Services selecting the deployment's pods
+				if svcOut, svcErr := listServices(); svcErr == nil {
+					localItems = append(localItems, servicesSelecting(svcOut, parsed.podLabels)...)
+				} else if k8s.IsThrottled(svcErr) {
+					mu.Lock()
+					throttled = true
+					mu.Unlock()
+				} else {
+					slog.Debug("failed to list services", "namespace", ns, "error", svcErr)
 				}
 
-				// Check volumes
-				gjson.Get(jsonRaw, "spec.template.spec.volumes").ForEach(func(_, v gjson.Result) bool {
-					if name := v.Get("secret.secretName").String(); name != "" && !seenSecrets[name] {
-						seenSecrets[name] = true
-						localItems = append(localItems, item{Type: "SEC", Name: name, Status: "Ref"})
-					}
-					if name := v.Get("configMap.name").String(); name != "" && !seenConfigMaps[name] {
-						seenConfigMaps[name] = true
-						localItems = append(localItems, item{Type: "CM", Name: name, Status: "Ref"})
+				// Secrets/CM, image pull secrets last
+				pullSecretIdx := make(map[string]int)
+				for _, ref := range parsed.refs {
+					if ref.Type == "SEC" && ref.Status == "Pull" {
+						pullSecretIdx[ref.Name] = len(localItems)
 					}
-					return true
-				})
+					localItems = append(localItems, ref)
+				}
+				imagePullFailing := false
 
 				// Pods
-				selectorMap := gjson.Get(jsonRaw, "spec.selector.matchLabels").Map()
-				keys := make([]string, 0, len(selectorMap))
-				for k := range selectorMap {
-					keys = append(keys, k)
+				newSelector := parsed.selector
+				if manual {
+					newSelector = override
 				}
-				sort.Strings(keys)
-				labels := make([]string, 0, len(keys))
-				for _, k := range keys {
-					labels = append(labels, k+"="+selectorMap[k].String())
+
+				if newSelector == "" && !manual {
+					anomalies = append(anomalies, "no label selector, pods not discovered")
 				}
-				newSelector := strings.Join(labels, ",")
 
 				if newSelector != "" {
 					mu.Lock()
 					updatedSelectors[tName] = newSelector
 					mu.Unlock()
 
-					podOut, podErr := client.ListPods(ctx, Namespace, newSelector)
+					podOut, podErr := kc.ListPods(ctx, ns, newSelector)
+					if podErr == nil && !gjson.GetBytes(podOut, "items").IsArray() {
+						anomalies = append(anomalies, "pod list has no items array")
+					}
 					if podErr == nil {
+						firstPod := len(localItems)
+						unnamed := 0
 						gjson.Get(string(podOut), "items").ForEach(func(_, p gjson.Result) bool {
-							phase := p.Get("status.phase").String()
-							readyCount, totalCount := 0, 0
-							p.Get("status.containerStatuses").ForEach(func(_, c gjson.Result) bool {
-								totalCount++
-								if c.Get("ready").Bool() {
-									readyCount++
-								}
+							if p.Get("metadata.name").String() == "" {
+								unnamed++
 								return true
-							})
-							isReady := totalCount > 0 && readyCount == totalCount
-							status := phase
-							if p.Get("metadata.deletionTimestamp").Exists() {
-								status = "Terminating"
-							} else if isReady {
-								status = "Running"
-							} else {
-								waitingReason := ""
-								p.Get("status.containerStatuses").ForEach(func(_, c gjson.Result) bool {
-									if r := c.Get("state.waiting.reason").String(); r != "" {
-										waitingReason = r
-										return false
-									}
-									return true
-								})
-								if waitingReason != "" {
-									status = waitingReason
-								}
 							}
-							fullStatus := fmt.Sprintf("%s %d/%d", status, readyCount, totalCount)
-							localItems = append(localItems, item{Type: "POD", Name: p.Get("metadata.name").String(), Status: fullStatus})
+							fullStatus := podStatus(p)
+							if strings.HasPrefix(fullStatus, "ErrImagePull ") || strings.HasPrefix(fullStatus, "ImagePullBackOff ") {
+								imagePullFailing = true
+							}
+							localItems = append(localItems, item{Type: "POD", Name: p.Get("metadata.name").String(), Status: fullStatus, Digest: podDigests(p), Created: p.Get("metadata.creationTimestamp").Time(), Restarts: podRestarts(p)})
 							return true
 						})
+						if kind == "STS" {
+							sortByOrdinal(localItems[firstPod:], name)
+						}
+						markDigestDrift(localItems)
+						if unnamed > 0 {
+							anomalies = append(anomalies, fmt.Sprintf("%d pod(s) without metadata.name skipped", unnamed))
+						}
+					} else if k8s.IsThrottled(podErr) {
+						mu.Lock()
+						throttled = true
+						mu.Unlock()
+					} else {
+						anomalies = append(anomalies, "pod list failed: "+podErr.Error())
+					}
+				}
+
+				// Only check pull secrets when a pod can't pull its image
+				if imagePullFailing {
+					for name, idx := range pullSecretIdx {
+						if problem := checkPullSecret(ctx, kc, ns, name); problem != "" {
+							localItems[idx].Status = "Pull: " + problem
+						} else {
+							localItems[idx].Status = "Pull OK"
+						}
 					}
 				}
 
+				for idx := range localItems {
+					localItems[idx].System = isSystemResource(localItems[idx].Type, localItems[idx].Name)
+				}
+
+				if len(anomalies) > 0 {
+					slog.Warn("unexpected deployment shape", "deployment", tName, "problems", strings.Join(anomalies, "; "))
+					localItems[1].Anomaly = strings.Join(anomalies, "; ")
+				}
+
 				mu.Lock()
 				targetItems[tName] = localItems
 				mu.Unlock()
 			}(targetName)
 		}
 
-		wg.Wait()
+		wg.Wait()
+
+		return dataMsg{gen: gen, targetItems: targetItems, targetErrs: targetErrs, selectors: updatedSelectors, helmReleases: updatedHelm, renamed: renamed, throttled: throttled, err: unreachableErr(targetErrs)}
+	}
+}
+
+// unreachableErr returns the first (by target name) of errs that means the
+// cluster can't be reached; errors about a single target stay on its header
+func unreachableErr(errs map[string]error) error {
+	names := make([]string, 0, len(errs))
+	for name := range errs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if k8s.IsUnreachable(errs[name]) {
+			return errs[name]
+		}
+	}
+	return nil
+}
+
+// targetErrReason shortens a target's refresh error for its header
+func targetErrReason(err error) string {
+	switch {
+	case k8s.IsNotFound(err):
+		return "not found"
+	case k8s.IsForbidden(err):
+		return "forbidden"
+	case k8s.IsThrottled(err):
+		return "throttled"
+	}
+	reason, _, _ := strings.Cut(err.Error(), "\n")
+	return reason
+}
+
+// deploymentShapeProblems lists the paths fetchDataCmd relies on that are
+// missing from a deployment (CRD-backed or partial objects)
+func deploymentShapeProblems(jsonRaw string) []string {
+	if !gjson.Valid(jsonRaw) {
+		return []string{"response is not valid JSON"}
+	}
+	var problems []string
+	if !gjson.Get(jsonRaw, "spec.template.spec.containers").IsArray() {
+		problems = append(problems, "no spec.template.spec.containers")
+	}
+	if !gjson.Get(jsonRaw, "spec.selector.matchLabels").IsObject() && !gjson.Get(jsonRaw, "spec.selector.matchExpressions").IsArray() {
+		problems = append(problems, "no spec.selector.matchLabels or matchExpressions")
+	}
+	if !gjson.Get(jsonRaw, "status").Exists() {
+		problems = append(problems, "no status")
+	}
+	return problems
+}
+
+// podStatus summarizes a pod as "<state> <ready>/<total>", e.g. "Running 1/1"
+// or "CrashLoopBackOff 0/1". Fully ready pods report Running even when
+// Kubernetes still lists a waiting reason.
+func podStatus(p gjson.Result) string {
+	phase := p.Get("status.phase").String()
+	readyCount, totalCount := 0, 0
+	p.Get("status.containerStatuses").ForEach(func(_, c gjson.Result) bool {
+		totalCount++
+		if c.Get("ready").Bool() {
+			readyCount++
+		}
+		return true
+	})
+	isReady := totalCount > 0 && readyCount == totalCount
+	status := phase
+	if p.Get("metadata.deletionTimestamp").Exists() {
+		status = "Terminating"
+	} else if isReady {
+		status = "Running"
+	} else {
+		waitingReason := ""
+		p.Get("status.containerStatuses").ForEach(func(_, c gjson.Result) bool {
+			if r := c.Get("state.waiting.reason").String(); r != "" {
+				waitingReason = r
+				return false
+			}
+			return true
+		})
+		if waitingReason != "" {
+			status = waitingReason
+		}
+	}
+	return fmt.Sprintf("%s %d/%d", status, readyCount, totalCount)
+}
+
+// podRestarts sums the restart counts of a pod's containers
+func podRestarts(p gjson.Result) int {
+	restarts := 0
+	p.Get("status.containerStatuses.#.restartCount").ForEach(func(_, c gjson.Result) bool {
+		restarts += int(c.Int())
+		return true
+	})
+	return restarts
+}
+
+// podRowSuffix returns the restart count ("↻3", "" without restarts) and
+// age ("12m", "" if unknown) shown after a POD row's status
+func podRowSuffix(it item, now time.Time) (restarts, age string) {
+	if it.Restarts > 0 {
+		restarts = icon("restarts") + strconv.Itoa(it.Restarts)
+	}
+	if !it.Created.IsZero() {
+		age = k8s.FormatAge(now.Sub(it.Created))
+	}
+	return restarts, age
+}
+
+// podDigests returns the short digests of the images a pod is actually
+// running, from status.containerStatuses[*].imageID ("" until pulled)
+func podDigests(pod gjson.Result) string {
+	var digests []string
+	pod.Get("status.containerStatuses.#.imageID").ForEach(func(_, v gjson.Result) bool {
+		if d := shortDigest(v.String()); d != "" {
+			digests = append(digests, d)
+		}
+		return true
+	})
+	return strings.Join(digests, ",")
+}
+
+// shortDigest turns "docker-pullable://nginx@sha256:abc..." into the first
+// 12 hex characters of the digest
+func shortDigest(imageID string) string {
+	idx := strings.LastIndex(imageID, "sha256:")
+	if idx == -1 {
+		return ""
+	}
+	digest := imageID[idx+len("sha256:"):]
+	if len(digest) > 12 {
+		digest = digest[:12]
+	}
+	return digest
+}
+
+// markDigestDrift flags a deployment group whose pods run different image
+// digests, i.e. an unfinished rollout or a mutable tag that moved
+func markDigestDrift(group []item) {
+	seen := make(map[string]bool)
+	for _, it := range group {
+		if it.Type == "POD" && it.Digest != "" {
+			seen[it.Digest] = true
+		}
+	}
+	if len(seen) < 2 {
+		return
+	}
+	for i := range group {
+		if isWorkload(group[i].Type) || (group[i].Type == "POD" && group[i].Digest != "") {
+			group[i].Drift = true
+		}
+	}
+}
+
+// assembleItems builds the sidebar from a refresh, in target name order.
+// A failing target keeps its last good items (marked stale) until it has
+// been failing for StaleErrorTimeout, after which only an error header remains.
+func (m *model) assembleItems(msg dataMsg) []item {
+	targets := append([]string(nil), m.targets...)
+	sort.Strings(targets)
+
+	m.targetErrs = msg.targetErrs
+	now := time.Now()
+	var items []item
+	for _, tName := range targets {
+		if targetItems, ok := msg.targetItems[tName]; ok {
+			m.lastGoodItems[tName] = targetItems
+			m.lastGoodAt[tName] = now
+			items = append(items, targetItems...)
+			continue
+		}
+		if _, failed := msg.targetErrs[tName]; !failed {
+			continue
+		}
+
+		lastGood, hasLastGood := m.lastGoodItems[tName]
+		at := m.lastGoodAt[tName]
+		if hasLastGood && now.Sub(at) < StaleErrorTimeout {
+			stale := append([]item(nil), lastGood...)
+			stale[0].Note = fmt.Sprintf("stale, last updated %s: %s", at.Format("15:04:05"), targetErrReason(msg.targetErrs[tName]))
+			items = append(items, stale...)
+			continue
+		}
+		items = append(items, item{Type: "HDR", Name: fmt.Sprintf("=== %s (Err: %s) ===", tName, targetErrReason(msg.targetErrs[tName]))})
+	}
+
+	m.hiddenSystem = 0
+	if m.showSystem {
+		return items
+	}
+	visible := items[:0]
+	for _, it := range items {
+		if it.System {
+			m.hiddenSystem++
+			continue
+		}
+		visible = append(visible, it)
+	}
+	return visible
+}
+
+// serviceAccountTokenRegex matches legacy service-account token secrets (<sa>-token-<5 chars>)
+var serviceAccountTokenRegex = regexp.MustCompile(`-token-[a-z0-9]{5}$`)
+
+// isSystemResource reports whether a SEC/CM is cluster plumbing rather than
+// app config: service-account tokens, the root CA bundle and Helm's release
+// records (type helm.sh/release.v1, named sh.helm.release.v1.<release>.v<N>)
+func isSystemResource(resourceType, name string) bool {
+	switch resourceType {
+	case "SEC":
+		return serviceAccountTokenRegex.MatchString(name) || strings.HasPrefix(name, "sh.helm.release.v1.")
+	case "CM":
+		return name == "kube-root-ca.crt" || name == "openshift-service-ca.crt"
+	}
+	return false
+}
+
+// logScope narrows the logs a details fetch returns
+type logScope struct {
+	container  string        // only this container of the pod ('c'), "" for all
+	previous   bool          // the previous, terminated container instance ('V')
+	since      time.Duration // only logs newer than this (:since), 0 for no limit
+	timestamps bool          // each line starts with its RFC3339 timestamp ('t')
+}
 
-		// Assemble items in consistent order (sorted by target name)
-		var globalItems []item
-		sort.Strings(targets) // Ensure consistent target order
-		for _, tName := range targets {
-			if items, exists := targetItems[tName]; exists {
-				globalItems = append(globalItems, items...)
-			}
-		}
+// logScopeFor returns the log scope that applies to it
+func (m model) logScopeFor(it item) logScope {
+	scope := logScope{since: m.logSince, timestamps: m.timestamps}
+	if it.Type == "POD" && it.Name == m.containerPod {
+		scope.container = m.container
+	}
+	scope.previous = it.Type == "POD" && it.Name == m.previousPod
+	return scope
+}
+
+// tailLines is how many lines to fetch: a time window gets a larger cap
+func (s logScope) tailLines(defaultLines int) int {
+	if s.since > 0 {
+		return SinceLogTailLines
+	}
+	return defaultLines
+}
 
-		return dataMsg{items: globalItems, selectors: updatedSelectors, helmReleases: updatedHelm, err: combinedErr}
+// label describes the scope for the Logs tab title, "" when unscoped
+func (s logScope) label() string {
+	var label string
+	if s.container != "" {
+		label = ": " + s.container
+	}
+	if s.since > 0 {
+		label += " (" + s.since.String() + ")"
 	}
+	if s.previous {
+		label += " (previous)"
+	}
+	return label
 }
 
-func fetchDetailsCmd(i item, tab int, selectors map[string]string, multiContainerInfo *multiContainerCache) tea.Cmd {
+// fetchDetailsCmd fetches the view of item i for a tab; scope narrows the
+// logs to one container or a time window
+func fetchDetailsCmd(kc k8s.Client, ns string, i item, tab int, selectors map[string]string, multiContainerInfo *multiContainerCache, scope logScope) tea.Cmd {
 	return func() tea.Msg {
 		var out []byte
 		var err error
@@ -1480,52 +3464,113 @@ func fetchDetailsCmd(i item, tab int, selectors map[string]string, multiContaine
 			return detailsMsg{content: "Service Group: " + i.Name, isYaml: false}
 		}
 
-		if i.Type == "DEP" {
-			if tab == 1 { // Events
-				out, err = client.GetEvents(ctx, Namespace)
-				if err != nil {
-					return detailsMsg{err: fmt.Errorf("Events error: %v", err)}
+		switch tabName(i.Type, tab) {
+		case TabEvents:
+			out, err = kc.GetEvents(ctx, ns)
+			if err != nil {
+				return detailsMsg{err: fmt.Errorf("Events error: %v", err)}
+			}
+			type eventRow struct {
+				at   time.Time
+				line string
+			}
+			objects := itemEventObjects(ctx, kc, ns, i, selectors[targetOf(i)])
+			now := time.Now()
+			var rows []eventRow
+			gjson.Get(string(out), "items").ForEach(func(_, e gjson.Result) bool {
+				if objects[eventObject(e)] {
+					at := eventTimestamp(e)
+					rows = append(rows, eventRow{at: at, line: fmt.Sprintf("%-6s %-10s %-22s %5d  %s", k8s.EventAge(now, at), e.Get("type").String(), e.Get("reason").String(), eventCount(e), e.Get("message").String())})
 				}
-				var events []string
-				events = append(events, fmt.Sprintf("%-25s %-10s %-15s %s", "TIMESTAMP", "TYPE", "REASON", "MESSAGE"))
-				gjson.Get(string(out), "items").ForEach(func(_, e gjson.Result) bool {
-					objName := e.Get("involvedObject.name").String()
-					if strings.Contains(objName, i.Name) {
-						ts := e.Get("lastTimestamp").String()
-						if ts == "" {
-							ts = e.Get("eventTime").String()
-						}
-						events = append(events, fmt.Sprintf("%-25s %-10s %-15s %s", ts, e.Get("type").String(), e.Get("reason").String(), e.Get("message").String()))
-					}
-					return true
-				})
-				if len(events) == 1 {
-					return detailsMsg{content: "No recent events found.", isYaml: false}
+				return true
+			})
+			if len(rows) == 0 {
+				return detailsMsg{content: "No recent events found.", isYaml: false}
+			}
+			// Don't trust the server order: events without lastTimestamp would sort to the epoch
+			sort.SliceStable(rows, func(a, b int) bool { return rows[a].at.Before(rows[b].at) })
+			events := []string{fmt.Sprintf("%-6s %-10s %-22s %5s  %s", "AGE", "TYPE", "REASON", "COUNT", "MESSAGE")}
+			for _, r := range rows {
+				events = append(events, r.line)
+			}
+			return detailsMsg{content: strings.Join(events, "\n"), isYaml: false}
+
+		case TabHistory:
+			return helmHistoryDetails(ctx, kc, ns, i.Name)
+
+		case TabNotes:
+			out, err = kc.GetHelmNotes(ctx, ns, i.Name)
+			if err != nil {
+				return detailsMsg{err: fmt.Errorf("Notes error: %v\n%s", err, string(out))}
+			}
+			if strings.TrimSpace(string(out)) == "" {
+				return detailsMsg{content: "Release " + i.Name + " has no NOTES.txt."}
+			}
+			return detailsMsg{content: string(out)}
+
+		case TabHooks:
+			return helmHooksDetails(ctx, kc, ns, i.Name)
+
+		case TabDescribe:
+			// Plain text: not highlighted as YAML nor formatted as logs
+			if i.Type == "DEP" {
+				out, err = kc.DescribeDeployment(ctx, ns, i.Name)
+			} else {
+				out, err = kc.DescribePod(ctx, ns, i.Name)
+			}
+			if err != nil {
+				return detailsMsg{err: fmt.Errorf("Describe error: %v\n%s", err, string(out))}
+			}
+			if i.Anomaly != "" {
+				out = append([]byte("⚠ Unexpected deployment shape: "+i.Anomaly+"\n\n"), out...)
+			}
+			return detailsMsg{content: string(out)}
+
+		case TabLogs:
+			if isWorkload(i.Type) { // Aggregated Logs
+				if scaledToZero(i) {
+					return detailsMsg{content: fmt.Sprintf("No running pods (%s scaled to 0)", editKinds[i.Type])}
 				}
-				return detailsMsg{content: strings.Join(events, "\n"), isYaml: false}
-			} else if tab == 2 { // Aggregated Logs
-				// Use cached selector data instead of kubectl call
-				selector, exists := selectors[i.Name]
+				// Use cached selector data
+				selector, exists := selectors[targetOf(i)]
 				if !exists || selector == "" {
-					return detailsMsg{err: fmt.Errorf("No label selector found for deployment %s", i.Name)}
+					return detailsMsg{err: fmt.Errorf("No label selector found for %s", targetOf(i))}
 				}
 
 				// Get logs from all pods using cached label selector
-				out, err = runCmd("kubectl", "logs", "-l", selector, "-n", Namespace, "--context", Context, "--all-containers=true", "--prefix", fmt.Sprintf("--tail=%d", DeploymentLogTail))
+				content, err := fetchAggregatedLogs(kc, ns, selector, scope)
 				if err != nil {
 					return detailsMsg{err: fmt.Errorf("Logs Err: %v", err)}
 				}
+				return detailsMsg{content: content, isYaml: false}
+			}
+
+			if scope.container != "" {
+				opts := k8s.LogOptions{TailLines: scope.tailLines(DefaultLogTailLines), Container: scope.container, Previous: scope.previous, Since: scope.since, Timestamps: scope.timestamps}
+				out, err = kc.GetPodLogsWithOptions(ctx, ns, i.Name, opts)
+				if scope.previous && (isNoPreviousLogs(err) || err == nil && len(out) == 0) {
+					return detailsMsg{content: fmt.Sprintf("No previous logs: container %s of %s has not restarted (V shows the current logs)", scope.container, i.Name)}
+				}
+				if err != nil {
+					return detailsMsg{err: fmt.Errorf("Log error (container %s): %v", scope.container, err)}
+				}
 				return detailsMsg{content: string(out), isYaml: false}
 			}
-		}
 
-		if i.Type == "POD" && tab == 1 {
 			// Detect if pod has multiple containers
-			isMulti, detectionErr := detectMultiContainer(i.Name, multiContainerInfo)
+			isMulti, detectionErr := detectMultiContainer(kc, ns, i.Name, multiContainerInfo)
 
 			// Use client to get pod logs
 			prefix := detectionErr == nil && isMulti
-			out, err = client.GetPodLogs(ctx, Namespace, i.Name, DefaultLogTailLines, true, prefix)
+			if scope.since > 0 || scope.timestamps || scope.previous {
+				opts := k8s.LogOptions{TailLines: scope.tailLines(DefaultLogTailLines), AllContainers: true, Prefix: prefix, Previous: scope.previous, Since: scope.since, Timestamps: scope.timestamps}
+				out, err = kc.GetPodLogsWithOptions(ctx, ns, i.Name, opts)
+			} else {
+				out, err = kc.GetPodLogs(ctx, ns, i.Name, DefaultLogTailLines, true, prefix)
+			}
+			if scope.previous && (isNoPreviousLogs(err) || err == nil && len(out) == 0) {
+				return detailsMsg{content: fmt.Sprintf("No previous logs: no container of %s has restarted (V shows the current logs)", i.Name)}
+			}
 			if err != nil {
 				return detailsMsg{err: fmt.Errorf("Log error: %v", err)}
 			}
@@ -1533,7 +3578,7 @@ func fetchDetailsCmd(i item, tab int, selectors map[string]string, multiContaine
 		}
 
 		if i.Type == "SEC" {
-			out, err = client.GetSecret(ctx, Namespace, i.Name)
+			out, err = kc.GetSecret(ctx, ns, i.Name)
 			if err == nil {
 				dataMap := gjson.Get(string(out), "data").Map()
 				decoded := make(map[string]string)
@@ -1544,25 +3589,33 @@ func fetchDetailsCmd(i item, tab int, selectors map[string]string, multiContaine
 				pretty, _ := json.MarshalIndent(decoded, "", "  ")
 				return detailsMsg{content: string(pretty), isYaml: true}
 			}
-		} else if i.Type == "HELM" {
-			out, err = client.GetHelmHistory(ctx, Namespace, i.Name)
-			isYaml = false
 		} else if i.Type == "CM" {
-			out, err = client.GetConfigMap(ctx, Namespace, i.Name)
-		} else if i.Type == "DEP" {
-			// For deployment YAML view (tab == 0)
-			out, err = client.GetDeployment(ctx, Namespace, i.Name)
+			out, err = kc.GetConfigMap(ctx, ns, i.Name)
+			if err == nil {
+				return configMapDetails(out, tab)
+			}
+		} else if i.Type == "SVC" {
+			return serviceDetails(ctx, kc, ns, i.Name)
+		} else if isWorkload(i.Type) {
+			// For deployment/statefulset YAML view
+			out, err = getWorkload(ctx, kc, ns, targetOf(i))
 			if err == nil {
 				// Pretty-print the JSON for readability
 				var prettyJSON bytes.Buffer
 				if jsonErr := json.Indent(&prettyJSON, out, "", "  "); jsonErr == nil {
 					out = prettyJSON.Bytes()
 				}
+				if summary := rolloutSummary(i); summary != "" {
+					out = append([]byte("# "+summary+"\n"), out...)
+				}
+				if i.Anomaly != "" {
+					out = append([]byte("# ⚠ Unexpected deployment shape: "+i.Anomaly+"\n"), out...)
+				}
 			}
 			isYaml = true
 		} else {
-			// For POD YAML, use kubectl for now (no GetPod method yet)
-			out, err = runCmd("kubectl", "get", "pod", i.Name, "-n", Namespace, "--context", Context, "-o", "yaml")
+			// For POD YAML view
+			out, err = kc.GetPod(ctx, ns, i.Name)
 		}
 
 		if err != nil {
@@ -1572,30 +3625,397 @@ func fetchDetailsCmd(i item, tab int, selectors map[string]string, multiContaine
 	}
 }
 
-func highlight(content, format string) string {
-	var buf bytes.Buffer
-	err := quick.Highlight(&buf, content, format, "terminal256", "dracula")
+// eventTimestamp returns when an event happened, using the first of
+// lastTimestamp, eventTime, firstTimestamp and the creation time that is set
+// and parses (same order as k8s.EventTime); zero if none does
+func eventTimestamp(e gjson.Result) time.Time {
+	for _, field := range []string{"lastTimestamp", "eventTime", "firstTimestamp", "metadata.creationTimestamp"} {
+		if at, err := time.Parse(time.RFC3339Nano, e.Get(field).String()); err == nil {
+			return at
+		}
+	}
+	return time.Time{}
+}
+
+// helmHooksDetails renders a release's hooks as a table with the state of
+// each hook resource in the cluster
+func helmHooksDetails(ctx context.Context, kc k8s.Client, ns string, release string) detailsMsg {
+	out, err := kc.GetHelmHooks(ctx, ns, release)
 	if err != nil {
-		return content
+		return detailsMsg{err: fmt.Errorf("Hooks error: %v\n%s", err, string(out))}
+	}
+	hooks, err := k8s.ParseHelmHooks(out)
+	if err != nil {
+		return detailsMsg{err: err}
+	}
+	if len(hooks) == 0 {
+		return detailsMsg{content: "Release " + release + " has no hooks."}
+	}
+
+	rows := []string{fmt.Sprintf("%-10s %-35s %-28s %-7s %-20s %s", "KIND", "NAME", "EVENTS", "WEIGHT", "DELETE POLICY", "STATUS")}
+	for _, h := range hooks {
+		weight := h.Weight
+		if weight == "" {
+			weight = "0"
+		}
+		rows = append(rows, fmt.Sprintf("%-10s %-35s %-28s %-7s %-20s %s", h.Kind, h.Name, h.Events, weight, h.DeletePolicy, hookStatus(ctx, kc, ns, h)))
 	}
-	return buf.String()
+	return detailsMsg{content: strings.Join(rows, "\n")}
 }
 
-func getCurrentDeploymentName(items []item, cursor int) string {
-	if len(items) == 0 || cursor >= len(items) {
+// hookStatus looks up a hook resource's outcome. Hooks removed by their
+// delete policy after running no longer exist, so "not found" is expected.
+func hookStatus(ctx context.Context, kc k8s.Client, ns string, h k8s.HelmHook) string {
+	out, err := kc.GetResource(ctx, ns, strings.ToLower(h.Kind), h.Name, "json")
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") || strings.Contains(string(out), "NotFound") {
+			if strings.Contains(h.DeletePolicy, "hook-succeeded") {
+				return "deleted (succeeded)"
+			}
+			return "not found"
+		}
+		return "unknown"
+	}
+	switch h.Kind {
+	case "Job":
+		switch {
+		case gjson.GetBytes(out, "status.succeeded").Int() > 0:
+			return "Succeeded"
+		case gjson.GetBytes(out, "status.failed").Int() > 0:
+			return "Failed"
+		default:
+			return "Running"
+		}
+	case "Pod":
+		return gjson.GetBytes(out, "status.phase").String()
+	}
+	return "present"
+}
+
+// fetchDebugLogCmd loads the tail of k9s-deck's own log file
+func fetchDebugLogCmd() tea.Cmd {
+	return func() tea.Msg {
+		out, err := logger.Tail(DefaultLogTailLines)
+		if err != nil {
+			return detailsMsg{err: fmt.Errorf("Debug log error (%s): %v", logger.Path(), err)}
+		}
+		if len(out) == 0 {
+			return detailsMsg{content: "Debug log is empty: " + logger.Path()}
+		}
+		return detailsMsg{content: string(out), lang: "json"}
+	}
+}
+
+// netTestScript probes $0:$1 with whichever tool the image ships.
+// It prints a "k9sdeck:<result> <tool>" marker so the outcome survives noisy output.
+const netTestScript = `if command -v nc >/dev/null 2>&1; then
+  nc -z -w 3 "$0" "$1" && echo "k9sdeck:ok nc" || echo "k9sdeck:fail nc"
+elif command -v wget >/dev/null 2>&1; then
+  wget -q -T 3 -O /dev/null "http://$0:$1/" 2>&1; rc=$?
+  # exit 8 means the server answered with an HTTP error, so it is reachable
+  if [ $rc -eq 0 ] || [ $rc -eq 8 ]; then echo "k9sdeck:ok wget"; else echo "k9sdeck:fail wget"; fi
+elif command -v bash >/dev/null 2>&1; then
+  bash -c 'exec 3<>"/dev/tcp/$0/$1"' "$0" "$1" && echo "k9sdeck:ok bash" || echo "k9sdeck:fail bash"
+else
+  echo "k9sdeck:notools"
+fi`
+
+// netTestCmd checks whether podName can open a TCP connection to target (host:port)
+func netTestCmd(kc k8s.Client, ns string, podName, target string) tea.Cmd {
+	kubeContext := Context
+	return func() tea.Msg {
+		host, port, err := net.SplitHostPort(target)
+		if err != nil || host == "" || !isPositiveInteger(port) {
+			return detailsMsg{err: fmt.Errorf("Invalid target %q, expected host:port", target)}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), LongCommandTimeout)
+		defer cancel()
+
+		containers, err := kc.GetPodContainers(ctx, ns, podName)
+		if err != nil || len(containers) == 0 {
+			return detailsMsg{err: fmt.Errorf("Cannot inspect pod %s: %v", podName, err)}
+		}
+		container := containers[0]
+
+		start := time.Now()
+		out, err := kc.ExecInPod(ctx, ns, podName, container, []string{"sh", "-c", netTestScript, host, port})
+		elapsed := time.Since(start)
+		output := strings.TrimSpace(string(out))
+
+		debugHint := fmt.Sprintf("Hint: attach a debug container with the tools instead:\n  kubectl debug -it %s -n %s --context %s --image=busybox --target=%s -- nc -zv -w 3 %s %s",
+			podName, ns, kubeContext, container, host, port)
+
+		var result string
+		switch {
+		case strings.Contains(output, "k9sdeck:ok"):
+			result = fmt.Sprintf("✓ %s can reach %s (%s, %dms incl. exec overhead)", podName, target, strings.TrimPrefix(lastLine(output), "k9sdeck:ok "), elapsed.Milliseconds())
+		case strings.Contains(output, "k9sdeck:fail"):
+			result = fmt.Sprintf("✗ %s cannot reach %s (%s, gave up after %dms)", podName, target, strings.TrimPrefix(lastLine(output), "k9sdeck:fail "), elapsed.Milliseconds())
+		case strings.Contains(output, "k9sdeck:notools"):
+			result = fmt.Sprintf("? Container %s has no nc, wget or bash to test with.\n\n%s", container, debugHint)
+		case err != nil:
+			// Typically a distroless image without a shell
+			result = fmt.Sprintf("? Could not exec into %s/%s: %v\n%s\n\n%s", podName, container, err, output, debugHint)
+		default:
+			result = "? Unexpected output:\n" + output
+		}
+		return detailsMsg{content: fmt.Sprintf("Connectivity test from %s (container %s) to %s\n\n%s", podName, container, target, result)}
+	}
+}
+
+// lastLine returns the final line of s
+func lastLine(s string) string {
+	if idx := strings.LastIndex(s, "\n"); idx != -1 {
+		return s[idx+1:]
+	}
+	return s
+}
+
+// triageCmd gathers ERROR/WARN log lines from every unhealthy pod, including
+// the previous container instance for pods that have been restarting
+func triageCmd(kc k8s.Client, ns string, pods []item) tea.Cmd {
+	return func() tea.Msg {
+		if len(pods) == 0 {
+			return detailsMsg{content: "Triage: all pods across monitored targets are healthy."}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), LongCommandTimeout)
+		defer cancel()
+
+		sections := make([]string, len(pods))
+		var wg sync.WaitGroup
+		for idx, pod := range pods {
+			wg.Add(1)
+			go func(idx int, pod item) {
+				defer wg.Done()
+				sections[idx] = triagePod(ctx, kc, ns, pod)
+			}(idx, pod)
+		}
+		wg.Wait()
+
+		header := fmt.Sprintf("Triage: %d unhealthy pod(s), ERROR/WARN lines from the last %d lines of each container\n\n", len(pods), DeploymentLogTail)
+		return detailsMsg{content: header + strings.Join(sections, "\n"), isLog: true}
+	}
+}
+
+// triagePod returns the error-filtered current and previous logs of one pod
+func triagePod(ctx context.Context, kc k8s.Client, ns string, pod item) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== %s (%s) ===\n", pod.Name, pod.Status)
+
+	opts := k8s.LogOptions{TailLines: DeploymentLogTail, AllContainers: true, Prefix: true}
+	current, err := kc.GetPodLogsWithOptions(ctx, ns, pod.Name, opts)
+	if err != nil {
+		fmt.Fprintf(&b, "failed to fetch logs: %v\n", err)
+	} else {
+		b.WriteString(filterErrorLines(string(current)))
+	}
+
+	// Crashed containers usually explain themselves in the previous instance;
+	// pods that never restarted simply have none
+	opts.Previous = true
+	if previous, err := kc.GetPodLogsWithOptions(ctx, ns, pod.Name, opts); err == nil && len(previous) > 0 {
+		b.WriteString("--- previous container ---\n")
+		b.WriteString(filterErrorLines(string(previous)))
+	}
+	return b.String()
+}
+
+// isNoPreviousLogs reports whether err means there is no previous container
+// instance to read logs from, i.e. the container never restarted
+func isNoPreviousLogs(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "previous terminated container")
+}
+
+// filterErrorLines keeps only lines logged at WARN level or above
+func filterErrorLines(content string) string {
+	var kept []string
+	for _, line := range strings.Split(content, "\n") {
+		switch parser.ParseLogLine(line).LogLevel {
+		case "FATAL", "ERROR", "ERR", "WARN", "WARNING":
+			kept = append(kept, line)
+		}
+	}
+	if len(kept) == 0 {
+		return "(no ERROR/WARN lines)\n"
+	}
+	return strings.Join(kept, "\n") + "\n"
+}
+
+// checkPullSecret verifies an imagePullSecret exists and holds a decodable
+// docker config. Returns a short problem description, or "" if it looks valid.
+func checkPullSecret(ctx context.Context, kc k8s.Client, ns string, name string) string {
+	out, err := kc.GetSecret(ctx, ns, name)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return "missing"
+		}
+		return "unreadable"
+	}
+
+	var dataKey string
+	switch secretType := gjson.GetBytes(out, "type").String(); secretType {
+	case "kubernetes.io/dockerconfigjson":
+		dataKey = ".dockerconfigjson"
+	case "kubernetes.io/dockercfg":
+		dataKey = ".dockercfg"
+	default:
+		return "wrong type " + secretType
+	}
+
+	encoded := gjson.GetBytes(out, "data."+gjson.Escape(dataKey)).String()
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || !json.Valid(decoded) {
+		return "invalid " + dataKey
+	}
+	if dataKey == ".dockerconfigjson" && !gjson.GetBytes(decoded, "auths").IsObject() {
+		return "no auths"
+	}
+	return ""
+}
+
+// fetchAggregatedLogs fetches logs from every pod matching selector in
+// parallel. Pods whose logs fail are listed in a footnote instead of failing
+// the whole view; an error is only returned if nothing could be fetched.
+// Tailing many pods takes longer than a single request, so this gets
+// LongCommandTimeout rather than the CommandTimeout of the other tabs.
+func fetchAggregatedLogs(kc k8s.Client, ns string, selector string, scope logScope) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), LongCommandTimeout)
+	defer cancel()
+
+	podOut, err := kc.ListPods(ctx, ns, selector)
+	if err != nil {
+		return "", err
+	}
+	var pods []string
+	gjson.GetBytes(podOut, "items.#.metadata.name").ForEach(func(_, v gjson.Result) bool {
+		pods = append(pods, v.String())
+		return true
+	})
+	if len(pods) == 0 {
+		return "No pods found for selector " + selector, nil
+	}
+
+	logs := make([][]byte, len(pods))
+	errs := make([]error, len(pods))
+	var wg sync.WaitGroup
+	for idx, pod := range pods {
+		wg.Add(1)
+		go func(idx int, pod string) {
+			defer wg.Done()
+			if scope.since > 0 || scope.timestamps {
+				opts := k8s.LogOptions{TailLines: scope.tailLines(DeploymentLogTail), AllContainers: true, Prefix: true, Since: scope.since, Timestamps: scope.timestamps}
+				logs[idx], errs[idx] = kc.GetPodLogsWithOptions(ctx, ns, pod, opts)
+				return
+			}
+			logs[idx], errs[idx] = kc.GetPodLogs(ctx, ns, pod, DeploymentLogTail, true, true)
+		}(idx, pod)
+	}
+	wg.Wait()
+
+	var content strings.Builder
+	var failures []string
+	for idx, pod := range pods {
+		if errs[idx] != nil {
+			failures = append(failures, fmt.Sprintf("  %s: %v", pod, errs[idx]))
+			continue
+		}
+		content.Write(logs[idx])
+	}
+
+	if len(failures) == len(pods) {
+		return "", fmt.Errorf("failed to fetch logs for all %d pods\n%s", len(pods), strings.Join(failures, "\n"))
+	}
+	if len(failures) > 0 {
+		content.WriteString(fmt.Sprintf("\n--- Failed to fetch logs for %d of %d pods ---\n", len(failures), len(pods)))
+		content.WriteString(strings.Join(failures, "\n"))
+	}
+	return content.String(), nil
+}
+
+// configMapDetails renders the whole ConfigMap for tab 0, or the value of a
+// single key (sorted order) for tab N
+func configMapDetails(out []byte, tab int) detailsMsg {
+	jsonOut, err := yaml.YAMLToJSON(out)
+	if err != nil {
+		return detailsMsg{content: string(out), isYaml: true}
+	}
+
+	data := gjson.GetBytes(jsonOut, "data").Map()
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	if tab < 1 || tab > len(keys) {
+		return detailsMsg{content: string(out), isYaml: true, cmKeys: keys}
+	}
+	key := keys[tab-1]
+	value := data[key].String()
+	return detailsMsg{content: value, lang: detectConfigLang(key, value), cmKeys: keys}
+}
+
+// detectConfigLang picks a chroma lexer for a ConfigMap value from the key's
+// file extension, falling back to sniffing the content ("" = plain text)
+func detectConfigLang(key, value string) string {
+	if lexer := lexers.Match(key); lexer != nil {
+		return lexer.Config().Name
+	}
+
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
 		return ""
 	}
-	curr := items[cursor]
-	if curr.Type == "DEP" {
-		return curr.Name
+	if parser.DetectJSONLog(trimmed) && json.Valid([]byte(trimmed)) {
+		return "json"
+	}
+	if propertiesLineRegex.MatchString(trimmed) {
+		return "properties"
+	}
+	// Multi-line values that parse into a YAML map or list
+	if strings.Contains(trimmed, "\n") {
+		if asJSON, err := yaml.YAMLToJSON([]byte(trimmed)); err == nil && parser.DetectJSONLog(string(asJSON)) {
+			return "yaml"
+		}
+	}
+	return ""
+}
+
+func highlight(content, format string) string {
+	return parser.HighlightWith(content, format, syntaxStyle(), SyntaxFormatter)
+}
+
+// getCurrentDeploymentName returns the target of the workload owning the
+// item at cursor: a deployment's name, or "sts/<name>" for a statefulset
+func getCurrentDeploymentName(items []item, cursor int) string {
+	if idx := getCurrentDeploymentIndex(items, cursor); idx != -1 {
+		return targetOf(items[idx])
+	}
+	return ""
+}
+
+// getCurrentDeploymentIndex returns the index of the DEP or STS item owning
+// the item at cursor, or -1 (e.g. on a failed group's header)
+func getCurrentDeploymentIndex(items []item, cursor int) int {
+	if len(items) == 0 || cursor >= len(items) {
+		return -1
 	}
 	// Find the deployment this resource belongs to
 	for i := cursor; i >= 0; i-- {
-		if items[i].Type == "DEP" {
-			return items[i].Name
+		if isWorkload(items[i].Type) {
+			return i
+		}
+		if items[i].Type == "HDR" && i != cursor {
+			break
 		}
 	}
-	return ""
+	// A group header belongs to the deployment right below it
+	if items[cursor].Type == "HDR" && cursor+1 < len(items) && isWorkload(items[cursor+1].Type) {
+		return cursor + 1
+	}
+	return -1
 }
 
 func getCurrentHelmRelease(items []item, cursor int, helmReleases map[string]string) string {
@@ -1638,9 +4058,23 @@ func isValidK8sName(name string) bool {
 	return true
 }
 
+// suggestionActions names the Enter action of each prompt with autocomplete
+var suggestionActions = map[string]string{
+	"add":       "Add",
+	"remove":    "Remove",
+	"namespace": "Switch",
+	"context":   "Switch",
+}
+
+// hasSuggestions reports whether the shortcut prompt offers autocomplete
+func hasSuggestions(shortcutMode string) bool {
+	_, ok := suggestionActions[shortcutMode]
+	return ok
+}
+
 // updateSuggestions filters the available suggestions based on current input
 func (m *model) updateSuggestions() {
-	if (m.shortcutMode != "add" && m.shortcutMode != "remove") || len(m.suggestions) == 0 {
+	if !hasSuggestions(m.shortcutMode) || len(m.suggestions) == 0 {
 		m.showSuggestions = false
 		return
 	}
@@ -1655,21 +4089,15 @@ func (m *model) updateSuggestions() {
 	// Filter suggestions that contain the input
 	filtered := make([]string, 0, len(m.suggestions))
 
-	// Build a map of targets for O(1) lookup instead of O(n)
-	targetMap := make(map[string]bool, len(m.targets))
-	for _, target := range m.targets {
-		targetMap[target] = true
-	}
-
 	for _, suggestion := range m.suggestions {
 		if strings.Contains(strings.ToLower(suggestion), input) {
 			if m.shortcutMode == "add" {
 				// For add mode: Don't suggest deployments already being monitored
-				if !targetMap[suggestion] {
+				if !m.hasTarget(suggestion) {
 					filtered = append(filtered, suggestion)
 				}
-			} else if m.shortcutMode == "remove" {
-				// For remove mode: Only suggest currently monitored deployments
+			} else {
+				// For remove mode (current targets) and namespaces: suggest every match
 				filtered = append(filtered, suggestion)
 			}
 		}
@@ -1680,6 +4108,30 @@ func (m *model) updateSuggestions() {
 	m.suggestionIndex = 0
 }
 
+// insertInput inserts pasted text at the cursor as a single edit
+func (m *model) insertInput(text string) {
+	// The input is single-line: fold newlines and drop surrounding whitespace
+	text = strings.TrimSpace(strings.NewReplacer("\r\n", " ", "\n", " ", "\t", " ").Replace(text))
+	if text == "" {
+		return
+	}
+
+	value := []rune(m.textInput.Value())
+	pos := minInt(m.textInput.Position(), len(value))
+	inserted := []rune(text)
+	newValue := make([]rune, 0, len(value)+len(inserted))
+	newValue = append(newValue, value[:pos]...)
+	newValue = append(newValue, inserted...)
+	newValue = append(newValue, value[pos:]...)
+
+	m.textInput.SetValue(string(newValue))
+	m.textInput.SetCursor(pos + len(inserted))
+
+	if hasSuggestions(m.shortcutMode) {
+		m.updateSuggestions()
+	}
+}
+
 // getFilteredSuggestions returns suggestions for display (limited to MaxSuggestions)
 func (m *model) getFilteredSuggestions() []string {
 	if !m.showSuggestions || len(m.suggestions) == 0 {
@@ -1694,154 +4146,14 @@ func (m *model) getFilteredSuggestions() []string {
 
 // --- LOG PROCESSING FUNCTIONS ---
 
-// parseLogLine extracts components from a log line
-func parseLogLine(line string) logLineInfo {
-	info := logLineInfo{
-		OriginalLine: line,
-		LogContent:   line,
-	}
-
-	// Try to extract pod prefix [pod/podname/container] or [podname/container]
-	if matches := podPrefixRegex.FindStringSubmatch(line); len(matches) == 5 {
-		// kubectl --prefix format: [pod/podname/container]
-		info.PodPrefix = matches[2] + "/" + matches[3]
-		info.PodName = matches[2]
-		info.ContainerName = matches[3]
-		info.LogContent = matches[4]
-	}
-
-	// Detect log level
-	if levelMatches := logLevelRegex.FindStringSubmatch(info.LogContent); len(levelMatches) > 1 {
-		info.LogLevel = strings.ToUpper(levelMatches[1])
-	}
-
-	// Detect JSON
-	trimmed := strings.TrimSpace(info.LogContent)
-	info.IsJSON = (strings.HasPrefix(trimmed, "{") && strings.HasSuffix(trimmed, "}")) ||
-		(strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]"))
-
-	return info
-}
-
-// getPodColor returns a consistent color for a pod name using hash
-func getPodColor(podName string) lipgloss.Color {
-	hash := 0
-	for _, c := range podName {
-		hash = (hash*31 + int(c)) % len(podColorPalette)
-	}
-	if hash < 0 {
-		hash = -hash
-	}
-	return podColorPalette[hash%len(podColorPalette)]
-}
-
-// getLogLevelColor returns the color for a log level
-func getLogLevelColor(level string) lipgloss.Color {
-	normalized := strings.ToUpper(strings.TrimSpace(level))
-	switch normalized {
-	case "FATAL", "ERROR", "ERR":
-		return cRed
-	case "WARN", "WARNING":
-		return cYellow
-	case "INFO":
-		return lipgloss.Color("39") // Cyan
-	case "DEBUG":
-		return cGray
-	case "TRACE":
-		return lipgloss.Color("238") // Darker gray
-	default:
-		return lipgloss.Color("255") // Default white
-	}
-}
-
-// shortenPodPrefix extracts replicaset hash and pod suffix
-func shortenPodPrefix(podName, containerName string) string {
-	// Pod format: deployment-replicasethash-podhash
-	// Example: third-service-55c74d7f8-zn5fd
-	// We want: [55c74d7f8-zn5fd]
-	// (deployment name is redundant since we're already viewing that deployment)
-
-	parts := strings.Split(podName, "-")
-	if len(parts) < 3 {
-		// If pod name doesn't follow expected format, return as-is
-		return fmt.Sprintf("[%s]", podName)
-	}
-
-	// Extract replicaset hash (second to last part)
-	replicaSetHash := parts[len(parts)-2]
-
-	// Extract unique pod suffix (last part)
-	podSuffix := parts[len(parts)-1]
-
-	return fmt.Sprintf("[%s-%s]", replicaSetHash, podSuffix)
-}
-
-// formatPodPrefix formats pod prefix with color and icon
-func formatPodPrefix(podName, containerName string) string {
-	shortened := shortenPodPrefix(podName, containerName)
-	color := getPodColor(podName)
-	icon := "●"
-
-	style := lipgloss.NewStyle().Foreground(color).Bold(true)
-	return style.Render(icon + " " + shortened)
-}
-
-// colorizeLogLevel applies color to log level keywords in a line
-func colorizeLogLevel(line string) string {
-	matches := logLevelRegex.FindAllStringIndex(line, -1)
-	if len(matches) == 0 {
-		return line
-	}
-
-	var result strings.Builder
-	lastIndex := 0
-
-	for _, match := range matches {
-		start, end := match[0], match[1]
-
-		// Write content before match
-		result.WriteString(line[lastIndex:start])
-
-		// Colorize the level
-		level := line[start:end]
-		color := getLogLevelColor(level)
-		style := lipgloss.NewStyle().Foreground(color).Bold(true)
-		result.WriteString(style.Render(level))
-
-		lastIndex = end
-	}
-
-	// Write remaining content
-	result.WriteString(line[lastIndex:])
-	return result.String()
-}
-
-// detectJSONLog checks if a line is JSON format
-func detectJSONLog(line string) bool {
-	trimmed := strings.TrimSpace(line)
-	return (strings.HasPrefix(trimmed, "{") && strings.HasSuffix(trimmed, "}")) ||
-		(strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]"))
-}
-
-// prettyPrintJSONLog formats and highlights JSON logs
-func prettyPrintJSONLog(line string) string {
-	// Try to parse and pretty-print JSON
-	var obj interface{}
-	if err := json.Unmarshal([]byte(line), &obj); err != nil {
-		return line // Return original if not valid JSON
-	}
-
-	pretty, err := json.MarshalIndent(obj, "", "  ")
-	if err != nil {
-		return line
-	}
-
-	// Apply Chroma syntax highlighting
-	return highlight(string(pretty), "json")
+// processLogContent formats logs for the details pane with the active
+// syntax style (see parser.ProcessLogContent)
+func processLogContent(content, resourceType, resourceName string, formatMode bool, jsonMode parser.JSONLogMode, collapse bool) string {
+	return parser.ProcessLogContent(content, resourceType, resourceName, formatMode, jsonMode, collapse, highlight)
 }
 
 // detectMultiContainer checks if a pod has multiple containers (with caching)
-func detectMultiContainer(podName string, cache *multiContainerCache) (bool, error) {
+func detectMultiContainer(kc k8s.Client, ns string, podName string, cache *multiContainerCache) (bool, error) {
 	// Check cache first
 	cache.mu.RLock()
 	if result, exists := cache.cache[podName]; exists {
@@ -1854,7 +4166,7 @@ func detectMultiContainer(podName string, cache *multiContainerCache) (bool, err
 	ctx, cancel := context.WithTimeout(context.Background(), CommandTimeout)
 	defer cancel()
 
-	containerNames, err := client.GetPodContainers(ctx, Namespace, podName)
+	containerNames, err := kc.GetPodContainers(ctx, ns, podName)
 	if err != nil {
 		return false, err
 	}
@@ -1868,51 +4180,3 @@ func detectMultiContainer(podName string, cache *multiContainerCache) (bool, err
 
 	return isMulti, nil
 }
-
-// processLogContent is the master log processing function
-func processLogContent(content, resourceType, resourceName string, formatMode bool) string {
-	if !formatMode {
-		return content // Raw mode - return unchanged
-	}
-
-	lines := strings.Split(content, "\n")
-	processed := make([]string, 0, len(lines))
-
-	for _, line := range lines {
-		if strings.TrimSpace(line) == "" {
-			processed = append(processed, line)
-			continue
-		}
-
-		// Parse line structure
-		info := parseLogLine(line)
-
-		// Check if JSON
-		if detectJSONLog(info.LogContent) {
-			// Format as JSON
-			formatted := prettyPrintJSONLog(info.LogContent)
-			if info.PodPrefix != "" {
-				prefix := formatPodPrefix(info.PodName, info.ContainerName)
-				processed = append(processed, prefix+" "+formatted)
-			} else {
-				processed = append(processed, formatted)
-			}
-		} else {
-			// Standard text log with level coloring
-			formattedLine := line
-
-			// Add pod prefix formatting if present
-			if info.PodPrefix != "" {
-				prefix := formatPodPrefix(info.PodName, info.ContainerName)
-				colorizedContent := colorizeLogLevel(info.LogContent)
-				formattedLine = prefix + " " + colorizedContent
-			} else {
-				formattedLine = colorizeLogLevel(line)
-			}
-
-			processed = append(processed, formattedLine)
-		}
-	}
-
-	return strings.Join(processed, "\n")
-}
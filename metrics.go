@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/devpopsdotin/k9s-deck/internal/k8s"
+)
+
+// --- POD METRICS ---
+
+const (
+	MetricsInterval      = 15 * time.Second // metrics-server scrapes every 15s by default
+	MetricsRetryInterval = 5 * time.Minute  // while the cluster has no metrics API
+)
+
+// metricsTickMsg and metricsMsg carry the generation of the metrics loop
+// they belong to; a namespace or context switch starts a new one
+type metricsTickMsg struct {
+	gen int
+}
+type metricsMsg struct {
+	gen     int
+	metrics map[string]k8s.PodMetrics
+	err     error
+}
+
+// fetchMetricsCmd fetches the usage of every pod in the namespace
+func fetchMetricsCmd(kc k8s.Client, ns string, gen int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), CommandTimeout)
+		defer cancel()
+
+		metrics, err := kc.GetPodMetrics(ctx, ns, "")
+		return metricsMsg{gen: gen, metrics: metrics, err: err}
+	}
+}
+
+// metricsTickCmd schedules the next metrics fetch, apart from the main
+// refresh so a slow metrics API never delays it
+func metricsTickCmd(gen int, interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return metricsTickMsg{gen: gen}
+	})
+}
+
+// restartMetrics drops the usage of the previous namespace or context and
+// starts a new metrics loop, retiring the old one
+func (m *model) restartMetrics() tea.Cmd {
+	m.metricsGen++
+	m.podMetrics = nil
+	m.metricsUnavailable = false
+	return fetchMetricsCmd(client, Namespace, m.metricsGen)
+}
+
+// handleMetricsMsg stores fetched usage and schedules the next fetch.
+// Without metrics-server the rows just show no usage.
+func (m *model) handleMetricsMsg(msg metricsMsg) tea.Cmd {
+	if msg.gen != m.metricsGen {
+		return nil
+	}
+	switch {
+	case errors.Is(msg.err, k8s.ErrMetricsUnavailable):
+		if !m.metricsUnavailable {
+			slog.Info("metrics API not available, not showing pod usage", "namespace", Namespace)
+		}
+		m.metricsUnavailable = true
+		m.podMetrics = nil
+		return metricsTickCmd(msg.gen, MetricsRetryInterval)
+	case msg.err != nil:
+		slog.Debug("failed to fetch pod metrics", "namespace", Namespace, "error", msg.err)
+		m.podMetrics = nil
+		return metricsTickCmd(msg.gen, MetricsInterval)
+	}
+	m.metricsUnavailable = false
+	m.podMetrics = msg.metrics
+	return metricsTickCmd(msg.gen, MetricsInterval)
+}
+
+// podUsage renders a pod's usage as "cpu/mem", "" without metrics
+func (m model) podUsage(pod string) string {
+	pm, ok := m.podMetrics[pod]
+	if !ok {
+		return ""
+	}
+	return k8s.FormatCPU(pm.CPUMilli) + "/" + k8s.FormatMemory(pm.MemoryBytes)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- MOUSE ---
+
+// updateMouse handles mouse events in normal mode, hit-testing against the
+// layout View draws: a left click on a sidebar row selects it, a left click on
+// a tab label switches to it, and the wheel scrolls whichever pane the
+// pointer is over
+func (m *model) updateMouse(msg tea.MouseMsg) tea.Cmd {
+	if m.showHelp || m.dashboardMode || m.picker != nil {
+		return nil
+	}
+	detailsX, detailsY := m.detailsOrigin()
+	inList := msg.X < detailsX || msg.Y < detailsY
+
+	if tea.MouseEvent(msg).IsWheel() {
+		if inList {
+			m.scrollList(msg.Button)
+			return nil
+		}
+		var cmd tea.Cmd
+		m.viewport, cmd = m.viewport.Update(msg)
+		return cmd
+	}
+	if msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft {
+		return nil
+	}
+
+	if inList {
+		if idx, ok := m.sidebarRowAt(msg.Y); ok {
+			return m.clickSelect(idx, 0)
+		}
+		return nil
+	}
+	if tab, ok := m.tabAt(msg.X-detailsX, msg.Y-detailsY); ok {
+		return m.clickSelect(m.cursor, tab)
+	}
+	return nil
+}
+
+// sidebarRowAt returns the index in m.items of the sidebar row at line y
+func (m model) sidebarRowAt(y int) (int, bool) {
+	// The header may wrap (e.g. a long error), so measure it as rendered
+	header := stylePane.Width(m.leftPaneWidth()).Render(lipgloss.JoinVertical(lipgloss.Left, m.sidebarHeader()...))
+	row := y - lipgloss.Height(header)
+	if row < 0 || row >= m.listHeight {
+		return 0, false
+	}
+	idx := m.listOffset + row
+	return idx, idx < len(m.items)
+}
+
+// tabAt returns the activeTab of the tab label at x, y (relative to the
+// details pane's top left corner)
+func (m model) tabAt(x, y int) (int, bool) {
+	labels, tabs := m.tabBar()
+	if y >= lipgloss.Height(lipgloss.JoinHorizontal(lipgloss.Top, labels...)) {
+		return 0, false
+	}
+	for idx, label := range labels {
+		width := lipgloss.Width(label)
+		if x < width {
+			return tabs[idx], true
+		}
+		x -= width
+	}
+	return 0, false
+}
+
+// clickSelect moves the selection to item idx and tab, like the keyboard
+// would, and fetches its details if either changed
+func (m *model) clickSelect(idx, tab int) tea.Cmd {
+	if idx == m.cursor && tab == m.activeTab {
+		return nil
+	}
+	if idx != m.cursor {
+		m.cursor = idx
+		tab = 0
+	}
+	m.activeTab = tab
+	m.detailView = ""
+	if m.follow != nil {
+		m.stopFollow()
+	}
+	m.dropPodLogChoices()
+	it := m.items[m.cursor]
+	return fetchDetailsCmd(client, Namespace, it, m.activeTab, copySelectorMap(m.selectors), m.multiContainerInfo, m.logScopeFor(it))
+}
+
+// scrollList scrolls the sidebar for a wheel event by as many lines as the
+// details pane scrolls. The cursor stays on its item, even out of view, so
+// scrolling doesn't reload the details; moving it scrolls back to it.
+func (m *model) scrollList(button tea.MouseButton) {
+	switch button {
+	case tea.MouseButtonWheelUp:
+		m.listOffset -= m.viewport.MouseWheelDelta
+	case tea.MouseButtonWheelDown:
+		m.listOffset += m.viewport.MouseWheelDelta
+	}
+	m.listOffset = maxInt(minInt(m.listOffset, len(m.items)-m.listHeight), 0)
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/devpopsdotin/k9s-deck/internal/k8s"
+)
+
+// --- NAMESPACE SWITCHING ---
+
+// namespaceSwitchMsg carries the deployments of the namespace being switched to
+type namespaceSwitchMsg struct {
+	namespace   string
+	deployments []string
+	focusPod    string // from :find, listed and selected after the switch
+	err         error
+}
+
+// switchNamespaceCmd lists the deployments of ns; the switch itself happens
+// in Update so a missing or empty namespace leaves the current one in place
+func switchNamespaceCmd(kc k8s.Client, ns string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), CommandTimeout)
+		defer cancel()
+
+		deployments, err := kc.ListDeployments(ctx, ns)
+		return namespaceSwitchMsg{namespace: ns, deployments: deployments, err: err}
+	}
+}
+
+// fetchAvailableNamespaces gets all namespaces for the namespace prompt
+func fetchAvailableNamespaces(kc k8s.Client) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), CommandTimeout)
+		defer cancel()
+
+		namespaces, err := kc.ListNamespaces(ctx)
+		if err != nil {
+			return suggestionsMsg{mode: "namespace", names: []string{}}
+		}
+		return suggestionsMsg{mode: "namespace", names: namespaces}
+	}
+}
+
+// switchNamespace makes msg.namespace the active namespace. Targets that
+// also exist there are kept, otherwise its first deployment is monitored.
+func (m *model) switchNamespace(msg namespaceSwitchMsg) tea.Cmd {
+	if msg.err != nil {
+		m.rawContent = fmt.Sprintf("Cannot switch to namespace '%s': %v", msg.namespace, msg.err)
+		m.updateViewportContent()
+		return nil
+	}
+	if msg.focusPod != "" {
+		return m.focusPod(msg)
+	}
+	if len(msg.deployments) == 0 {
+		m.rawContent = fmt.Sprintf("Namespace '%s' has no deployments", msg.namespace)
+		m.updateViewportContent()
+		return nil
+	}
+
+	Namespace = msg.namespace
+	m.setTargets(carryOverTargets(m.targets, msg.deployments))
+	m.resetClusterState()
+
+	m.statusMsg = "Switched to namespace " + Namespace
+	return tea.Batch(m.refreshCmd(), m.restartMetrics(), clearStatusLater(), m.scheduleConfigSave())
+}
+
+// focusPod switches to msg.namespace, which needn't have deployments, and
+// lists its pods named like msg.focusPod with that pod selected
+func (m *model) focusPod(msg namespaceSwitchMsg) tea.Cmd {
+	Namespace = msg.namespace
+	if len(msg.deployments) > 0 {
+		m.setTargets(carryOverTargets(m.targets, msg.deployments))
+	}
+	m.resetClusterState()
+	m.podsMode, m.podsFilter = true, msg.focusPod
+	// The refresh keeps the cursor on the item it was on
+	m.items = []item{{Type: "POD", Name: msg.focusPod}}
+
+	m.statusMsg = fmt.Sprintf("Switched to namespace %s, pod %s", Namespace, msg.focusPod)
+	return tea.Batch(m.refreshCmd(), m.restartMetrics(), clearStatusLater(), m.scheduleConfigSave())
+}
+
+// carryOverTargets keeps the targets found in deployments, falling back to
+// the first deployment by name when none of them exist there
+func carryOverTargets(targets, deployments []string) []string {
+	var kept []string
+	for _, t := range targets {
+		if containsString(deployments, t) {
+			kept = append(kept, t)
+		}
+	}
+	if len(kept) == 0 {
+		sorted := append([]string(nil), deployments...)
+		sort.Strings(sorted)
+		kept = []string{sorted[0]}
+	}
+	return kept
+}
+
+// resetClusterState drops everything cached from the previous namespace or
+// context: selectors, Helm releases, stale items, container info and views
+func (m *model) resetClusterState() {
+	m.dataGen++
+	m.fetching = false
+	m.stopFollow()
+	m.stopWatches()
+	m.peek = nil
+	m.selectors = make(map[string]string)
+	m.manualSelectors = make(map[string]string)
+	m.scaleUndo = make(map[string]int)
+	m.helmReleases = make(map[string]string)
+	m.lastGoodItems = make(map[string][]item)
+	m.lastGoodAt = make(map[string]time.Time)
+	parsedWorkloads.clear()
+	m.multiContainerInfo = &multiContainerCache{cache: make(map[string]bool)}
+	m.items = nil
+	m.cmKeys = nil
+	m.cursor, m.listOffset, m.activeTab, m.dashCursor = 0, 0, 0, 0
+	m.detailView = ""
+	m.rawContent = ""
+	m.updateViewportContent()
+}
@@ -0,0 +1,20 @@
+package main
+
+import "testing"
+
+func TestStaleRefreshDropped(t *testing.T) {
+	m := initialModel(savedState{}, []string{"web"})
+	stale := m.dataGen
+	m.resetClusterState()
+
+	items := map[string][]item{"web": {{Type: "DEP", Name: "web"}}}
+	update(t, &m, dataMsg{gen: stale, targetItems: items})
+	if len(m.items) != 0 {
+		t.Fatalf("refresh from before the switch should be dropped, got %v", m.items)
+	}
+
+	update(t, &m, dataMsg{gen: m.dataGen, targetItems: items})
+	if len(m.items) == 0 {
+		t.Fatal("refresh of the current namespace should be applied")
+	}
+}
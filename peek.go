@@ -0,0 +1,70 @@
+package main
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- PEEK PANE ---
+
+// PeekHeight is the number of lines the pinned pane shows, title included
+const PeekHeight = 8
+
+// peekPane is a detail view pinned below the main viewport with 'p'
+type peekPane struct {
+	item    item
+	tab     int
+	scope   logScope // container/time window the pinned logs are scoped to
+	source  string   // what was pinned, e.g. "POD web-1 / logs"
+	live    bool     // re-fetched on every refresh; command output stays frozen
+	content string   // rendered content
+}
+
+// peekMsg carries a refreshed detail view for the peek pane
+type peekMsg struct {
+	details detailsMsg
+}
+
+// pinPeek pins whatever the detail pane shows right now
+func (m *model) pinPeek() {
+	p := &peekPane{source: m.detailSource(), content: m.rawContent, live: m.detailView == ""}
+	if len(m.items) > 0 && m.cursor < len(m.items) {
+		p.item = m.items[m.cursor]
+		p.tab = m.activeTab
+		p.scope = m.logScopeFor(p.item)
+	} else {
+		p.live = false
+	}
+	m.peek = p
+}
+
+// peekCmd re-fetches the pinned item's view
+func peekCmd(p *peekPane, selectors map[string]string, multiContainerInfo *multiContainerCache) tea.Cmd {
+	fetch := fetchDetailsCmd(client, Namespace, p.item, p.tab, selectors, multiContainerInfo, p.scope)
+	return func() tea.Msg {
+		details, _ := fetch().(detailsMsg)
+		return peekMsg{details: details}
+	}
+}
+
+// peekView renders the tail of the pinned content in a small bordered pane
+func (m model) peekView() string {
+	width := maxInt(m.viewport.Width-2, MinWrapWidth)
+	bodyLines := PeekHeight - 1
+
+	content := strings.ReplaceAll(m.peek.content, "\r\n", "\n")
+	wrapped := strings.Split(lipgloss.NewStyle().Width(width).Render(content), "\n")
+	// Show the tail: that's where new log lines and events appear
+	if len(wrapped) > bodyLines {
+		wrapped = wrapped[len(wrapped)-bodyLines:]
+	}
+
+	title := icon("pin") + " " + m.peek.source
+	if !m.peek.live {
+		title += " (frozen)"
+	}
+	lines := append([]string{styleTitle.Render(title)}, wrapped...)
+	return styleBorder.Width(m.viewport.Width).Height(PeekHeight).MaxHeight(PeekHeight + 2).Render(strings.Join(lines, "\n"))
+}
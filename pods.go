@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tidwall/gjson"
+
+	"github.com/devpopsdotin/k9s-deck/internal/k8s"
+)
+
+// --- NAMESPACE POD VIEW ---
+
+// refreshCmd refreshes whatever the sidebar shows: the monitored targets,
+// or every pod in the namespace while :pods is active
+func (m model) refreshCmd() tea.Cmd {
+	if m.podsMode {
+		return fetchAllPodsCmd(client, Namespace, m.dataGen, m.podsFilter)
+	}
+	return fetchDataCmd(client, Context, Namespace, m.dataGen, m.targets, copySelectorMap(m.manualSelectors))
+}
+
+// fetchAllPodsCmd lists every pod in the namespace, whatever owns it, as a
+// flat list. Only pods whose name contains filter are kept.
+func fetchAllPodsCmd(kc k8s.Client, ns string, gen int, filter string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), CommandTimeout)
+		defer cancel()
+
+		out, err := kc.ListPods(ctx, ns, "")
+		if err != nil {
+			return dataMsg{gen: gen, allPods: true, throttled: k8s.IsThrottled(err), err: err}
+		}
+
+		header := fmt.Sprintf("=== all pods in %s ===", ns)
+		if filter != "" {
+			header = fmt.Sprintf("=== pods in %s matching %q ===", ns, filter)
+		}
+		items := []item{{Type: "HDR", Name: header}}
+		gjson.GetBytes(out, "items").ForEach(func(_, p gjson.Result) bool {
+			name := p.Get("metadata.name").String()
+			if filter != "" && !strings.Contains(name, filter) {
+				return true
+			}
+			items = append(items, item{Type: "POD", Name: name, Status: podStatus(p), Digest: podDigests(p), Created: p.Get("metadata.creationTimestamp").Time(), Restarts: podRestarts(p)})
+			return true
+		})
+		return dataMsg{gen: gen, allPods: true, pods: items}
+	}
+}
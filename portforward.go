@@ -0,0 +1,247 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- PORT FORWARDING ---
+
+// Forward states
+const (
+	forwardStarting = "starting"
+	forwardRunning  = "running"
+	forwardDown     = "down" // the pod went away, waiting for a replacement
+)
+
+// portForward is a :pf forward from a local port to a pod
+type portForward struct {
+	id         int // of the current attempt, tells a replaced attempt's messages apart
+	namespace  string
+	context    string
+	deployment string // a new pod of it takes over when the pod is recreated, "" for none
+	pod        string
+	local      int
+	remote     int
+	state      string
+	cancel     context.CancelFunc
+}
+
+// forwardStartedMsg reports that a forward listens (or failed to start)
+type forwardStartedMsg struct {
+	id   int
+	done <-chan error
+	err  error
+}
+
+// forwardEndedMsg reports that a running forward stopped
+type forwardEndedMsg struct {
+	id  int
+	err error
+}
+
+// parsePortPair parses "<local>:<remote>", or a single port used for both
+func parsePortPair(s string) (local, remote int, err error) {
+	localStr, remoteStr, found := strings.Cut(s, ":")
+	if !found {
+		remoteStr = localStr
+	}
+	local, err = strconv.Atoi(localStr)
+	if err == nil {
+		remote, err = strconv.Atoi(remoteStr)
+	}
+	if err != nil || local < 1 || local > 65535 || remote < 1 || remote > 65535 {
+		return 0, 0, fmt.Errorf("invalid ports %q", s)
+	}
+	return local, remote, nil
+}
+
+// runningPodOf returns the first running pod listed under deployment (a
+// target, so "sts/<name>" for a statefulset), "" if none
+func runningPodOf(items []item, deployment string) string {
+	for i, it := range items {
+		if !isWorkload(it.Type) || targetOf(it) != deployment {
+			continue
+		}
+		for _, pod := range items[i+1:] {
+			if pod.Type == "HDR" || isWorkload(pod.Type) {
+				break
+			}
+			if pod.Type == "POD" && strings.HasPrefix(pod.Status, "Running") {
+				return pod.Name
+			}
+		}
+	}
+	return ""
+}
+
+// startPortForward handles ":pf <local>:<remote>": it forwards to the selected
+// pod, or to a running pod of the selected deployment (or its service)
+func (m *model) startPortForward(arg string) tea.Cmd {
+	local, remote, err := parsePortPair(arg)
+	if err != nil {
+		m.rawContent = "Usage: pf <localPort>:<remotePort> | pf stop (" + err.Error() + ")"
+		m.updateViewportContent()
+		return nil
+	}
+	for _, pf := range m.forwards {
+		if pf.local == local {
+			m.statusMsg = fmt.Sprintf("Port %d is already forwarded to %s", local, pf.pod)
+			return clearStatusLater()
+		}
+	}
+
+	deployment := getCurrentDeploymentName(m.items, m.cursor)
+	pod := ""
+	if len(m.items) > 0 && m.items[m.cursor].Type == "POD" {
+		pod = m.items[m.cursor].Name
+	} else if deployment != "" {
+		pod = runningPodOf(m.items, deployment)
+	}
+	if pod == "" {
+		m.statusMsg = "Select a pod, or a deployment with a running pod, to forward to"
+		return clearStatusLater()
+	}
+
+	pf := &portForward{namespace: Namespace, context: Context, deployment: deployment, pod: pod, local: local, remote: remote}
+	m.forwards = append(m.forwards, pf)
+	return m.launchForward(pf)
+}
+
+// launchForward (re)starts pf against its pod
+func (m *model) launchForward(pf *portForward) tea.Cmd {
+	if pf.cancel != nil {
+		pf.cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.forwardSeq++
+	pf.id = m.forwardSeq
+	pf.cancel = cancel
+	pf.state = forwardStarting
+
+	kc, id, namespace, pod, local, remote := client, pf.id, pf.namespace, pf.pod, pf.local, pf.remote
+	return func() tea.Msg {
+		done, err := kc.PortForward(ctx, namespace, pod, local, remote)
+		return forwardStartedMsg{id: id, done: done, err: err}
+	}
+}
+
+// waitForwardCmd waits for a running forward to end
+func waitForwardCmd(id int, done <-chan error) tea.Cmd {
+	return func() tea.Msg {
+		return forwardEndedMsg{id: id, err: <-done}
+	}
+}
+
+// forwardByID finds the forward whose current attempt is id
+func (m *model) forwardByID(id int) *portForward {
+	for _, pf := range m.forwards {
+		if pf.id == id {
+			return pf
+		}
+	}
+	return nil
+}
+
+// handleForwardStarted records a forward that listens, or drops one that
+// could not start
+func (m *model) handleForwardStarted(msg forwardStartedMsg) tea.Cmd {
+	pf := m.forwardByID(msg.id)
+	if pf == nil {
+		return nil
+	}
+	if msg.err != nil {
+		m.removeForward(pf)
+		m.statusMsg = fmt.Sprintf("Port-forward %d -> %s:%d failed: %v", pf.local, pf.pod, pf.remote, msg.err)
+		return clearStatusLater()
+	}
+	pf.state = forwardRunning
+	m.statusMsg = fmt.Sprintf("Forwarding 127.0.0.1:%d -> %s:%d", pf.local, pf.pod, pf.remote)
+	return tea.Batch(waitForwardCmd(msg.id, msg.done), clearStatusLater())
+}
+
+// handleForwardEnded keeps a deployment's forward waiting for a new pod (the
+// refresh loop restarts it) and drops the others
+func (m *model) handleForwardEnded(msg forwardEndedMsg) tea.Cmd {
+	pf := m.forwardByID(msg.id)
+	if pf == nil || msg.err == nil {
+		return nil
+	}
+	if pf.deployment == "" {
+		m.removeForward(pf)
+		m.statusMsg = fmt.Sprintf("Port-forward %d -> %s stopped: %v", pf.local, pf.pod, msg.err)
+		return clearStatusLater()
+	}
+	pf.state = forwardDown
+	return nil
+}
+
+// restartForwards moves forwards whose pod went away onto a running pod of
+// the same deployment; called after every refresh
+func (m *model) restartForwards() []tea.Cmd {
+	var cmds []tea.Cmd
+	for _, pf := range m.forwards {
+		if pf.deployment == "" || pf.state == forwardStarting || pf.namespace != Namespace || pf.context != Context {
+			continue
+		}
+		if pf.state == forwardRunning && podListed(m.items, pf.pod) {
+			continue
+		}
+		pod := runningPodOf(m.items, pf.deployment)
+		if pod == "" {
+			continue
+		}
+		pf.pod = pod
+		cmds = append(cmds, m.launchForward(pf))
+	}
+	return cmds
+}
+
+// podListed reports whether pod is among items
+func podListed(items []item, pod string) bool {
+	for _, it := range items {
+		if it.Type == "POD" && it.Name == pod {
+			return true
+		}
+	}
+	return false
+}
+
+// removeForward stops pf and forgets it
+func (m *model) removeForward(pf *portForward) {
+	pf.cancel()
+	for i, other := range m.forwards {
+		if other == pf {
+			m.forwards = append(m.forwards[:i], m.forwards[i+1:]...)
+			return
+		}
+	}
+}
+
+// stopForwards tears every forward down (":pf stop" and on quit)
+func (m *model) stopForwards() {
+	for _, pf := range m.forwards {
+		pf.cancel()
+	}
+	m.forwards = nil
+}
+
+// forwardsStatus is the footer's port-forward indicator, "" without forwards
+func (m model) forwardsStatus() string {
+	if len(m.forwards) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(m.forwards))
+	for _, pf := range m.forwards {
+		target := fmt.Sprintf("%d->%s:%d", pf.local, pf.pod, pf.remote)
+		if pf.state != forwardRunning {
+			target += " (" + pf.state + ")"
+		}
+		parts = append(parts, target)
+	}
+	return " PF: " + strings.Join(parts, ", ") + " |"
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tidwall/gjson"
+)
+
+// --- JSON QUERY ---
+
+// The query ('\') narrows the YAML tab to the result of a gjson path such as
+// spec.template.spec.containers.#.image. The fetched JSON is kept in
+// lastDetails, so clearing the query (Esc) restores the full view.
+
+// startQuery opens the query prompt on the YAML tab, prefilled with the
+// current query
+func (m *model) startQuery() tea.Cmd {
+	m.partialKey = ""
+	if len(m.items) == 0 || tabName(m.items[m.cursor].Type, m.activeTab) != TabYAML {
+		m.statusMsg = "Queries work on the YAML tab"
+		return clearStatusLater()
+	}
+	m.inputMode = true
+	m.filterMode = false
+	m.queryMode = true
+	m.textInput.Prompt = "\\ "
+	m.textInput.Placeholder = "gjson path, e.g. spec.template.spec.containers.#.image"
+	m.textInput.SetValue(m.jsonQuery)
+	m.textInput.Focus()
+	return textinput.Blink
+}
+
+// applyQuery sets the query ("" clears it) and re-renders the details from
+// the last fetched JSON
+func (m *model) applyQuery(query string) {
+	m.jsonQuery = strings.TrimSpace(query)
+	if len(m.items) > 0 && m.cursor < len(m.items) && m.detailView == "" {
+		m.rawContent = m.renderDetails(m.lastDetails, m.items[m.cursor], m.activeTab)
+	}
+	m.updateViewportContent()
+	m.viewport.GotoTop()
+}
+
+// queryJSON renders the result of the gjson path query on content, the
+// pretty-printed JSON of the YAML tab, or why there is none
+func queryJSON(content, query string) string {
+	// Drop leading comments such as the unexpected shape warning
+	lines := strings.Split(content, "\n")
+	for len(lines) > 0 && strings.HasPrefix(lines[0], "#") {
+		lines = lines[1:]
+	}
+	doc := strings.Join(lines, "\n")
+
+	header := styleDim.Render(fmt.Sprintf("Query: %s (\\ to edit, Esc to clear)", query)) + "\n\n"
+	if !gjson.Valid(doc) {
+		return header + styleErr.Render("The details are not JSON, nothing to query")
+	}
+	result := gjson.Get(doc, query)
+	if !result.Exists() {
+		return header + styleErr.Render(fmt.Sprintf("No match for %q", query))
+	}
+	if !result.IsObject() && !result.IsArray() {
+		return header + result.String()
+	}
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, []byte(result.Raw), "", "  "); err != nil {
+		return header + result.Raw
+	}
+	return header + highlight(pretty.String(), "yaml")
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- QUICK YANK ---
+
+// YankKeyTimeout is how long 'y' waits for 'p' or 's' before copying the pane
+const YankKeyTimeout = 500 * time.Millisecond
+
+// yankTimeoutMsg ends the yank sequence started by the seq-th 'y'
+type yankTimeoutMsg struct {
+	seq int
+}
+
+// yankTimeoutCmd copies the pane once YankKeyTimeout passes without a key
+func yankTimeoutCmd(seq int) tea.Cmd {
+	return tea.Tick(YankKeyTimeout, func(t time.Time) tea.Msg {
+		return yankTimeoutMsg{seq: seq}
+	})
+}
+
+// yankPane copies the right pane as displayed, only the matching lines while
+// filtering (vim-style)
+func (m *model) yankPane() tea.Cmd {
+	if m.viewContent == "" {
+		m.statusMsg = "Nothing to yank: no lines match the filter"
+		return clearStatusLater()
+	}
+	return m.yank(m.viewContent)
+}
+
+// quickYank handles the key after the yank key: 'p' copies the selected
+// item's name, 's' the label selector of the workload it belongs to. It
+// reports whether key was one of them.
+func (m *model) quickYank(key string) (tea.Cmd, bool) {
+	var text string
+	switch key {
+	case "p":
+		if len(m.items) == 0 || m.items[m.cursor].Type == "HDR" {
+			m.statusMsg = "Nothing to yank: no item selected"
+			return clearStatusLater(), true
+		}
+		text = m.items[m.cursor].Name
+	case "s":
+		target := getCurrentDeploymentName(m.items, m.cursor)
+		if target == "" {
+			m.statusMsg = "Nothing to yank: the selection belongs to no workload"
+			return clearStatusLater(), true
+		}
+		if text = m.selectors[target]; text == "" {
+			m.statusMsg = "Nothing to yank: no label selector known for " + target
+			return clearStatusLater(), true
+		}
+	default:
+		return nil, false
+	}
+	return m.yank(text), true
+}
+
+// yank copies text to the clipboard. While another copy is running it waits
+// for it, so a quick second yank isn't overwritten by the first.
+func (m *model) yank(text string) tea.Cmd {
+	if m.yanking {
+		m.queuedYank = text
+		return nil
+	}
+	m.yanking = true
+	return yankCmd(text)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func key(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestYankWaitsForTheNextKey(t *testing.T) {
+	newModel := func() model {
+		m := initialModel(savedState{}, []string{"web"})
+		m.items = []item{{Type: "DEP", Name: "web"}, {Type: "POD", Name: "web-1"}}
+		m.viewContent = "pane"
+		return m
+	}
+
+	m := newModel()
+	update(t, &m, key("y"))
+	if m.yanking {
+		t.Fatal("'y' alone shouldn't copy before the next key or the timeout")
+	}
+	update(t, &m, key("p"))
+	if !m.yanking || m.queuedYank != "" {
+		t.Errorf("\"yp\" should copy once, got yanking=%v queued=%q", m.yanking, m.queuedYank)
+	}
+
+	// Another key copies the pane and still moves the cursor
+	m = newModel()
+	update(t, &m, key("y"))
+	update(t, &m, key("j"))
+	if !m.yanking || m.partialKey != "" || m.cursor != 1 {
+		t.Errorf("\"yj\" should copy the pane and move down, got yanking=%v partialKey=%q cursor=%d", m.yanking, m.partialKey, m.cursor)
+	}
+
+	// The timeout copies the pane; a stale one does nothing
+	m = newModel()
+	update(t, &m, key("y"))
+	update(t, &m, yankTimeoutMsg{seq: m.yankSeq - 1})
+	if m.yanking {
+		t.Error("a timeout of an earlier 'y' shouldn't copy")
+	}
+	update(t, &m, yankTimeoutMsg{seq: m.yankSeq})
+	if !m.yanking || m.partialKey != "" {
+		t.Error("the timeout should copy the pane and end the sequence")
+	}
+	update(t, &m, key("p"))
+	if m.queuedYank != "" {
+		t.Error("'p' after the timeout shouldn't copy the name")
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/devpopsdotin/k9s-deck/internal/k8s"
+)
+
+// --- RESTART ALL ---
+
+// restartAllCommand restarts every monitored target
+const restartAllCommand = "restart all"
+
+// RestartAllParallelism caps how many targets :restart all restarts at once
+const RestartAllParallelism = 4
+
+// restartResult is the outcome of restarting one target
+type restartResult struct {
+	target string
+	err    error
+}
+
+// restartAllMsg carries the per-target outcome of :restart all, in the order
+// of the targets
+type restartAllMsg struct {
+	results []restartResult
+}
+
+// restartWorkload triggers a rolling restart of a deployment, StatefulSet or
+// DaemonSet target
+func restartWorkload(ctx context.Context, kc k8s.Client, ns string, target string) error {
+	kind, name := parseTarget(target)
+	if err := workloadActionError(kind, "restart"); err != nil {
+		return err
+	}
+	switch kind {
+	case "STS":
+		return kc.RestartStatefulSet(ctx, ns, name)
+	case "DS":
+		return kc.RestartDaemonSet(ctx, ns, name)
+	}
+	return kc.RestartDeployment(ctx, ns, target)
+}
+
+// startRestartAll runs :restart all, asking first with ConfirmActions
+func (m *model) startRestartAll() tea.Cmd {
+	if ReadOnly {
+		return func() tea.Msg {
+			return detailsMsg{err: fmt.Errorf("restart is disabled in read-only mode")}
+		}
+	}
+	if m.mutating != "" {
+		m.statusMsg = fmt.Sprintf("Operation in progress (%s), try again when it finishes", m.mutating)
+		return clearStatusLater()
+	}
+	if len(m.targets) == 0 {
+		m.statusMsg = "No targets to restart"
+		return clearStatusLater()
+	}
+	if ConfirmActions {
+		msg := actionPreviewMsg{
+			action: pendingAction{input: restartAllCommand},
+			prompt: fmt.Sprintf("Restart all %d monitored targets (%s)? [y/N] ", len(m.targets), strings.Join(m.targets, ", ")),
+		}
+		return func() tea.Msg { return msg }
+	}
+	return m.runMutation(restartAllCommand, "", "")
+}
+
+// restartAllCmd restarts targets concurrently, at most RestartAllParallelism
+// at a time. Each restart gets its own LongCommandTimeout and a failure
+// doesn't stop the others.
+func restartAllCmd(kc k8s.Client, ns string, targets []string) tea.Cmd {
+	targets = append([]string(nil), targets...)
+	return func() tea.Msg {
+		results := make([]restartResult, len(targets))
+		sem := make(chan struct{}, RestartAllParallelism)
+		var wg sync.WaitGroup
+		for idx, target := range targets {
+			wg.Add(1)
+			go func(idx int, target string) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				ctx, cancel := context.WithTimeout(context.Background(), LongCommandTimeout)
+				defer cancel()
+				results[idx] = restartResult{target: target, err: restartWorkload(ctx, kc, ns, target)}
+			}(idx, target)
+		}
+		wg.Wait()
+		return restartAllMsg{results: results}
+	}
+}
+
+// handleRestartAll shows the summary of :restart all in the details pane
+func (m *model) handleRestartAll(msg restartAllMsg) tea.Cmd {
+	failed := 0
+	var b strings.Builder
+	for _, r := range msg.results {
+		if r.err != nil {
+			failed++
+			fmt.Fprintf(&b, "FAILED  %s: %s\n", r.target, targetErrReason(r.err))
+			continue
+		}
+		fmt.Fprintf(&b, "OK      %s\n", r.target)
+	}
+	restarted := len(msg.results) - failed
+	header := fmt.Sprintf("Restart all: %d of %d targets restarted", restarted, len(msg.results))
+	if failed > 0 {
+		header += fmt.Sprintf(", %d failed", failed)
+	}
+
+	m.detailView = "restart-all"
+	m.rawContent = header + "\n\n" + b.String()
+	m.updateViewportContent()
+	m.statusMsg = header
+	return tea.Batch(m.refreshCmd(), clearStatusLater())
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/devpopsdotin/k9s-deck/internal/k8s"
+)
+
+// --- ROLLOUT PROGRESS ---
+
+const (
+	RolloutPollInterval = 2 * time.Second
+	RolloutWatchTimeout = 5 * time.Minute // stop watching, the rollout may still finish
+)
+
+// rolloutWatch follows a deployment's rollout after a restart or scale
+type rolloutWatch struct {
+	id       int // tells a replaced watch's late polls apart
+	name     string
+	deadline time.Time
+	status   k8s.RolloutStatus
+	err      error // the rollout failed, shown until Esc or the next rollout
+}
+
+// rolloutStatusMsg carries one poll of the watched rollout
+type rolloutStatusMsg struct {
+	id     int
+	status k8s.RolloutStatus
+	err    error
+}
+
+// startRolloutWatch starts polling the rollout of a deployment, replacing
+// any previous watch
+func (m *model) startRolloutWatch(name string) tea.Cmd {
+	m.rolloutSeq++
+	m.rollout = &rolloutWatch{
+		id:       m.rolloutSeq,
+		name:     name,
+		deadline: time.Now().Add(RolloutWatchTimeout),
+		status:   k8s.RolloutStatus{Phase: k8s.RolloutProgressing, Message: "waiting for the rollout to start"},
+	}
+	return pollRolloutCmd(client, Namespace, m.rollout.id, name, RolloutPollInterval)
+}
+
+// pollRolloutCmd fetches the rollout status after delay
+func pollRolloutCmd(kc k8s.Client, ns string, id int, name string, delay time.Duration) tea.Cmd {
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), CommandTimeout)
+		defer cancel()
+		status, err := kc.GetRolloutStatus(ctx, ns, name)
+		return rolloutStatusMsg{id: id, status: status, err: err}
+	})
+}
+
+// handleRolloutStatus updates the watch and polls again until the rollout
+// completes, fails or the watch times out
+func (m *model) handleRolloutStatus(msg rolloutStatusMsg) tea.Cmd {
+	w := m.rollout
+	if w == nil || msg.id != w.id || w.err != nil {
+		return nil
+	}
+
+	switch {
+	case errors.Is(msg.err, k8s.ErrProgressDeadlineExceeded):
+		w.status = msg.status
+		w.err = msg.err
+		return m.refreshCmd()
+	case msg.err != nil:
+		// Transient API errors: keep the last status and try again
+	case msg.status.Phase == k8s.RolloutComplete:
+		m.rollout = nil
+		m.statusMsg = fmt.Sprintf("Rollout of %s complete: %s", w.name, msg.status.Message)
+		return tea.Batch(m.refreshCmd(), clearStatusLater())
+	default:
+		w.status = msg.status
+	}
+
+	if time.Now().After(w.deadline) {
+		m.rollout = nil
+		m.statusMsg = fmt.Sprintf("Stopped watching %s after %s: %s", w.name, RolloutWatchTimeout, w.status.Message)
+		return clearStatusLater()
+	}
+	return pollRolloutCmd(client, Namespace, w.id, w.name, RolloutPollInterval)
+}
+
+// rolloutLine renders the watched rollout's progress or failure, "" when
+// no rollout is watched
+func (m model) rolloutLine() string {
+	if m.rollout == nil {
+		return ""
+	}
+	if m.rollout.err != nil {
+		return styleErr.Render(fmt.Sprintf("✗ Rollout of %s failed: %s (Esc to dismiss)", m.rollout.name, m.rollout.status.Message))
+	}
+	return lipgloss.NewStyle().Foreground(cYellow).Render(fmt.Sprintf("⟳ Rolling out %s: %s", m.rollout.name, m.rollout.status.Message))
+}
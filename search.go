@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// --- SEARCH ---
+
+// Search ('Ctrl+/') keeps every line of the details pane, highlights the
+// matches and jumps between them with n/N, like less or vim. The filter ('/')
+// hides the lines that don't match instead.
+
+// startSearch opens the search prompt, prefilled with the current query
+func (m *model) startSearch() tea.Cmd {
+	m.partialKey = ""
+	m.inputMode = true
+	m.filterMode = false
+	m.searchMode = true
+	m.textInput.Prompt = "Search: "
+	m.textInput.Placeholder = "Highlight and jump with n/N..."
+	m.textInput.SetValue(m.searchQuery)
+	m.textInput.Focus()
+	return textinput.Blink
+}
+
+// applySearch sets the query ("" clears it) and jumps to the first match at
+// or below the top of the viewport
+func (m *model) applySearch(query string) {
+	m.searchQuery = query
+	m.searchRegex = nil
+	if query != "" {
+		m.searchRegex = regexp.MustCompile("(?i)" + regexp.QuoteMeta(query))
+	}
+	m.updateViewportContent()
+
+	m.searchIndex = 0
+	for i, line := range m.searchMatches {
+		if line >= m.viewport.YOffset {
+			m.searchIndex = i
+			break
+		}
+	}
+	m.centerSearchMatch()
+}
+
+// clearSearch drops the query and its highlights
+func (m *model) clearSearch() {
+	m.searchQuery = ""
+	m.searchRegex = nil
+	m.searchMatches = nil
+	m.searchIndex = 0
+	m.updateViewportContent()
+}
+
+// highlightSearch marks every match of the query in content
+func (m model) highlightSearch(content string) string {
+	if m.searchRegex == nil {
+		return content
+	}
+	return m.searchRegex.ReplaceAllStringFunc(content, func(s string) string {
+		return styleHighlight.Render(s)
+	})
+}
+
+// indexSearchMatches records the lines of the rendered (wrapped) content that
+// hold a match, so n/N land on the line the viewport actually shows. A match
+// broken across two wrapped lines isn't found.
+func (m *model) indexSearchMatches(rendered string) {
+	m.searchMatches = m.searchMatches[:0]
+	if m.searchRegex == nil {
+		return
+	}
+	for i, line := range strings.Split(rendered, "\n") {
+		if m.searchRegex.MatchString(ansi.Strip(line)) {
+			m.searchMatches = append(m.searchMatches, i)
+		}
+	}
+	if m.searchIndex >= len(m.searchMatches) {
+		m.searchIndex = maxInt(len(m.searchMatches)-1, 0)
+	}
+}
+
+// jumpToMatch moves delta matches forward (or back), wrapping around
+func (m *model) jumpToMatch(delta int) tea.Cmd {
+	if len(m.searchMatches) == 0 {
+		m.statusMsg = fmt.Sprintf("No matches for %q", m.searchQuery)
+		return clearStatusLater()
+	}
+	n := len(m.searchMatches)
+	m.searchIndex = ((m.searchIndex+delta)%n + n) % n
+	m.centerSearchMatch()
+	return nil
+}
+
+// centerSearchMatch scrolls the current match to the middle of the viewport
+func (m *model) centerSearchMatch() {
+	if m.searchIndex < len(m.searchMatches) {
+		m.viewport.SetYOffset(maxInt(m.searchMatches[m.searchIndex]-m.viewport.Height/2, 0))
+	}
+}
+
+// searchStatus is the footer's search indicator, "" without a query
+func (m model) searchStatus() string {
+	if m.searchQuery == "" {
+		return ""
+	}
+	if len(m.searchMatches) == 0 {
+		return fmt.Sprintf(" SEARCH: %q no matches (Esc to clear) |", m.searchQuery)
+	}
+	return fmt.Sprintf(" SEARCH: %q match %d/%d (n/N, Esc to clear) |", m.searchQuery, m.searchIndex+1, len(m.searchMatches))
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tidwall/gjson"
+
+	"github.com/devpopsdotin/k9s-deck/internal/k8s"
+)
+
+// --- LOG SEARCH ---
+
+const (
+	SearchLogTailLines = 10000 // history searched per pod, far beyond the display tail
+	SearchContextLines = 2     // lines shown before and after each match
+	MaxSearchMatches   = 500   // stop collecting after this many matches
+)
+
+// searchLogsCmd searches the long log history of pods (or of every pod
+// matching selector, when pods is empty) and returns the matches with context
+func searchLogsCmd(kc k8s.Client, ns string, pods []string, selector string, re *regexp.Regexp) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), LongCommandTimeout)
+		defer cancel()
+
+		if len(pods) == 0 {
+			podOut, err := kc.ListPods(ctx, ns, selector)
+			if err != nil {
+				return detailsMsg{err: fmt.Errorf("Search failed: %v", err)}
+			}
+			gjson.GetBytes(podOut, "items.#.metadata.name").ForEach(func(_, v gjson.Result) bool {
+				pods = append(pods, v.String())
+				return true
+			})
+			if len(pods) == 0 {
+				return detailsMsg{content: "No pods found for selector " + selector}
+			}
+		}
+
+		logs := make([][]byte, len(pods))
+		errs := make([]error, len(pods))
+		var wg sync.WaitGroup
+		for idx, pod := range pods {
+			wg.Add(1)
+			go func(idx int, pod string) {
+				defer wg.Done()
+				opts := k8s.LogOptions{TailLines: SearchLogTailLines, AllContainers: true, Prefix: len(pods) > 1}
+				logs[idx], errs[idx] = kc.GetPodLogsWithOptions(ctx, ns, pod, opts)
+			}(idx, pod)
+		}
+		wg.Wait()
+
+		var b strings.Builder
+		total, searched := 0, 0
+		for idx, pod := range pods {
+			if errs[idx] != nil {
+				fmt.Fprintf(&b, "=== %s: failed to fetch logs: %v ===\n", pod, errs[idx])
+				continue
+			}
+			lines := strings.Split(strings.TrimRight(string(logs[idx]), "\n"), "\n")
+			searched += len(lines)
+			section, n := searchLines(lines, re, SearchContextLines, MaxSearchMatches-total)
+			total += n
+			if n > 0 {
+				fmt.Fprintf(&b, "=== %s (%d match(es)) ===\n%s\n", pod, n, section)
+			}
+		}
+
+		header := fmt.Sprintf("Search /%s/ in the last %d lines of %d pod(s): %d match(es) in %d lines", re, SearchLogTailLines, len(pods), total, searched)
+		if total >= MaxSearchMatches {
+			header += fmt.Sprintf(" (stopped after %d)", MaxSearchMatches)
+		}
+		return detailsMsg{content: header + "\n\n" + b.String()}
+	}
+}
+
+// searchLines returns the lines matching re with context lines around
+// them, grep -C style: "N:" marks a match, "N-" context, "--" a gap.
+// At most limit matches are collected.
+func searchLines(lines []string, re *regexp.Regexp, context, limit int) (string, int) {
+	var out []string
+	matches := 0
+	shownUntil := -1 // last line index already printed
+	for i, line := range lines {
+		if matches >= limit {
+			break
+		}
+		if !re.MatchString(line) {
+			continue
+		}
+		matches++
+
+		start := maxInt(i-context, shownUntil+1)
+		if shownUntil >= 0 && start > shownUntil+1 {
+			out = append(out, "--")
+		}
+		end := minInt(i+context, len(lines)-1)
+		for j := start; j <= end; j++ {
+			if j <= shownUntil {
+				continue
+			}
+			sep := "-"
+			text := lines[j]
+			if re.MatchString(text) {
+				sep = ":"
+				text = re.ReplaceAllStringFunc(text, func(s string) string {
+					return styleHighlight.Render(s)
+				})
+			}
+			out = append(out, fmt.Sprintf("%d%s %s", j+1, sep, text))
+		}
+		shownUntil = end
+	}
+	return strings.Join(out, "\n"), matches
+}
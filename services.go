@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/devpopsdotin/k9s-deck/internal/k8s"
+)
+
+// --- SERVICES ---
+
+// servicesSelecting returns the services whose selector matches the pod
+// template labels of a deployment. Services without a selector (manually
+// managed endpoints, ExternalName) never match.
+func servicesSelecting(servicesJSON []byte, podLabels map[string]gjson.Result) []item {
+	var matched []item
+	gjson.GetBytes(servicesJSON, "items").ForEach(func(_, svc gjson.Result) bool {
+		selector := svc.Get("spec.selector").Map()
+		if len(selector) == 0 {
+			return true
+		}
+		for k, v := range selector {
+			if label, ok := podLabels[k]; !ok || label.String() != v.String() {
+				return true
+			}
+		}
+		matched = append(matched, item{Type: "SVC", Name: svc.Get("metadata.name").String(), Status: svc.Get("spec.type").String()})
+		return true
+	})
+	return matched
+}
+
+// serviceDetails renders a service's YAML, headed by a summary of its
+// endpoints so it's clear at a glance which pods receive traffic
+func serviceDetails(ctx context.Context, kc k8s.Client, ns string, name string) detailsMsg {
+	out, err := kc.GetResource(ctx, ns, "service", name, "yaml")
+	if err != nil {
+		return detailsMsg{err: fmt.Errorf("%s\n%s", err.Error(), string(out))}
+	}
+
+	summary := "# Endpoints: unavailable\n"
+	if eps, epErr := kc.GetResource(ctx, ns, "endpoints", name, "json"); epErr == nil {
+		summary = endpointsSummary(eps)
+	}
+	return detailsMsg{content: summary + "\n" + string(out), isYaml: true}
+}
+
+// endpointsSummary lists the ready and not ready addresses of an Endpoints
+// object as YAML comments, one "ip:port (pod)" per line
+func endpointsSummary(endpointsJSON []byte) string {
+	var ready, notReady []string
+	gjson.GetBytes(endpointsJSON, "subsets").ForEach(func(_, subset gjson.Result) bool {
+		var ports []string
+		subset.Get("ports.#.port").ForEach(func(_, p gjson.Result) bool {
+			ports = append(ports, p.String())
+			return true
+		})
+		collect := func(addresses gjson.Result) []string {
+			var lines []string
+			addresses.ForEach(func(_, addr gjson.Result) bool {
+				target := ""
+				if pod := addr.Get("targetRef.name").String(); pod != "" {
+					target = " (" + pod + ")"
+				}
+				if len(ports) == 0 {
+					lines = append(lines, addr.Get("ip").String()+target)
+				}
+				for _, port := range ports {
+					lines = append(lines, addr.Get("ip").String()+":"+port+target)
+				}
+				return true
+			})
+			return lines
+		}
+		ready = append(ready, collect(subset.Get("addresses"))...)
+		notReady = append(notReady, collect(subset.Get("notReadyAddresses"))...)
+		return true
+	})
+
+	if len(ready) == 0 && len(notReady) == 0 {
+		return "# Endpoints: none (no ready pods match the selector)\n"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Endpoints: %d ready, %d not ready\n", len(ready), len(notReady))
+	for _, addr := range ready {
+		b.WriteString("#   " + addr + "\n")
+	}
+	for _, addr := range notReady {
+		b.WriteString("#   " + addr + " [not ready]\n")
+	}
+	return b.String()
+}
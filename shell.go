@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- SHELL ---
+
+// shells are tried in order: /bin/sh exists in most images, bash in the rest
+var shells = []string{"/bin/sh", "/bin/bash"}
+
+// shellFinishedMsg reports how an interactive shell session ended
+type shellFinishedMsg struct {
+	pod       string
+	container string
+	shell     string
+	stderr    string // what kubectl printed on stderr, to explain a failure
+	err       error
+}
+
+// openShell starts a shell in the selected pod, asking for the container
+// first if it has several
+func (m *model) openShell() tea.Cmd {
+	if len(m.items) == 0 || m.items[m.cursor].Type != "POD" {
+		m.statusMsg = "Select a pod to open a shell in it"
+		return clearStatusLater()
+	}
+	if ReadOnly {
+		m.statusMsg = "Shell is disabled in read-only mode"
+		return clearStatusLater()
+	}
+	return fetchContainersCmd(client, Namespace, m.items[m.cursor].Name, true)
+}
+
+// execShellCmd suspends the TUI and runs kubectl exec -it with shell until
+// it exits. stderr still reaches the terminal and is kept for the details
+// pane in case the session fails.
+func execShellCmd(pod, container, shell string) tea.Cmd {
+	args := []string{"exec", "-it", pod, "-n", Namespace, "--context", Context}
+	if container != "" {
+		args = append(args, "-c", container)
+	}
+	args = append(args, "--", shell)
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("kubectl", args...)
+	cmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return shellFinishedMsg{pod: pod, container: container, shell: shell, stderr: stderr.String(), err: err}
+	})
+}
+
+// handleShellFinished retries with the next shell when the image lacks one,
+// and shows why the session failed in the details pane
+func (m *model) handleShellFinished(msg shellFinishedMsg) tea.Cmd {
+	if msg.err == nil {
+		m.statusMsg = fmt.Sprintf("Shell in %s closed", msg.pod)
+		return clearStatusLater()
+	}
+	if last := strings.TrimSpace(msg.stderr); strings.HasPrefix(last, "command terminated with exit code") {
+		// The shell ran; its last command failed before the user left
+		m.statusMsg = fmt.Sprintf("Shell in %s closed (%s)", msg.pod, strings.TrimPrefix(last, "command terminated with "))
+		return clearStatusLater()
+	}
+	if shellNotFound(msg.stderr) {
+		for i, shell := range shells[:len(shells)-1] {
+			if shell == msg.shell {
+				return execShellCmd(msg.pod, msg.container, shells[i+1])
+			}
+		}
+	}
+
+	target := msg.pod
+	if msg.container != "" {
+		target += "/" + msg.container
+	}
+	reason := fmt.Sprintf("%s failed: %v", msg.shell, msg.err)
+	if shellNotFound(msg.stderr) {
+		reason = "no shell in the image (tried " + strings.Join(shells, ", ") + ")"
+	}
+	m.rawContent = fmt.Sprintf("Error: shell in %s: %s\n\n%s", target, reason, strings.TrimSpace(msg.stderr))
+	m.updateViewportContent()
+	return nil
+}
+
+// shellNotFound tells a missing shell binary apart from other exec failures
+func shellNotFound(stderr string) bool {
+	return strings.Contains(stderr, "executable file not found") ||
+		strings.Contains(stderr, "no such file or directory")
+}
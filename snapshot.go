@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// --- SNAPSHOTS ---
+
+const (
+	DiffContextLines = 3       // unchanged lines kept around each change
+	MaxDiffCells     = 4000000 // LCS table size above which diffing is refused
+)
+
+// Diff line styles, built from the theme by buildStyles
+var styleDiffAdd, styleDiffDel lipgloss.Style
+
+// snapshot is a frozen copy of the detail pane taken with :snapshot
+type snapshot struct {
+	content string // plain text, ANSI stripped
+	source  string // what was shown, e.g. "DEP web / yaml"
+	at      time.Time
+}
+
+// diffOp is one line of a line diff
+type diffOp struct {
+	kind byte // ' ' unchanged, '-' only in the snapshot, '+' only in the live view
+	line string
+}
+
+// detailSource describes what the detail pane currently shows
+func (m model) detailSource() string {
+	if m.detailView != "" {
+		return ":" + m.detailView
+	}
+	return m.itemSource()
+}
+
+// itemSource describes the selected item and tab
+func (m model) itemSource() string {
+	if len(m.items) == 0 || m.cursor >= len(m.items) {
+		return "empty"
+	}
+	it := m.items[m.cursor]
+	return fmt.Sprintf("%s %s / %s", it.Type, it.Name, tabName(it.Type, m.activeTab))
+}
+
+// diffLines returns the line diff turning a into b (LCS based).
+// ok is false when the inputs are too large to diff.
+func diffLines(a, b []string) (ops []diffOp, ok bool) {
+	// Trim the common prefix and suffix so the table only covers the changes
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if (len(midA)+1)*(len(midB)+1) > MaxDiffCells {
+		return nil, false
+	}
+
+	for _, l := range a[:prefix] {
+		ops = append(ops, diffOp{' ', l})
+	}
+
+	// lcs[i][j] = LCS length of midA[i:] and midB[j:]
+	cols := len(midB) + 1
+	lcs := make([]int32, (len(midA)+1)*cols)
+	for i := len(midA) - 1; i >= 0; i-- {
+		for j := len(midB) - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				lcs[i*cols+j] = lcs[(i+1)*cols+j+1] + 1
+			} else if lcs[(i+1)*cols+j] >= lcs[i*cols+j+1] {
+				lcs[i*cols+j] = lcs[(i+1)*cols+j]
+			} else {
+				lcs[i*cols+j] = lcs[i*cols+j+1]
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(midA) && j < len(midB) {
+		switch {
+		case midA[i] == midB[j]:
+			ops = append(ops, diffOp{' ', midA[i]})
+			i++
+			j++
+		case lcs[(i+1)*cols+j] >= lcs[i*cols+j+1]:
+			ops = append(ops, diffOp{'-', midA[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', midB[j]})
+			j++
+		}
+	}
+	for ; i < len(midA); i++ {
+		ops = append(ops, diffOp{'-', midA[i]})
+	}
+	for ; j < len(midB); j++ {
+		ops = append(ops, diffOp{'+', midB[j]})
+	}
+
+	for _, l := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{' ', l})
+	}
+	return ops, true
+}
+
+// renderSnapshotDiff shows a color-coded diff from the snapshot to live,
+// collapsing long unchanged runs
+func renderSnapshotDiff(snap snapshot, live, liveSource string) string {
+	header := []string{
+		styleDiffDel.Render(fmt.Sprintf("--- snapshot: %s (captured %s)", snap.source, snap.at.Format("15:04:05"))),
+		styleDiffAdd.Render(fmt.Sprintf("+++ live: %s (%s)", liveSource, time.Now().Format("15:04:05"))),
+		"",
+	}
+	return renderDiff(header, snap.content, live)
+}
+
+// renderDiff renders a unified diff of a against b below header, collapsing
+// unchanged runs further than DiffContextLines from a change
+func renderDiff(header []string, a, b string) string {
+	ops, ok := diffLines(strings.Split(a, "\n"), strings.Split(b, "\n"))
+	if !ok {
+		return strings.Join(append(header, "Content is too large to diff."), "\n")
+	}
+
+	// Keep lines within DiffContextLines of a change
+	keep := make([]bool, len(ops))
+	changed := false
+	for i, op := range ops {
+		if op.kind == ' ' {
+			continue
+		}
+		changed = true
+		for k := maxInt(i-DiffContextLines, 0); k <= minInt(i+DiffContextLines, len(ops)-1); k++ {
+			keep[k] = true
+		}
+	}
+	if !changed {
+		return strings.Join(append(header, styleDim.Render("No differences.")), "\n")
+	}
+
+	lines := header
+	for i := 0; i < len(ops); i++ {
+		if !keep[i] {
+			start := i
+			for i < len(ops) && !keep[i] {
+				i++
+			}
+			lines = append(lines, styleDim.Render(fmt.Sprintf("@@ %d unchanged lines @@", i-start)))
+			i--
+			continue
+		}
+		switch op := ops[i]; op.kind {
+		case '-':
+			lines = append(lines, styleDiffDel.Render("- "+op.line))
+		case '+':
+			lines = append(lines, styleDiffAdd.Render("+ "+op.line))
+		default:
+			lines = append(lines, "  "+op.line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
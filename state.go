@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// --- SAVED STATE ---
+
+// savedState holds preferences remembered between launches.
+// Unlike Config it is only ever written by the app, so it lives in the XDG state dir.
+type savedState struct {
+	RawLogs *bool `json:"rawLogs,omitempty"` // last log format chosen with 'f'
+}
+
+// statePath returns $XDG_STATE_HOME/k9s-deck/state.json (~/.local/state fallback)
+func statePath() (string, error) {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(dir, "k9s-deck", "state.json"), nil
+}
+
+// loadState reads the saved state; a missing or unreadable file yields defaults
+func loadState() savedState {
+	var st savedState
+	path, err := statePath()
+	if err != nil {
+		return st
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return st
+	}
+	if err := json.Unmarshal(data, &st); err != nil {
+		slog.Warn("ignoring invalid saved state", "path", path, "error", err)
+		return savedState{}
+	}
+	return st
+}
+
+// saveState writes the saved state atomically
+func saveState(st savedState) error {
+	path, err := statePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// saveStateCmd persists st in the background; failures are only logged
+func saveStateCmd(st savedState) tea.Cmd {
+	return func() tea.Msg {
+		if err := saveState(st); err != nil {
+			slog.Warn("failed to save state", "error", err)
+		}
+		return nil
+	}
+}
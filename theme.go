@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/devpopsdotin/k9s-deck/internal/parser"
+)
+
+// --- THEMES ---
+
+// DefaultTheme is used unless the config or :theme picks another
+const DefaultTheme = "dark"
+
+// Theme is a UI color palette plus the Chroma style YAML/JSON is highlighted with
+type Theme struct {
+	Primary   lipgloss.Color // selection, active tab, borders of overlays
+	Secondary lipgloss.Color // titles, ConfigMaps
+	Green     lipgloss.Color
+	Red       lipgloss.Color
+	Yellow    lipgloss.Color
+	Gray      lipgloss.Color // dim text and borders
+
+	SelectedFg lipgloss.Color // text on Primary
+	HeaderFg   lipgloss.Color // group headers
+	HeaderBg   lipgloss.Color
+	CmdBarFg   lipgloss.Color // command bar
+	CmdBarBg   lipgloss.Color
+	MatchFg    lipgloss.Color // filter matches
+	MatchBg    lipgloss.Color
+
+	Helm    lipgloss.Color // resource rows without a status color
+	Service lipgloss.Color
+
+	Active   lipgloss.Color // selected suggestion or picker entry
+	Inactive lipgloss.Color // the others
+
+	LogInfo  lipgloss.Color // log level colors besides Red/Yellow/Gray
+	LogTrace lipgloss.Color
+	LogText  lipgloss.Color // lines without a level
+
+	PodColors []lipgloss.Color // log prefix colors, one per pod
+
+	Chroma string // Chroma style name
+}
+
+// themes are the built-in palettes, selected by name
+var themes = map[string]Theme{
+	"dark": {
+		Primary: "62", Secondary: "39", Green: "42", Red: "196", Yellow: "220", Gray: "240",
+		SelectedFg: "255", HeaderFg: "255", HeaderBg: "237", CmdBarFg: "255", CmdBarBg: "236", MatchFg: "255", MatchBg: "201",
+		Helm: "201", Service: "141",
+		Active: "12", Inactive: "8",
+		LogInfo: "39", LogTrace: "238", LogText: "255",
+		PodColors: []lipgloss.Color{"39", "42", "220", "201", "141", "208", "51", "82", "213", "228"},
+		Chroma:    "dracula",
+	},
+	"light": {
+		Primary: "25", Secondary: "31", Green: "28", Red: "160", Yellow: "130", Gray: "243",
+		SelectedFg: "255", HeaderFg: "235", HeaderBg: "253", CmdBarFg: "235", CmdBarBg: "254", MatchFg: "255", MatchBg: "163",
+		Helm: "127", Service: "97",
+		Active: "26", Inactive: "245",
+		LogInfo: "31", LogTrace: "250", LogText: "235",
+		PodColors: []lipgloss.Color{"31", "28", "130", "127", "97", "166", "25", "64", "162", "136"},
+		Chroma:    "github",
+	},
+	"solarized-dark": {
+		Primary: "#268bd2", Secondary: "#2aa198", Green: "#859900", Red: "#dc322f", Yellow: "#b58900", Gray: "#657b83",
+		SelectedFg: "#fdf6e3", HeaderFg: "#93a1a1", HeaderBg: "#073642", CmdBarFg: "#93a1a1", CmdBarBg: "#073642", MatchFg: "#fdf6e3", MatchBg: "#d33682",
+		Helm: "#d33682", Service: "#6c71c4",
+		Active: "#268bd2", Inactive: "#586e75",
+		LogInfo: "#2aa198", LogTrace: "#586e75", LogText: "#839496",
+		PodColors: []lipgloss.Color{"#2aa198", "#859900", "#b58900", "#d33682", "#6c71c4", "#cb4b16", "#268bd2", "#93a1a1"},
+		Chroma:    "solarized-dark256",
+	},
+	"solarized-light": {
+		Primary: "#268bd2", Secondary: "#2aa198", Green: "#859900", Red: "#dc322f", Yellow: "#b58900", Gray: "#657b83",
+		SelectedFg: "#fdf6e3", HeaderFg: "#586e75", HeaderBg: "#eee8d5", CmdBarFg: "#586e75", CmdBarBg: "#eee8d5", MatchFg: "#fdf6e3", MatchBg: "#d33682",
+		Helm: "#d33682", Service: "#6c71c4",
+		Active: "#268bd2", Inactive: "#93a1a1",
+		LogInfo: "#2aa198", LogTrace: "#93a1a1", LogText: "#586e75",
+		PodColors: []lipgloss.Color{"#2aa198", "#859900", "#b58900", "#d33682", "#6c71c4", "#cb4b16", "#268bd2", "#586e75"},
+		Chroma:    "solarized-light",
+	},
+}
+
+// activeTheme is the palette the styles were last built from, by name
+var (
+	activeTheme     = themes[DefaultTheme]
+	activeThemeName = DefaultTheme
+)
+
+func init() {
+	buildStyles(activeTheme)
+}
+
+// SyntaxStyle overrides the theme's Chroma style ("" to follow the theme) and
+// SyntaxFormatter picks the terminal formatter (terminal16m for truecolor)
+var (
+	SyntaxStyle     string
+	SyntaxFormatter = parser.DefaultSyntaxFormatter
+)
+
+// syntaxSettings resolves the Chroma style and formatter: the environment
+// beats the config. Unknown names are logged and the defaults kept.
+func syntaxSettings(cfg Config) (style, formatter string) {
+	formatter = parser.DefaultSyntaxFormatter
+	name := os.Getenv(parser.EnvSyntaxStyle)
+	if name == "" {
+		name = cfg.SyntaxStyle
+	}
+	if name != "" {
+		resolved, err := parser.ResolveSyntaxStyle(name)
+		if err != nil {
+			slog.Warn("ignoring syntax style", "error", err)
+		}
+		style = resolved
+	}
+	name = os.Getenv(parser.EnvSyntaxFormatter)
+	if name == "" {
+		name = cfg.SyntaxFormatter
+	}
+	if name != "" {
+		resolved, err := parser.ResolveSyntaxFormatter(name)
+		if err != nil {
+			slog.Warn("ignoring syntax formatter", "error", err)
+			resolved = parser.DefaultSyntaxFormatter
+		}
+		formatter = resolved
+	}
+	return style, formatter
+}
+
+// syntaxStyle is the Chroma style YAML/JSON is highlighted with
+func syntaxStyle() string {
+	if SyntaxStyle != "" {
+		return SyntaxStyle
+	}
+	return activeTheme.Chroma
+}
+
+// themeNames lists the built-in themes, sorted
+func themeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// setTheme switches to a built-in theme by name
+func setTheme(name string) error {
+	t, ok := themes[strings.ToLower(name)]
+	if !ok {
+		return fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(themeNames(), ", "))
+	}
+	activeThemeName = strings.ToLower(name)
+	buildStyles(t)
+	return nil
+}
+
+// buildStyles (re)builds the UI colors and styles from a theme
+func buildStyles(t Theme) {
+	activeTheme = t
+
+	cPrimary = t.Primary
+	cSecondary = t.Secondary
+	cGreen = t.Green
+	cRed = t.Red
+	cYellow = t.Yellow
+	cGray = t.Gray
+	parser.Colors = parser.LogColors{
+		Red: t.Red, Yellow: t.Yellow, Green: t.Green, Gray: t.Gray,
+		Info: t.LogInfo, Trace: t.LogTrace, Text: t.LogText,
+		Pods: t.PodColors,
+	}
+
+	styleBorder = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1).BorderForeground(cGray)
+	stylePane = lipgloss.NewStyle().Padding(0, 1)
+	styleTitle = lipgloss.NewStyle().Foreground(cSecondary).Bold(true)
+	styleSelected = lipgloss.NewStyle().Foreground(t.SelectedFg).Background(cPrimary).Bold(true).Padding(0, 1)
+	styleDim = lipgloss.NewStyle().Foreground(cGray)
+	styleErr = lipgloss.NewStyle().Foreground(cRed)
+	styleHeader = lipgloss.NewStyle().Foreground(t.HeaderFg).Bold(true).Background(t.HeaderBg).Padding(0, 1)
+
+	styleTabActive = lipgloss.NewStyle().Border(lipgloss.NormalBorder(), false, false, true, false).BorderForeground(cPrimary).Foreground(cPrimary).Bold(true).Padding(0, 1)
+	styleTabInactive = lipgloss.NewStyle().Padding(0, 1).Foreground(cGray)
+
+	styleCmdBar = lipgloss.NewStyle().Foreground(t.CmdBarFg).Background(t.CmdBarBg).Padding(0, 1)
+
+	styleHighlight = lipgloss.NewStyle().Background(t.MatchBg).Foreground(t.MatchFg).Bold(true)
+
+	styleCard = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(cGray).Padding(0, 1).Width(DashboardCardWidth).Height(DashboardCardHeight - 2)
+	styleCardSelected = styleCard.BorderForeground(cPrimary)
+
+	styleDiffAdd = lipgloss.NewStyle().Foreground(cGreen)
+	styleDiffDel = lipgloss.NewStyle().Foreground(cRed)
+}
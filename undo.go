@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tidwall/gjson"
+
+	"github.com/devpopsdotin/k9s-deck/internal/k8s"
+)
+
+// --- SCALE UNDO ---
+
+// scaleChange is a replica change made by scale or undo
+type scaleChange struct {
+	target string
+	from   int // replicas before the change, -1 if they couldn't be read
+	to     int
+	undo   bool // the change was an :undo
+}
+
+// currentReplicas reads the replica count target is scaled to
+func currentReplicas(ctx context.Context, kc k8s.Client, ns string, target string) (int, error) {
+	out, err := getWorkload(ctx, kc, ns, target)
+	if err != nil {
+		return 0, err
+	}
+	replicas := gjson.GetBytes(out, "spec.replicas")
+	if !replicas.Exists() {
+		// The API server defaults an unset spec.replicas to 1
+		return 1, nil
+	}
+	return int(replicas.Int()), nil
+}
+
+// recordScale keeps the replicas before a scale for :undo; an undo uses up
+// the target's entry
+func (m *model) recordScale(change scaleChange) tea.Cmd {
+	if change.undo {
+		delete(m.scaleUndo, change.target)
+		m.statusMsg = fmt.Sprintf("Restored %s to %d replicas", change.target, change.to)
+		return clearStatusLater()
+	}
+	if change.from >= 0 && change.from != change.to {
+		m.scaleUndo[change.target] = change.from
+	}
+	return nil
+}
+
+// undoScale scales the selected target back to its replicas before its
+// last scale
+func (m *model) undoScale() tea.Cmd {
+	target := getCurrentDeploymentName(m.items, m.cursor)
+	if target == "" {
+		m.statusMsg = "No deployment selected"
+		return clearStatusLater()
+	}
+	from, ok := m.scaleUndo[target]
+	if !ok {
+		m.statusMsg = "Nothing to undo for " + target
+		return clearStatusLater()
+	}
+	return m.startCommand(fmt.Sprintf("undo %d", from), "", target)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/devpopsdotin/k9s-deck/internal/k8s"
+)
+
+// --- WAIT SUBCOMMAND ---
+
+// waitCondition is what `k9s-deck wait --for` blocks on
+type waitCondition string
+
+const (
+	waitAvailable waitCondition = "available" // as many replicas available as desired, old ones included
+	waitComplete  waitCondition = "complete"  // every replica updated and available
+)
+
+// waitUsage is printed for bad `k9s-deck wait` arguments
+const waitUsage = "Usage: k9s-deck wait [--timeout 5m] [--for available|complete] [--kubeconfig path] <context> <namespace> <deployment>"
+
+// runWait implements `k9s-deck wait`: it polls a deployment's rollout
+// without the TUI, printing progress to stdout, and returns the exit code
+// (0 once the condition is met, 1 on a failed rollout, timeout or bad usage)
+func runWait(args []string) int {
+	fs := flag.NewFlagSet("wait", flag.ContinueOnError)
+	timeout := fs.Duration("timeout", RolloutWatchTimeout, "give up after this long")
+	forFlag := fs.String("for", string(waitComplete), "wait until the rollout is available or complete")
+	kubeconfig := fs.String("kubeconfig", "", "path of the kubeconfig (default $KUBECONFIG or ~/.kube/config)")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, waitUsage)
+		fs.PrintDefaults()
+	}
+
+	// Flags may come before or after the positional arguments
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return 1
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(positional) != 3 {
+		fs.Usage()
+		return 1
+	}
+	cond := waitCondition(*forFlag)
+	if cond != waitAvailable && cond != waitComplete {
+		fmt.Fprintf(os.Stderr, "Error: --for must be %s or %s, not %q\n", waitAvailable, waitComplete, *forFlag)
+		return 1
+	}
+	if *timeout <= 0 {
+		fmt.Fprintf(os.Stderr, "Error: --timeout must be positive, not %s\n", *timeout)
+		return 1
+	}
+	if err := setKubeconfig(*kubeconfig); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	kc, err := k8s.NewClientWithOptions(positional[0], k8s.Options{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to create Kubernetes client: %v\n", err)
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	if err := waitForRollout(ctx, kc, positional[1], os.Stdout, positional[2], cond, RolloutPollInterval); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// waitMet reports whether status satisfies cond
+func waitMet(status k8s.RolloutStatus, cond waitCondition) bool {
+	if cond == waitAvailable {
+		return status.Available >= status.Desired
+	}
+	return status.Phase == k8s.RolloutComplete
+}
+
+// waitForRollout polls the rollout of deployment name in ns every interval
+// until cond is met, printing each new status to out. It fails when the
+// rollout exceeds its progress deadline, the deployment doesn't exist or ctx
+// ends; other API errors are printed and retried.
+func waitForRollout(ctx context.Context, kc k8s.Client, ns string, out io.Writer, name string, cond waitCondition, interval time.Duration) error {
+	var last string
+	for {
+		pollCtx, cancel := context.WithTimeout(ctx, CommandTimeout)
+		status, err := kc.GetRolloutStatus(pollCtx, ns, name)
+		cancel()
+
+		switch {
+		case errors.Is(err, k8s.ErrProgressDeadlineExceeded):
+			return fmt.Errorf("rollout of %s failed: %s", name, status.Message)
+		case k8s.IsNotFound(err):
+			return err
+		case err != nil && ctx.Err() == nil:
+			fmt.Fprintf(out, "%s: %v (retrying)\n", name, err)
+		case err == nil && waitMet(status, cond):
+			fmt.Fprintf(out, "%s: %s (%s)\n", name, cond, status.Message)
+			return nil
+		case err == nil && status.Message != last:
+			fmt.Fprintf(out, "%s: %s\n", name, status.Message)
+			last = status.Message
+		}
+
+		select {
+		case <-ctx.Done():
+			if last == "" {
+				return fmt.Errorf("timed out waiting for %s", name)
+			}
+			return fmt.Errorf("timed out waiting for %s: %s", name, last)
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devpopsdotin/k9s-deck/internal/k8s"
+)
+
+// rolloutMock serves statuses in turn, repeating the last one
+func rolloutMock(statuses []k8s.RolloutStatus, last error) *k8s.MockClient {
+	mock := k8s.NewMockClient()
+	polls := 0
+	mock.GetRolloutStatusFunc = func(ctx context.Context, namespace, name string) (k8s.RolloutStatus, error) {
+		status := statuses[min(polls, len(statuses)-1)]
+		polls++
+		if polls >= len(statuses) {
+			return status, last
+		}
+		return status, nil
+	}
+	return mock
+}
+
+func TestWaitForRollout(t *testing.T) {
+	progressing := k8s.RolloutStatus{Phase: k8s.RolloutProgressing, Message: "1 of 3 new replicas have been updated", Desired: 3, Updated: 1, Available: 3}
+	complete := k8s.RolloutStatus{Phase: k8s.RolloutComplete, Message: "3 of 3 replicas updated and available", Desired: 3, Updated: 3, Available: 3}
+	stuck := k8s.RolloutStatus{Phase: k8s.RolloutFailed, Message: "ReplicaSet web-2 has timed out progressing.", Desired: 3, Updated: 1, Available: 2}
+
+	tests := []struct {
+		name     string
+		statuses []k8s.RolloutStatus
+		last     error
+		cond     waitCondition
+		wantErr  string
+		wantOut  string
+	}{
+		{"complete", []k8s.RolloutStatus{progressing, progressing, complete}, nil, waitComplete, "", "web: complete (3 of 3"},
+		{"available before complete", []k8s.RolloutStatus{progressing, complete}, nil, waitAvailable, "", "web: available (1 of 3"},
+		{"progress deadline", []k8s.RolloutStatus{progressing, stuck}, fmt.Errorf("deployment web: %w", k8s.ErrProgressDeadlineExceeded), waitComplete, "timed out progressing", ""},
+		{"timeout", []k8s.RolloutStatus{progressing}, nil, waitComplete, "timed out waiting for web: 1 of 3", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			var out strings.Builder
+			err := waitForRollout(ctx, rolloutMock(tt.statuses, tt.last), "default", &out, "web", tt.cond, time.Millisecond)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("waitForRollout() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("waitForRollout() error = %v, want %q", err, tt.wantErr)
+			}
+			if !strings.Contains(out.String(), tt.wantOut) {
+				t.Errorf("waitForRollout() printed %q, want %q", out.String(), tt.wantOut)
+			}
+			if strings.Count(out.String(), progressing.Message) > 1 {
+				t.Errorf("an unchanged status should print once, got %q", out.String())
+			}
+		})
+	}
+}
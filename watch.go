@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/devpopsdotin/k9s-deck/internal/k8s"
+)
+
+// --- POD WATCHES ---
+
+const (
+	WatchDebounce       = 250 * time.Millisecond // pod events within this window cause one refresh
+	WatchResyncInterval = 30 * time.Second       // full re-list while every target is watched
+	WatchRetryInterval  = time.Minute            // before re-trying a watch that could not be opened
+)
+
+// podWatch is the pod watch of one target. events is nil until the watch
+// is open, and again once it failed or ended.
+type podWatch struct {
+	id       int
+	selector string
+	cancel   context.CancelFunc
+	events   <-chan k8s.PodEvent
+	retryAt  time.Time // zero while starting or open
+}
+
+type watchStartedMsg struct {
+	target string
+	id     int
+	events <-chan k8s.PodEvent
+	err    error
+}
+type podEventMsg struct {
+	target string
+	id     int
+	event  k8s.PodEvent
+}
+type watchEndedMsg struct {
+	target string
+	id     int
+}
+type watchRefreshMsg struct{}
+
+// startWatchCmd opens a pod watch for target's selector
+func startWatchCmd(ctx context.Context, kc k8s.Client, ns string, id int, target, selector string) tea.Cmd {
+	return func() tea.Msg {
+		events, err := kc.WatchPods(ctx, ns, selector)
+		return watchStartedMsg{target: target, id: id, events: events, err: err}
+	}
+}
+
+// waitPodEventCmd waits for the next event of an open watch
+func waitPodEventCmd(target string, id int, events <-chan k8s.PodEvent) tea.Cmd {
+	return func() tea.Msg {
+		ev, ok := <-events
+		if !ok {
+			return watchEndedMsg{target: target, id: id}
+		}
+		return podEventMsg{target: target, id: id, event: ev}
+	}
+}
+
+// syncWatches starts a watch for every target whose selector is known and
+// stops the ones of removed targets or changed selectors
+func (m *model) syncWatches() tea.Cmd {
+	if m.podsMode {
+		return nil
+	}
+	if m.watches == nil {
+		m.watches = make(map[string]*podWatch)
+	}
+
+	for target, w := range m.watches {
+		if !m.hasTarget(target) || m.selectors[target] != w.selector {
+			w.cancel()
+			delete(m.watches, target)
+		}
+	}
+
+	var cmds []tea.Cmd
+	now := time.Now()
+	for _, target := range m.targets {
+		selector := m.selectors[target]
+		if selector == "" {
+			continue
+		}
+		if w := m.watches[target]; w != nil {
+			if w.retryAt.IsZero() || now.Before(w.retryAt) {
+				continue
+			}
+			// Failed or ended: release it before it is reopened
+			w.cancel()
+		}
+		m.watchSeq++
+		ctx, cancel := context.WithCancel(context.Background())
+		m.watches[target] = &podWatch{id: m.watchSeq, selector: selector, cancel: cancel}
+		cmds = append(cmds, startWatchCmd(ctx, client, Namespace, m.watchSeq, target, selector))
+	}
+	return tea.Batch(cmds...)
+}
+
+// stopWatches cancels every pod watch
+func (m *model) stopWatches() {
+	for _, w := range m.watches {
+		w.cancel()
+	}
+	m.watches = nil
+}
+
+// watchingAll reports whether pod changes of every target are pushed, so
+// tick-driven re-lists can be slowed down to WatchResyncInterval
+func (m model) watchingAll() bool {
+	if m.podsMode || len(m.targets) == 0 {
+		return false
+	}
+	for _, target := range m.targets {
+		if w := m.watches[target]; w == nil || w.events == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// currentWatch returns the watch a message belongs to, nil if it is stale
+func (m model) currentWatch(target string, id int) *podWatch {
+	if w := m.watches[target]; w != nil && w.id == id {
+		return w
+	}
+	return nil
+}
+
+// handleWatchMsg updates the watches for watch and pod event messages
+func (m *model) handleWatchMsg(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case watchStartedMsg:
+		w := m.currentWatch(msg.target, msg.id)
+		if w == nil {
+			return nil
+		}
+		if msg.err != nil {
+			if errors.Is(msg.err, k8s.ErrWatchNotSupported) {
+				slog.Debug("pod watch unavailable, polling", "target", msg.target)
+			} else {
+				slog.Warn("pod watch failed, polling", "target", msg.target, "error", msg.err)
+			}
+			w.retryAt = time.Now().Add(WatchRetryInterval)
+			return nil
+		}
+		w.events = msg.events
+		return waitPodEventCmd(msg.target, msg.id, msg.events)
+
+	case podEventMsg:
+		w := m.currentWatch(msg.target, msg.id)
+		if w == nil {
+			return nil
+		}
+		slog.Debug("pod event", "target", msg.target, "type", msg.event.Type, "pod", msg.event.Pod.Name)
+		return tea.Batch(waitPodEventCmd(msg.target, msg.id, w.events), m.scheduleWatchRefresh())
+
+	case watchEndedMsg:
+		w := m.currentWatch(msg.target, msg.id)
+		if w == nil {
+			return nil
+		}
+		// Polling takes over until the next refresh re-opens it
+		w.events = nil
+		w.retryAt = time.Now()
+		return nil
+
+	case watchRefreshMsg:
+		m.watchRefreshPending = false
+		if m.paused {
+			// Pod events are picked up on resume
+			return nil
+		}
+		if m.fetching {
+			// Let the refresh in flight land first
+			return m.scheduleWatchRefresh()
+		}
+		m.fetching = true
+		return m.refreshCmd()
+	}
+	return nil
+}
+
+// scheduleWatchRefresh refreshes once WatchDebounce after the first of a
+// burst of pod events (a rollout changes many pods at once)
+func (m *model) scheduleWatchRefresh() tea.Cmd {
+	if m.watchRefreshPending {
+		return nil
+	}
+	m.watchRefreshPending = true
+	return tea.Tick(WatchDebounce, func(t time.Time) tea.Msg {
+		return watchRefreshMsg{}
+	})
+}
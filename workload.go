@@ -0,0 +1,534 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tidwall/gjson"
+
+	"github.com/devpopsdotin/k9s-deck/internal/k8s"
+)
+
+// --- WORKLOADS ---
+
+// Target prefixes: "sts/db" is a StatefulSet, "ds/node-agent" a DaemonSet,
+// "rs/legacy" a ReplicaSet; targets without a prefix are deployments
+const (
+	StatefulSetPrefix = "sts/"
+	DaemonSetPrefix   = "ds/"
+	ReplicaSetPrefix  = "rs/"
+)
+
+// workloadTypes maps the item type of each kind of workload but DEP to its
+// target prefix and Kubernetes kind
+var workloadTypes = map[string]struct{ prefix, kind string }{
+	"STS": {StatefulSetPrefix, "StatefulSet"},
+	"DS":  {DaemonSetPrefix, "DaemonSet"},
+	"RS":  {ReplicaSetPrefix, "ReplicaSet"},
+}
+
+// parseTarget splits a target into the item type it is listed as ("DEP",
+// "STS", "DS" or "RS") and the workload's name
+func parseTarget(target string) (string, string) {
+	for itemType, w := range workloadTypes {
+		if name, ok := strings.CutPrefix(target, w.prefix); ok {
+			return itemType, name
+		}
+	}
+	return "DEP", target
+}
+
+// targetOf returns the target a workload item was listed for
+func targetOf(it item) string {
+	if w, ok := workloadTypes[it.Type]; ok {
+		return w.prefix + it.Name
+	}
+	return it.Name
+}
+
+// kindTarget returns the target for a workload of a Kubernetes kind (one
+// of k8s.WorkloadKinds)
+func kindTarget(kind, name string) string {
+	for _, w := range workloadTypes {
+		if w.kind == kind {
+			return w.prefix + name
+		}
+	}
+	return name
+}
+
+// isWorkload reports whether an item type heads a group of pods
+func isWorkload(itemType string) bool {
+	_, ok := workloadTypes[itemType]
+	return ok || itemType == "DEP"
+}
+
+// isValidTarget reports whether target names a deployment, or another
+// workload with its prefix
+func isValidTarget(target string) bool {
+	_, name := parseTarget(target)
+	return isValidK8sName(name)
+}
+
+// getWorkload fetches the workload a target names
+func getWorkload(ctx context.Context, kc k8s.Client, ns string, target string) ([]byte, error) {
+	switch kind, name := parseTarget(target); kind {
+	case "STS":
+		return kc.GetStatefulSet(ctx, ns, name)
+	case "DS":
+		return kc.GetDaemonSet(ctx, ns, name)
+	case "RS":
+		return kc.GetResource(ctx, ns, "replicaset", name, "json")
+	default:
+		return kc.GetDeployment(ctx, ns, name)
+	}
+}
+
+// workloadActionError says why verb ("scale" or "restart") can't run on a
+// kind of workload, nil if it can
+func workloadActionError(itemType, verb string) error {
+	switch {
+	case itemType == "DS" && verb == "scale":
+		// One pod runs per matching node
+		return errors.New("DaemonSets cannot be scaled")
+	case itemType == "RS" && verb == "scale":
+		return errors.New("ReplicaSets cannot be scaled here, scale the deployment that owns them")
+	case itemType == "RS" && verb == "restart":
+		return errors.New("ReplicaSets cannot be restarted here, restart the deployment that owns them")
+	}
+	return nil
+}
+
+// resolveTarget turns a bare name into the target of the workload it
+// actually is, e.g. "sts/db" when db is a StatefulSet
+func resolveTarget(ctx context.Context, kc k8s.Client, ns string, name string) (string, error) {
+	kind, err := kc.ResolveWorkload(ctx, ns, name)
+	if err != nil {
+		return "", err
+	}
+	return kindTarget(kind, name), nil
+}
+
+// jumpType is the item type the number keys jump by: '1' lands on every
+// kind of workload
+func jumpType(itemType string) string {
+	if isWorkload(itemType) {
+		return "DEP"
+	}
+	return itemType
+}
+
+// sortByOrdinal orders a statefulset's pods by their stable ordinal (db-2
+// before db-10); pods that don't follow the naming go last, by name
+func sortByOrdinal(pods []item, statefulSet string) {
+	sort.SliceStable(pods, func(a, b int) bool {
+		oa, okA := k8s.StatefulSetOrdinal(statefulSet, pods[a].Name)
+		ob, okB := k8s.StatefulSetOrdinal(statefulSet, pods[b].Name)
+		if okA != okB {
+			return okA
+		}
+		if okA {
+			return oa < ob
+		}
+		return pods[a].Name < pods[b].Name
+	})
+}
+
+// Pod sort orders cycled with 'o'; the default keeps the API order (and
+// statefulset ordinals)
+var podSortKeys = []string{"", "status", "name", "age"}
+
+// nextPodSort returns the sort order after key
+func nextPodSort(key string) string {
+	for i, k := range podSortKeys {
+		if k == key {
+			return podSortKeys[(i+1)%len(podSortKeys)]
+		}
+	}
+	return ""
+}
+
+// podSortLabel names a sort order for the status line and footer
+func podSortLabel(key string) string {
+	switch key {
+	case "status":
+		return "unhealthy first"
+	case "age":
+		return "newest first"
+	case "":
+		return "API order"
+	}
+	return key
+}
+
+// sortPods reorders each run of POD rows by key, leaving headers, workloads
+// and the other rows of a group where they are
+func sortPods(items []item, key string) {
+	if key == "" {
+		return
+	}
+	for start := 0; start < len(items); start++ {
+		if items[start].Type != "POD" {
+			continue
+		}
+		end := start
+		for end < len(items) && items[end].Type == "POD" {
+			end++
+		}
+		pods := items[start:end]
+		sort.SliceStable(pods, func(a, b int) bool {
+			switch key {
+			case "status":
+				// Failing before pending (incl. Terminating) before running
+				return podHealth(pods[a].Status) > podHealth(pods[b].Status)
+			case "age":
+				return pods[a].Created.After(pods[b].Created)
+			}
+			return pods[a].Name < pods[b].Name
+		})
+		start = end
+	}
+}
+
+// cyclePodSort switches to the next pod order and applies it right away,
+// keeping the selection. The API order comes back with a refresh.
+func (m *model) cyclePodSort() tea.Cmd {
+	m.podSort = nextPodSort(m.podSort)
+	if len(m.items) > 0 {
+		selected := m.items[m.cursor]
+		sortPods(m.items, m.podSort)
+		for i, it := range m.items {
+			if it.Type == selected.Type && it.Name == selected.Name {
+				m.cursor = i
+				break
+			}
+		}
+	}
+	m.statusMsg = "Pods: " + podSortLabel(m.podSort)
+	if m.podSort == "" {
+		return tea.Batch(m.refreshCmd(), clearStatusLater())
+	}
+	return clearStatusLater()
+}
+
+// daemonSetStatus summarizes a daemonset's node counts as
+// "desired/ready/available"
+func daemonSetStatus(jsonRaw string) string {
+	return fmt.Sprintf("%d/%d/%d",
+		gjson.Get(jsonRaw, "status.desiredNumberScheduled").Int(),
+		gjson.Get(jsonRaw, "status.numberReady").Int(),
+		gjson.Get(jsonRaw, "status.numberAvailable").Int())
+}
+
+// replicaCounts parses the "ready/desired" status of a DEP, STS or RS item
+func replicaCounts(status string) (ready, desired int, ok bool) {
+	_, err := fmt.Sscanf(status, "%d/%d", &ready, &desired)
+	return ready, desired, err == nil
+}
+
+// scaledToZero reports whether a DEP, STS or RS item wants no pods
+func scaledToZero(it item) bool {
+	_, desired, ok := replicaCounts(it.Status)
+	return it.Type != "DS" && isWorkload(it.Type) && ok && desired == 0
+}
+
+// daemonSetCounts parses a daemonSetStatus
+func daemonSetCounts(status string) (desired, ready, available int, ok bool) {
+	_, err := fmt.Sscanf(status, "%d/%d/%d", &desired, &ready, &available)
+	return desired, ready, available, err == nil
+}
+
+// imageSummary shortens a workload's comma-separated images to the first
+// one without its registry, "+N" for the other containers
+func imageSummary(images string) string {
+	if images == "" {
+		return ""
+	}
+	parts := strings.Split(images, ",")
+	summary := shortImage(parts[0])
+	if len(parts) > 1 {
+		summary += fmt.Sprintf(" +%d", len(parts)-1)
+	}
+	return summary
+}
+
+// rolloutSummary is the "Image: ... · Revision: N" line heading a
+// workload's YAML, "" when neither is known
+func rolloutSummary(it item) string {
+	var parts []string
+	if it.Image != "" {
+		parts = append(parts, "Image: "+strings.ReplaceAll(it.Image, ",", ", "))
+	}
+	if it.Revision != "" {
+		parts = append(parts, "Revision: "+it.Revision)
+	}
+	return strings.Join(parts, " · ")
+}
+
+// resolveAddCmd finds which kind of workload a bare name given to :add is
+func resolveAddCmd(kc k8s.Client, ns string, name string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), CommandTimeout)
+		defer cancel()
+
+		target, err := resolveTarget(ctx, kc, ns, name)
+		if err != nil {
+			return detailsMsg{err: fmt.Errorf("Cannot add %s: %v", name, err)}
+		}
+		return addTargetMsg{name: target}
+	}
+}
+
+// newTargetSet returns targets as a set
+func newTargetSet(targets []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(targets))
+	for _, t := range targets {
+		set[t] = struct{}{}
+	}
+	return set
+}
+
+// setTargets replaces the monitored targets, keeping targetSet in sync
+func (m *model) setTargets(targets []string) {
+	m.targets = targets
+	m.targetSet = newTargetSet(targets)
+}
+
+// hasTarget reports whether target is monitored
+func (m model) hasTarget(target string) bool {
+	_, ok := m.targetSet[target]
+	return ok
+}
+
+// addTarget validates a target given to :add or the '+' prompt and starts
+// monitoring it. A bare name may be a StatefulSet, DaemonSet or ReplicaSet,
+// so it is resolved to its workload kind first.
+func (m *model) addTarget(name string) tea.Cmd {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		m.rawContent = "Usage: add <name> (sts/<name>, ds/<name> or rs/<name> for other workloads)"
+		m.updateViewportContent()
+		return nil
+	}
+	if !isValidTarget(name) {
+		m.rawContent = "Invalid deployment name (sts/<name> for a statefulset, ds/<name> for a daemonset). Must be lowercase alphanumeric with hyphens only."
+		m.updateViewportContent()
+		return nil
+	}
+	if kind, _ := parseTarget(name); kind == "DEP" {
+		return resolveAddCmd(client, Namespace, name)
+	}
+	return m.monitorTarget(name)
+}
+
+// monitorTarget adds a resolved target unless it is already monitored, and
+// refetches either way
+func (m *model) monitorTarget(target string) tea.Cmd {
+	if m.hasTarget(target) {
+		m.statusMsg = target + " is already monitored"
+		return tea.Batch(fetchDataCmd(client, Context, Namespace, m.dataGen, m.targets, copySelectorMap(m.manualSelectors)), clearStatusLater())
+	}
+	m.setTargets(append(m.targets, target))
+	return tea.Batch(fetchDataCmd(client, Context, Namespace, m.dataGen, m.targets, copySelectorMap(m.manualSelectors)), m.scheduleConfigSave())
+}
+
+// renameTargets replaces bare targets with the ones of the kind of workload
+// they turned out to be, keeping their :selector overrides
+func (m *model) renameTargets(renamed map[string]string) tea.Cmd {
+	var targets []string
+	for _, t := range m.targets {
+		if to, ok := renamed[t]; ok {
+			if selector, ok := m.manualSelectors[t]; ok {
+				delete(m.manualSelectors, t)
+				m.manualSelectors[to] = selector
+			}
+			t = to
+		}
+		if !containsString(targets, t) {
+			targets = append(targets, t)
+		}
+	}
+	m.setTargets(targets)
+	return m.scheduleConfigSave()
+}
+
+// parsedWorkload is everything fetchDataCmd derives from a workload's JSON
+// alone. It only changes with the object's resourceVersion, so ticks that
+// see the same version reuse it and just refresh services and pods.
+type parsedWorkload struct {
+	items       []item // the workload and its Helm release
+	refs        []item // referenced secrets and config maps, pull secrets last
+	helmRelease string
+	selector    string // spec.selector as "k=v,k in (a,b),..."
+	podLabels   map[string]gjson.Result
+	anomalies   []string
+}
+
+// workloadKey scopes a target's cached parse to its context and namespace,
+// so a refresh still running after :ctx or :ns can't serve the old cluster's
+type workloadKey struct {
+	context   string
+	namespace string
+	target    string
+}
+
+// cachedWorkload is a target's parsedWorkload at resourceVersion
+type cachedWorkload struct {
+	resourceVersion string
+	parsed          *parsedWorkload
+}
+
+// workloadCache holds the parsedWorkload of each target; fetchDataCmd's
+// goroutines share it, so access is guarded by mu
+type workloadCache struct {
+	mu      sync.Mutex
+	entries map[workloadKey]cachedWorkload
+}
+
+// parsedWorkloads is the cache parseWorkloadCached reads and fills
+var parsedWorkloads = &workloadCache{entries: make(map[workloadKey]cachedWorkload)}
+
+// get returns the parse of key as long as it was made at resourceVersion
+func (c *workloadCache) get(key workloadKey, resourceVersion string) (*parsedWorkload, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok || resourceVersion == "" || e.resourceVersion != resourceVersion {
+		return nil, false
+	}
+	return e.parsed, true
+}
+
+// set stores the parse of key at resourceVersion; objects without one are
+// not cached
+func (c *workloadCache) set(key workloadKey, resourceVersion string, parsed *parsedWorkload) {
+	if resourceVersion == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = cachedWorkload{resourceVersion: resourceVersion, parsed: parsed}
+}
+
+// delete forgets the parse of key
+func (c *workloadCache) delete(key workloadKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// clear forgets every parse
+func (c *workloadCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[workloadKey]cachedWorkload)
+}
+
+// parseWorkloadCached returns the parsedWorkload of target, parsing jsonRaw
+// only when its resourceVersion differs from the cached one
+func parseWorkloadCached(kubeContext, ns, target, kind, name, jsonRaw string) *parsedWorkload {
+	key := workloadKey{context: kubeContext, namespace: ns, target: target}
+	version := gjson.Get(jsonRaw, "metadata.resourceVersion").String()
+	if cached, ok := parsedWorkloads.get(key, version); ok {
+		return cached
+	}
+	parsed := parseWorkload(kind, name, jsonRaw)
+	parsedWorkloads.set(key, version, parsed)
+	return parsed
+}
+
+// parseWorkload extracts the sidebar items, selector and pod labels of a
+// workload from its JSON
+func parseWorkload(kind, name, jsonRaw string) *parsedWorkload {
+	// Missing paths would otherwise just yield an empty group
+	p := &parsedWorkload{anomalies: deploymentShapeProblems(jsonRaw)}
+
+	var images []string
+	gjson.Get(jsonRaw, "spec.template.spec.containers.#.image").ForEach(func(_, v gjson.Result) bool {
+		images = append(images, v.String())
+		return true
+	})
+	desired := int64(1) // the API server's default for an unset spec.replicas
+	if r := gjson.Get(jsonRaw, "spec.replicas"); r.Exists() {
+		desired = r.Int()
+	}
+	replicaStatus := fmt.Sprintf("%d/%d", gjson.Get(jsonRaw, "status.readyReplicas").Int(), desired)
+	if kind == "DS" {
+		replicaStatus = daemonSetStatus(jsonRaw)
+	}
+	workload := item{Type: kind, Name: name, Status: replicaStatus, Image: strings.Join(images, ",")}
+	if kind == "DEP" {
+		workload.Revision = gjson.Get(jsonRaw, `metadata.annotations.deployment\.kubernetes\.io/revision`).String()
+	}
+	p.items = append(p.items, workload)
+
+	// Helm
+	p.helmRelease = gjson.Get(jsonRaw, `metadata.annotations.meta\.helm\.sh/release-name`).String()
+	if p.helmRelease != "" {
+		p.items = append(p.items, item{Type: "HELM", Name: p.helmRelease, Status: "Release"})
+	}
+
+	p.podLabels = gjson.Get(jsonRaw, "spec.template.metadata.labels").Map()
+
+	// Secrets/CM
+	seenSecrets := make(map[string]bool)
+	seenConfigMaps := make(map[string]bool)
+	addSecret := func(name, status string) {
+		if name != "" && !seenSecrets[name] {
+			seenSecrets[name] = true
+			p.refs = append(p.refs, item{Type: "SEC", Name: name, Status: status})
+		}
+	}
+	addConfigMap := func(name string) {
+		if name != "" && !seenConfigMaps[name] {
+			seenConfigMaps[name] = true
+			p.refs = append(p.refs, item{Type: "CM", Name: name, Status: "Ref"})
+		}
+	}
+
+	for _, c := range gjson.Get(jsonRaw, "spec.template.spec.containers").Array() {
+		// Check envFrom
+		c.Get("envFrom").ForEach(func(_, v gjson.Result) bool {
+			addSecret(v.Get("secretRef.name").String(), "Ref")
+			addConfigMap(v.Get("configMapRef.name").String())
+			return true
+		})
+		// Check env
+		c.Get("env").ForEach(func(_, v gjson.Result) bool {
+			addSecret(v.Get("valueFrom.secretKeyRef.name").String(), "Ref")
+			addConfigMap(v.Get("valueFrom.configMapKeyRef.name").String())
+			return true
+		})
+	}
+
+	// Check volumes
+	gjson.Get(jsonRaw, "spec.template.spec.volumes").ForEach(func(_, v gjson.Result) bool {
+		addSecret(v.Get("secret.secretName").String(), "Ref")
+		addConfigMap(v.Get("configMap.name").String())
+		return true
+	})
+
+	// Image pull secrets (tagged "Pull" so they stand apart from env/volume
+	// refs), listed even when a container already references them
+	seenPull := make(map[string]bool)
+	gjson.Get(jsonRaw, "spec.template.spec.imagePullSecrets.#.name").ForEach(func(_, v gjson.Result) bool {
+		if name := v.String(); name != "" && !seenPull[name] {
+			seenPull[name] = true
+			p.refs = append(p.refs, item{Type: "SEC", Name: name, Status: "Pull"})
+		}
+		return true
+	})
+
+	// Pods
+	selector, err := k8s.LabelSelector([]byte(jsonRaw))
+	if err != nil {
+		p.anomalies = append(p.anomalies, err.Error())
+	}
+	p.selector = selector
+	return p
+}
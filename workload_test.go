@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Compare parsing a deployment every tick with reusing the parse while its
+// resourceVersion is unchanged
+// Run with: go test -bench=ParseWorkload -benchmem .
+
+// benchmarkDeployment is a deployment with a Helm release, env, volume and
+// pull secret references
+func benchmarkDeployment(resourceVersion string) string {
+	env := ""
+	for i := 0; i < 20; i++ {
+		env += fmt.Sprintf(`{"name":"VAR_%d","valueFrom":{"secretKeyRef":{"name":"secret-%d","key":"k"}}},`, i, i%5)
+	}
+	return `{"metadata":{"name":"web","resourceVersion":"` + resourceVersion + `",
+		"annotations":{"meta.helm.sh/release-name":"web","deployment.kubernetes.io/revision":"4"}},
+	"spec":{"replicas":3,"selector":{"matchLabels":{"app":"web","tier":"frontend"}},
+		"template":{"metadata":{"labels":{"app":"web","tier":"frontend"}},
+			"spec":{"containers":[{"name":"web","image":"nginx:1.27",
+				"envFrom":[{"configMapRef":{"name":"web-config"}}],
+				"env":[` + env + `{"name":"MODE","value":"prod"}]}],
+			"volumes":[{"name":"tls","secret":{"secretName":"web-tls"}},{"name":"conf","configMap":{"name":"nginx-conf"}}],
+			"imagePullSecrets":[{"name":"registry"}]}}},
+	"status":{"readyReplicas":3}}`
+}
+
+func BenchmarkParseWorkload(b *testing.B) {
+	jsonRaw := benchmarkDeployment("100")
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		parseWorkload("DEP", "web", jsonRaw)
+	}
+}
+
+func BenchmarkParseWorkloadCached(b *testing.B) {
+	jsonRaw := benchmarkDeployment("100")
+	defer parsedWorkloads.clear()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		parseWorkloadCached("kind-kind", "default", "web", "DEP", "web", jsonRaw)
+	}
+}
+
+func TestParseWorkloadCached(t *testing.T) {
+	defer parsedWorkloads.clear()
+
+	first := parseWorkloadCached("kind-kind", "default", "web", "DEP", "web", benchmarkDeployment("100"))
+	if got := parseWorkloadCached("kind-kind", "default", "web", "DEP", "web", benchmarkDeployment("100")); got != first {
+		t.Error("Expected the same resourceVersion to reuse the parse")
+	}
+	if got := parseWorkloadCached("kind-kind", "default", "web", "DEP", "web", benchmarkDeployment("101")); got == first {
+		t.Error("Expected a new resourceVersion to be parsed again")
+	}
+	if got := parseWorkloadCached("prod", "default", "web", "DEP", "web", benchmarkDeployment("100")); got == first {
+		t.Error("Expected another context's web to be parsed on its own")
+	}
+
+	if first.helmRelease != "web" || first.selector != "app=web,tier=frontend" {
+		t.Errorf("helmRelease = %q, selector = %q", first.helmRelease, first.selector)
+	}
+	if dep := first.items[0]; dep.Image != "nginx:1.27" || dep.Revision != "4" {
+		t.Errorf("Image = %q, Revision = %q", dep.Image, dep.Revision)
+	}
+	// 5 env secrets, web-tls, the pull secret and 2 config maps
+	if len(first.refs) != 9 {
+		t.Errorf("Expected 9 refs, got %d: %v", len(first.refs), first.refs)
+	}
+	if last := first.refs[len(first.refs)-1]; last.Name != "registry" || last.Status != "Pull" {
+		t.Errorf("Expected the pull secret last, got %+v", last)
+	}
+}
+
+func TestImageSummary(t *testing.T) {
+	tests := []struct {
+		images string
+		want   string
+	}{
+		{"", ""},
+		{"nginx:1.27", "nginx:1.27"},
+		{"ghcr.io/acme/api:v2.1.0", "api:v2.1.0"},
+		{"ghcr.io/acme/api:v2.1.0,envoyproxy/envoy:v1.30,busybox", "api:v2.1.0 +2"},
+	}
+	for _, tt := range tests {
+		if got := imageSummary(tt.images); got != tt.want {
+			t.Errorf("imageSummary(%q) = %q, want %q", tt.images, got, tt.want)
+		}
+	}
+}